@@ -0,0 +1,28 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+# Fail early if the cluster runs HyperCore older than 9.4
+data "hypercore_cluster" "cluster" {
+  min_version = "9.4"
+}
+
+output "hypercore_cluster_version" {
+  value = data.hypercore_cluster.cluster.version
+}
+
+# Check there is enough free memory before creating a 4 GiB VM
+locals {
+  vm_memory_bytes = 4 * 1024 * 1024 * 1024
+}
+
+resource "hypercore_vm" "myvm" {
+  name   = "myvm"
+  memory = local.vm_memory_bytes / 1024 / 1024
+
+  lifecycle {
+    precondition {
+      condition     = data.hypercore_cluster.cluster.memory_free_bytes >= local.vm_memory_bytes
+      error_message = "Cluster ${data.hypercore_cluster.cluster.name} does not have enough free memory."
+    }
+  }
+}
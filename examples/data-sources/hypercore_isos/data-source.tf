@@ -0,0 +1,20 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+data "hypercore_isos" "virtio" {
+  name = "virtio-win.iso"
+}
+
+output "virtio_iso_uuid" {
+  value = data.hypercore_isos.virtio.isos.0.uuid
+}
+
+# All Ubuntu ISOs, with VMs which have them inserted
+data "hypercore_isos" "ubuntu" {
+  name_prefix = "ubuntu-"
+  name_regex  = "\\.iso$"
+}
+
+output "ubuntu_iso_usage" {
+  value = { for iso in data.hypercore_isos.ubuntu.isos : iso.name => iso.vm_uuids }
+}
@@ -17,3 +17,27 @@ data "hypercore_nodes" "node_1" {
 output "hypercore_nodes_1_uuid" {
   value = data.hypercore_nodes.node_1.nodes.0.uuid
 }
+
+# Place a VM on the schedulable node with the most free memory,
+# and use the next one as backup
+data "hypercore_nodes" "schedulable" {
+  schedulable = true
+}
+
+locals {
+  nodes_by_free_memory = reverse(sort([
+    for node in data.hypercore_nodes.schedulable.nodes :
+    format("%020d %s", node.memory_free_bytes, node.uuid)
+  ]))
+}
+
+resource "hypercore_vm" "myvm" {
+  name   = "myvm"
+  memory = 4096 # MiB
+
+  affinity_strategy = {
+    strict_affinity     = false
+    preferred_node_uuid = split(" ", local.nodes_by_free_memory[0])[1]
+    backup_node_uuid    = length(local.nodes_by_free_memory) > 1 ? split(" ", local.nodes_by_free_memory[1])[1] : ""
+  }
+}
@@ -0,0 +1,19 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+data "hypercore_virtual_disks" "ubuntu" {
+  name = "ubuntu-22.04-server-cloudimg-amd64.img"
+}
+
+output "ubuntu_virtual_disk_uuid" {
+  value = data.hypercore_virtual_disks.ubuntu.virtual_disks.0.uuid
+}
+
+# All cloud images
+data "hypercore_virtual_disks" "cloud_images" {
+  name_regex = "cloudimg"
+}
+
+output "cloud_image_sizes" {
+  value = { for vd in data.hypercore_virtual_disks.cloud_images.virtual_disks : vd.name => vd.size_bytes }
+}
@@ -0,0 +1,18 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+data "hypercore_vm" "templatevm" {
+  name = "ubuntu-22.04-server-cloudimg-amd64.img"
+}
+
+data "hypercore_vm" "by_uuid" {
+  uuid = "2a9a9bde-4c4e-4c3f-9c1e-1c2b7f6f6a11"
+}
+
+output "templatevm_uuid" {
+  value = data.hypercore_vm.templatevm.uuid
+}
+
+output "by_uuid_disks" {
+  value = data.hypercore_vm.by_uuid.vm.disks
+}
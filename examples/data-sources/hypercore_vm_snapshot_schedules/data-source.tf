@@ -0,0 +1,23 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+# Reference the pre-existing "Daily" schedule instead of creating a duplicate
+data "hypercore_vm_snapshot_schedules" "daily" {
+  name = "Daily"
+}
+
+resource "hypercore_vm_snapshot_schedule_assignment" "daily" {
+  schedule_uuid = data.hypercore_vm_snapshot_schedules.daily.schedules.0.uuid
+  tags_any      = ["prod"]
+}
+
+output "daily_rules" {
+  value = data.hypercore_vm_snapshot_schedules.daily.schedules.0.rules
+}
+
+# All schedules, with VMs using each of them
+data "hypercore_vm_snapshot_schedules" "all" {}
+
+output "schedule_vm_uuids" {
+  value = { for s in data.hypercore_vm_snapshot_schedules.all.schedules : s.name => s.vm_uuids }
+}
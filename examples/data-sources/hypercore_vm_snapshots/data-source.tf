@@ -0,0 +1,28 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+data "hypercore_vms" "app" {
+  name = "app-1"
+}
+
+# The most recent nightly snapshot of the VM
+data "hypercore_vm_snapshots" "nightly" {
+  vm_uuid     = data.hypercore_vms.app.vms.0.uuid
+  type        = "AUTOMATED"
+  label_regex = "^nightly"
+}
+
+output "latest_nightly_snapshot_uuid" {
+  value = data.hypercore_vm_snapshots.nightly.latest.uuid
+}
+
+# All manual snapshots taken during January 2030
+data "hypercore_vm_snapshots" "january" {
+  type           = "USER"
+  created_after  = "2030-01-01T00:00:00Z"
+  created_before = "2030-02-01T00:00:00Z"
+}
+
+output "january_snapshot_labels" {
+  value = data.hypercore_vm_snapshots.january.snapshots[*].label
+}
@@ -8,3 +8,20 @@ data "hypercore_vms" "templatevm" {
 output "templatevm_uuid" {
   value = data.hypercore_vms.templatevm.vms.0.uuid
 }
+
+# All stopped VMs tagged as templates, with name starting with "ubuntu-"
+data "hypercore_vms" "ubuntu_templates" {
+  tags_all    = ["template"]
+  name_prefix = "ubuntu-"
+  power_state = "SHUTOFF"
+}
+
+# VMs tagged either "web" or "db", with name matching a regex
+data "hypercore_vms" "app_vms" {
+  tags_any   = ["web", "db"]
+  name_regex = "^app-[0-9]+$"
+}
+
+output "ubuntu_template_uuids" {
+  value = data.hypercore_vms.ubuntu_templates.vms[*].uuid
+}
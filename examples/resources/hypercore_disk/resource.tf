@@ -21,6 +21,33 @@ resource "hypercore_disk" "disk_newly_created" {
   flash_priority = 5 # defaults to 4 if not provided
 }
 
+# Scratch disk, excluded from VM snapshots
+resource "hypercore_disk" "disk_scratch" {
+  vm_uuid              = data.hypercore_vms.diskvm.vms.0.uuid
+  type                 = "VIRTIO_DISK"
+  size                 = 20.0
+  cache_mode           = "WRITEBACK" # WRITETHROUGH, WRITEBACK or NONE
+  disable_snapshotting = true
+}
+
+# Size can also be given in bytes (size_bytes) or in GiB (size_gib),
+# the unit used by HC3 UI.
+resource "hypercore_disk" "disk_gib" {
+  vm_uuid  = data.hypercore_vms.diskvm.vms.0.uuid
+  type     = "VIRTIO_DISK"
+  size_gib = 16
+}
+
+# Shrinking a disk or changing its type is not possible in place.
+# With on_incompatible_change = "replace" such changes recreate the disk,
+# otherwise (default "fail") they are rejected at plan time.
+resource "hypercore_disk" "disk_replaceable" {
+  vm_uuid                = data.hypercore_vms.diskvm.vms.0.uuid
+  type                   = "VIRTIO_DISK"
+  size                   = 10.0
+  on_incompatible_change = "replace"
+}
+
 output "diskvm_uuid" {
   value = data.hypercore_vms.diskvm.vms.0.uuid
 }
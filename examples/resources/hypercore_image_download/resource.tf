@@ -0,0 +1,19 @@
+# Download an ISO by name
+resource "hypercore_image_download" "installer_iso" {
+  source_type = "iso"
+  source_name = "alpine-virt-3.21.3.iso"
+  path        = "${path.module}/artifacts/alpine-virt-3.21.3.iso"
+}
+
+# Download a virtual disk by UUID
+resource "hypercore_image_download" "golden_image" {
+  source_type = "virtual_disk"
+  source_uuid = "11424aec-0511-41c2-8be9-7fd9fb5e5138"
+  path        = "${path.module}/artifacts/golden-image.img"
+}
+
+# The file is downloaded again only if it was changed or removed locally,
+# or if the image size on HC3 changed.
+output "golden_image_sha256" {
+  value = hypercore_image_download.golden_image.sha256
+}
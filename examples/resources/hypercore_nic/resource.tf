@@ -18,6 +18,19 @@ resource "hypercore_nic" "net_newly_created" {
   type    = "VIRTIO"
 }
 
+# NIC with fixed MAC address, disconnected like an unplugged network cable
+resource "hypercore_nic" "net_disconnected" {
+  vm_uuid     = data.hypercore_vms.nicvm.vms.0.uuid
+  vlan        = 12
+  type        = "INTEL_E1000"
+  mac_address = "52:54:00:ab:cd:ef"
+  connected   = false
+}
+
+output "net_newly_created_ipv4_addresses" {
+  value = hypercore_nic.net_newly_created.ipv4_addresses
+}
+
 output "nicvm_uuid" {
   value = data.hypercore_vms.nicvm.vms.0.uuid
 }
@@ -26,6 +39,6 @@ output "nicvm_uuid" {
 import {
   to = hypercore_nic.net_cloned
 
-  # import id consists of three parts: vm_uuid:nic_type:nic_slot
-  id = format("%s:%s:%d", data.hypercore_vms.nicvm.vms.0.uuid, "INTEL_E1000", 1)
+  # import id is vm_uuid:mac_address, vm_uuid:nic_index (0-based, NICs ordered by slot) or vm_uuid:nic_type:nic_vlan
+  id = format("%s:%s", data.hypercore_vms.nicvm.vms.0.uuid, "7C:4C:58:12:34:56")
 }
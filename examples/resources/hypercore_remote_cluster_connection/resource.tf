@@ -0,0 +1,44 @@
+variable "remote_cluster_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+# Pair with the DR cluster and wait until replication over the link works.
+resource "hypercore_remote_cluster_connection" "dr" {
+  remote_host = "10.5.11.200"
+  username    = "admin"
+  password    = var.remote_cluster_password
+
+  # Bump after rotating the remote password, to send credentials again.
+  credentials_version = 1
+
+  wait_for_healthy = {
+    timeout = 600
+  }
+}
+
+data "hypercore_vms" "app" {
+  name = "app-1"
+}
+
+resource "hypercore_vm_replication" "app" {
+  vm_uuid         = data.hypercore_vms.app.vms.0.uuid
+  connection_uuid = hypercore_remote_cluster_connection.dr.id
+}
+
+output "dr_connection_status" {
+  value = hypercore_remote_cluster_connection.dr.connection_status
+}
+
+# An existing connection can also be imported
+resource "hypercore_remote_cluster_connection" "imported" {
+  remote_host = "10.5.12.200"
+  username    = "admin"
+  password    = var.remote_cluster_password
+}
+
+import {
+  to = hypercore_remote_cluster_connection.imported
+  id = "6ab8c456-85af-4c97-8cb7-76246552b1e6"
+}
@@ -11,11 +11,31 @@ resource "hypercore_virtual_disk" "vd_upload_local" {
   source_url = "file:////media/testtf-local-virtual-disk.img" # 4 slashes, because /media is in the root
 }
 
+# Image format (qcow2, vmdk, vhd, vhdx or raw) is detected before upload,
+# and must match the name extension. Ubuntu cloud images are qcow2 with ".img" extension.
 resource "hypercore_virtual_disk" "vd_upload_from_url" {
   name       = "virtual-disk-from-url.img"
   source_url = "https://cloud-images.ubuntu.com/jammy/current/jammy-server-cloudimg-amd64.img"
 }
 
+# Publish a "golden" VM disk into the virtual disk library, on the cluster.
+resource "hypercore_virtual_disk" "vd_from_vm_disk" {
+  name             = "golden-image.img"
+  source_disk_uuid = data.hypercore_vms.myvm.vms.0.disks.0.uuid
+}
+
+# Same, but use the disk content from a VM snapshot
+resource "hypercore_vm_snapshot" "golden" {
+  vm_uuid = data.hypercore_vms.myvm.vms.0.uuid
+  label   = "golden-image"
+}
+
+resource "hypercore_virtual_disk" "vd_from_snapshot" {
+  name                 = "golden-image-snapshot.img"
+  source_disk_uuid     = data.hypercore_vms.myvm.vms.0.disks.0.uuid
+  source_snapshot_uuid = hypercore_vm_snapshot.golden.id
+}
+
 resource "hypercore_virtual_disk" "vd_import_existing" {
   name = "some-existing-virtual-disk.img"
 }
@@ -36,6 +56,10 @@ output "uploaded_vd_EXTERNAL" {
   value = hypercore_virtual_disk.vd_upload_from_url
 }
 
+output "uploaded_vd_EXTERNAL_format" {
+  value = hypercore_virtual_disk.vd_upload_from_url.format # "qcow2"
+}
+
 output "uploaded_vd_EXISTING" {
   value = hypercore_virtual_disk.vd_testtf_import_existing
 }
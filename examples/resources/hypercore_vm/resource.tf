@@ -42,6 +42,20 @@ resource "hypercore_vm" "myvm" {
   }
 }
 
+# New VMs are powered off. Start the VM, and wait until the guest agent
+# reports an address in 10.0.0.0/24 on the NIC with the preserved MAC address.
+resource "hypercore_vm_power_state" "myvm" {
+  vm_uuid = hypercore_vm.myvm.id
+  state   = "RUNNING"
+
+  wait_for_guest = {
+    timeout         = 600
+    guest_agent     = true
+    ipv4_cidr       = "10.0.0.0/24"
+    nic_mac_address = "52:54:00:AB:CD:EF"
+  }
+}
+
 resource "hypercore_vm" "import-from-smb" {
   tags        = ["my-group"]
   name        = "imported-vm"
@@ -63,3 +77,7 @@ resource "hypercore_vm" "import-from-smb" {
 output "vm_uuid" {
   value = hypercore_vm.myvm.id
 }
+
+output "vm_primary_ipv4" {
+  value = hypercore_vm.myvm.primary_ipv4
+}
@@ -0,0 +1,36 @@
+locals {
+  vm_name = "myvm"
+}
+
+data "hypercore_vms" "cdromvm" {
+  name = local.vm_name
+}
+
+resource "hypercore_iso" "installer" {
+  name       = "alpine-virt-3.21.3.iso"
+  source_url = "https://dl-cdn.alpinelinux.org/alpine/v3.21/releases/x86_64/alpine-virt-3.21.3-x86_64.iso"
+}
+
+# Insert ISO by UUID. IDE_CDROM drive is created if the VM has none.
+resource "hypercore_vm_cdrom" "installer" {
+  vm_uuid  = data.hypercore_vms.cdromvm.vms.0.uuid
+  iso_uuid = hypercore_iso.installer.id
+}
+
+# Insert an already uploaded ISO by name, into the CD-ROM drive in slot 1.
+resource "hypercore_vm_cdrom" "drivers" {
+  vm_uuid  = data.hypercore_vms.cdromvm.vms.0.uuid
+  slot     = 1
+  iso_name = "virtio-win.iso"
+}
+
+# Empty CD-ROM drive - set iso_uuid and iso_name to null (or omit them) to eject.
+# Destroying hypercore_vm_cdrom ejects the ISO too, the ISO itself is kept.
+
+# an existing CD-ROM drive can also be imported
+import {
+  to = hypercore_vm_cdrom.drivers
+
+  # import id consists of two parts: vm_uuid:slot
+  id = format("%s:%d", data.hypercore_vms.cdromvm.vms.0.uuid, 1)
+}
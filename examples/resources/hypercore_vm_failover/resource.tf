@@ -0,0 +1,36 @@
+# Run against the provider configured for the DR (target) cluster.
+locals {
+  db_replica_vm_uuid  = "c9a1dc7b-2b1f-4d5f-8d3b-4ff7e2f0d1a1" # target_vm_uuid of hypercore_vm_replication
+  app_replica_vm_uuid = "0f6a5b0e-6d8c-4a86-9a41-1f0a4f3c2e77"
+}
+
+# Database comes up first, from the most recent replicated snapshot.
+resource "hypercore_vm_failover" "db" {
+  replica_vm_uuid = local.db_replica_vm_uuid
+  name            = "db-failover"
+
+  # Production VLAN 10 is VLAN 110 on the DR cluster
+  nic_vlan_map = {
+    "10" = 110
+  }
+
+  # Give the database time to boot before the app VM starts
+  boot_delay = 120
+}
+
+# App VM starts after the database, from a chosen snapshot.
+resource "hypercore_vm_failover" "app" {
+  replica_vm_uuid = local.app_replica_vm_uuid
+  snapshot_uuid   = "3b2e1a8c-5b7d-4c1e-9f0a-2d6e8b4c7a13"
+  name            = "app-failover"
+
+  nic_vlan_map = {
+    "10" = 110
+  }
+
+  depends_on = [hypercore_vm_failover.db]
+}
+
+output "db_failover_snapshot_created" {
+  value = hypercore_vm_failover.db.snapshot_created
+}
@@ -21,6 +21,16 @@ resource "hypercore_vm_power_state" "power_state_with_import" {
   state   = "RUNNING" # available states are: SHUTOFF, RUNNING, PAUSED
 }
 
+# Restart the VM whenever the application config changes.
+# REBOOT asks the guest OS to reboot, apply fails if the VM is not RUNNING after restart_timeout seconds.
+resource "hypercore_vm_power_state" "power_state_with_restart" {
+  vm_uuid         = data.hypercore_vms.powerstatevm_no_import.vms.0.uuid
+  state           = "RUNNING"
+  restart_trigger = sha256(file("app.conf"))
+  restart_method  = "REBOOT" # or RESET
+  restart_timeout = 120
+}
+
 output "powerstatevm_uuid" {
   value = data.hypercore_vms.powerstatevm.vms.0.uuid
 }
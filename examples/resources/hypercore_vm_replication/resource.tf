@@ -19,6 +19,29 @@ resource "hypercore_vm_replication" "example-replication" {
   enable          = false
 }
 
+data "hypercore_vms" "example-vm-synced" {
+  name = "example-vm-three"
+}
+
+# Replication already configured on the VM is adopted.
+# Apply waits until the first snapshot is on the remote cluster.
+resource "hypercore_vm_replication" "example-replication-synced" {
+  vm_uuid         = data.hypercore_vms.example-vm-synced.vms.0.uuid
+  connection_uuid = "6ab8c456-85af-4c97-8cb7-76246552b1e6"
+
+  wait_for_initial_sync = {
+    timeout = 7200
+  }
+}
+
+output "replication_state" {
+  value = hypercore_vm_replication.example-replication-synced.state
+}
+
+output "replication_lag_seconds" {
+  value = hypercore_vm_replication.example-replication-synced.lag_seconds
+}
+
 resource "hypercore_vm_replication" "example-replication-imported" {
   vm_uuid = data.hypercore_vms.example-vm-two.vms.0.uuid
 }
@@ -16,6 +16,22 @@ resource "hypercore_vm_snapshot" "snapshot" {
   label   = "my-snapshot"
 }
 
+# Snapshot removed by HC3 30 days after it was taken, and its replica after 90 days
+resource "hypercore_vm_snapshot" "expiring-snapshot" {
+  vm_uuid             = data.hypercore_vms.example-vm-one.vms.0.uuid
+  label               = "before-upgrade"
+  local_retain_until  = "30d"
+  remote_retain_until = "90d"
+}
+
+# Snapshot kept until a fixed date, and not replicated
+resource "hypercore_vm_snapshot" "local-only-snapshot" {
+  vm_uuid            = data.hypercore_vms.example-vm-one.vms.0.uuid
+  label              = "end-of-year"
+  local_retain_until = "2030-12-31T23:59:59Z"
+  replicate          = false
+}
+
 resource "hypercore_vm_snapshot" "imported-snapshot" {
   vm_uuid = data.hypercore_vms.example-vm-two.vms.0.uuid
 }
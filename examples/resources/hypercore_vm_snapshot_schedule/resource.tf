@@ -29,6 +29,31 @@ resource "hypercore_vm_snapshot_schedule" "example-schedule" {
   ]
 }
 
+# Rules without raw RRULE. They are converted to frequency and start_timestamp.
+resource "hypercore_vm_snapshot_schedule" "example-schedule-structured" {
+  name = "my-structured-schedule"
+  rules = [
+    {
+      name                    = "hourly",
+      every                   = "1h",
+      local_retention_seconds = 86400
+    },
+    {
+      name                    = "nightly-on-workdays",
+      every                   = "1d",
+      at                      = "02:30",
+      weekdays                = ["MO", "TU", "WE", "TH", "FR"],
+      local_retention_seconds = 604800
+    },
+    {
+      name                    = "weekly-utc",
+      frequency               = "FREQ=WEEKLY;INTERVAL=1;BYDAY=SU",
+      start_timestamp         = "2023-02-05T03:00:00Z", # converted to cluster timezone
+      local_retention_seconds = 2419200
+    }
+  ]
+}
+
 resource "hypercore_vm_snapshot_schedule" "example-schedule-no-rules" {
   name = "my-schedule-without-rules"
 }
@@ -41,3 +66,13 @@ import {
   to = hypercore_vm_snapshot_schedule.example-schedule-imported
   id = "69b21f14-6bb6-4dd5-a6bc-6dec9bd59c96"
 }
+
+# Import id is schedule UUID or schedule name. Import by name fails if the name is not unique.
+resource "hypercore_vm_snapshot_schedule" "example-schedule-imported-by-name" {
+  name = "Hourly"
+}
+
+import {
+  to = hypercore_vm_snapshot_schedule.example-schedule-imported-by-name
+  id = "Hourly"
+}
@@ -0,0 +1,44 @@
+resource "hypercore_vm_snapshot_schedule" "daily" {
+  name = "daily"
+  rules = [
+    {
+      name                    = "daily-at-two",
+      every                   = "1d",
+      at                      = "02:00",
+      local_retention_seconds = 7 * 86400
+    }
+  ]
+}
+
+# Assign the schedule to all VMs tagged "prod", and to one extra VM.
+# VMs tagged "prod" later get the schedule on the next apply.
+# hypercore_vm resources of assigned VMs need
+# lifecycle { ignore_changes = [snapshot_schedule_uuid] }.
+resource "hypercore_vm_snapshot_schedule_assignment" "daily" {
+  schedule_uuid = hypercore_vm_snapshot_schedule.daily.id
+  tags_any      = ["prod"]
+  vm_uuids      = ["97904009-1878-4881-b6df-83c85ab7dc1a"]
+}
+
+resource "hypercore_vm_snapshot_schedule" "weekly" {
+  name = "weekly"
+  rules = [
+    {
+      name                    = "sunday",
+      weekdays                = ["SU"],
+      local_retention_seconds = 28 * 86400
+    }
+  ]
+}
+
+# Only VMs selected here use the schedule, it is removed from any other VM.
+# Use one assignment resource per schedule.
+resource "hypercore_vm_snapshot_schedule_assignment" "weekly" {
+  schedule_uuid = hypercore_vm_snapshot_schedule.weekly.id
+  tags_all      = ["prod", "db"]
+  exclusive     = true
+}
+
+output "daily_assigned_vm_uuids" {
+  value = hypercore_vm_snapshot_schedule_assignment.daily.assigned_vm_uuids
+}
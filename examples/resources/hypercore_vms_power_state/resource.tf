@@ -0,0 +1,31 @@
+locals {
+  db_vm_name  = "app-db"
+  app_vm_name = "app-server"
+}
+
+data "hypercore_vm" "db" {
+  name = local.db_vm_name
+}
+
+data "hypercore_vm" "app" {
+  name = local.app_vm_name
+}
+
+# Start the database first. The application server is started
+# 60 seconds after the database is RUNNING.
+resource "hypercore_vms_power_state" "app_startup" {
+  vm_uuids = [data.hypercore_vm.db.uuid, data.hypercore_vm.app.uuid]
+  state    = "RUNNING"
+
+  ordering = [
+    [data.hypercore_vm.db.uuid],
+    [data.hypercore_vm.app.uuid],
+  ]
+  stagger_delay = 60
+}
+
+# Shut down all VMs tagged "lab" and "temporary", in one HC3 task.
+resource "hypercore_vms_power_state" "lab_shutdown" {
+  tags  = ["lab", "temporary"]
+  state = "SHUTOFF"
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+var _ basetypes.Float64Typable = DiskSizeGBType{}
+var _ basetypes.Float64ValuableWithSemanticEquals = DiskSizeGBValue{}
+
+// DiskSizeGBType is a disk size in GB. Two sizes are semantically equal
+// if they are the same number of bytes, so float rounding does not cause diffs.
+type DiskSizeGBType struct {
+	basetypes.Float64Type
+}
+
+func (t DiskSizeGBType) Equal(o attr.Type) bool {
+	other, ok := o.(DiskSizeGBType)
+	if !ok {
+		return false
+	}
+	return t.Float64Type.Equal(other.Float64Type)
+}
+
+func (t DiskSizeGBType) String() string {
+	return "DiskSizeGBType"
+}
+
+func (t DiskSizeGBType) ValueFromFloat64(_ context.Context, in basetypes.Float64Value) (basetypes.Float64Valuable, diag.Diagnostics) {
+	return DiskSizeGBValue{Float64Value: in}, nil
+}
+
+func (t DiskSizeGBType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.Float64Type.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	float64Value, ok := attrValue.(basetypes.Float64Value)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return DiskSizeGBValue{Float64Value: float64Value}, nil
+}
+
+func (t DiskSizeGBType) ValueType(_ context.Context) attr.Value {
+	return DiskSizeGBValue{}
+}
+
+type DiskSizeGBValue struct {
+	basetypes.Float64Value
+}
+
+func NewDiskSizeGBValue(sizeGB float64) DiskSizeGBValue {
+	return DiskSizeGBValue{Float64Value: basetypes.NewFloat64Value(sizeGB)}
+}
+
+func NewDiskSizeGBUnknown() DiskSizeGBValue {
+	return DiskSizeGBValue{Float64Value: basetypes.NewFloat64Unknown()}
+}
+
+func (v DiskSizeGBValue) Equal(o attr.Value) bool {
+	other, ok := o.(DiskSizeGBValue)
+	if !ok {
+		return false
+	}
+	return v.Float64Value.Equal(other.Float64Value)
+}
+
+func (v DiskSizeGBValue) Type(_ context.Context) attr.Type {
+	return DiskSizeGBType{}
+}
+
+func (v DiskSizeGBValue) Float64SemanticEquals(_ context.Context, newValuable basetypes.Float64Valuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(DiskSizeGBValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+	return utils.DiskSizesGBEqual(v.ValueFloat64(), newValue.ValueFloat64()), diags
+}
@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &hypercoreClusterDataSource{}
+	_ datasource.DataSourceWithConfigure = &hypercoreClusterDataSource{}
+)
+
+// NewHypercoreClusterDataSource is a helper function to simplify the provider implementation.
+func NewHypercoreClusterDataSource() datasource.DataSource {
+	return &hypercoreClusterDataSource{}
+}
+
+// hypercoreClusterDataSource is the data source implementation.
+type hypercoreClusterDataSource struct {
+	client *utils.RestClient
+}
+
+// hypercoreClusterDataSourceModel maps the data source schema data.
+type hypercoreClusterDataSourceModel struct {
+	MinVersion           types.String `tfsdk:"min_version"`
+	UUID                 types.String `tfsdk:"uuid"`
+	Name                 types.String `tfsdk:"name"`
+	Version              types.String `tfsdk:"version"`
+	NodeCount            types.Int64  `tfsdk:"node_count"`
+	StorageRawTotalBytes types.Int64  `tfsdk:"storage_raw_total_bytes"`
+	StorageRawUsedBytes  types.Int64  `tfsdk:"storage_raw_used_bytes"`
+	StorageRawFreeBytes  types.Int64  `tfsdk:"storage_raw_free_bytes"`
+	MemoryTotalBytes     types.Int64  `tfsdk:"memory_total_bytes"`
+	MemoryUsedBytes      types.Int64  `tfsdk:"memory_used_bytes"`
+	MemoryFreeBytes      types.Int64  `tfsdk:"memory_free_bytes"`
+}
+
+// Metadata returns the data source type name.
+func (d *hypercoreClusterDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster"
+}
+
+// Schema defines the schema for the data source.
+func (d *hypercoreClusterDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "" +
+			"Information about the cluster the provider is connected to. <br>" +
+			"Storage and memory are summed over all nodes. " +
+			"Storage is raw drive capacity. HC3 mirrors data across drives and nodes, so usable storage is about half of it.",
+		Attributes: map[string]schema.Attribute{
+			"min_version": schema.StringAttribute{
+				MarkdownDescription: "If set, reading fails when HyperCore `version` is lower, e.g. `9.4`.",
+				Optional:            true,
+				Validators: []validator.String{
+					hyperCoreVersionValidator(),
+				},
+			},
+			"uuid": schema.StringAttribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Computed: true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "HyperCore version, e.g. `9.4.30.217736`.",
+				Computed:            true,
+			},
+			"node_count": schema.Int64Attribute{
+				Computed: true,
+			},
+			"storage_raw_total_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Raw capacity of all node drives, in bytes. Not the usable capacity, data is mirrored.",
+				Computed:            true,
+			},
+			"storage_raw_used_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Raw used space of all node drives, in bytes, including mirrored copies.",
+				Computed:            true,
+			},
+			"storage_raw_free_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Raw free space of all node drives, in bytes. Data written by VMs uses about twice as much raw space.",
+				Computed:            true,
+			},
+			"memory_total_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Memory of all nodes, in bytes.",
+				Computed:            true,
+			},
+			"memory_used_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Memory used on all nodes, in bytes.",
+				Computed:            true,
+			},
+			"memory_free_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Memory free on all nodes, in bytes.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *hypercoreClusterDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	restClient, ok := req.ProviderData.(*utils.RestClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = restClient
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *hypercoreClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	var conf hypercoreClusterDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &conf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hc3_clusters := d.client.ListRecords(
+		"/rest/v1/Cluster",
+		map[string]any{},
+		-1.0,
+		false,
+	)
+	if len(hc3_clusters) == 0 {
+		resp.Diagnostics.AddError("Cluster not found", "HC3 did not return cluster information.")
+		return
+	}
+	cluster := hc3_clusters[0]
+	hc3_nodes := d.client.ListRecords(
+		"/rest/v1/Node",
+		map[string]any{},
+		-1.0,
+		false,
+	)
+	version := utils.AnyToStringOrEmpty(cluster["icosVersion"])
+	tflog.Debug(ctx, fmt.Sprintf("TTRT: cluster=%v node_count=%d\n", cluster, len(hc3_nodes)))
+
+	if minVersion := conf.MinVersion.ValueString(); minVersion != "" {
+		cmp, err := utils.CompareHyperCoreVersion(version, minVersion)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid HyperCore version", fmt.Sprintf("Can't compare cluster version with 'min_version': %s", err.Error()))
+			return
+		}
+		if cmp < 0 {
+			resp.Diagnostics.AddError(
+				"HyperCore version too old",
+				fmt.Sprintf("Cluster runs HyperCore %s, at least %s is required.", version, minVersion),
+			)
+			return
+		}
+	}
+
+	capacity := utils.GetClusterCapacity(hc3_nodes)
+	state := conf
+	state.UUID = types.StringValue(utils.AnyToString(cluster["uuid"]))
+	state.Name = types.StringValue(utils.AnyToStringOrEmpty(cluster["clusterName"]))
+	state.Version = types.StringValue(version)
+	state.NodeCount = types.Int64Value(int64(len(hc3_nodes)))
+	state.StorageRawTotalBytes = types.Int64Value(capacity.StorageRawTotalBytes)
+	state.StorageRawUsedBytes = types.Int64Value(capacity.StorageRawUsedBytes)
+	state.StorageRawFreeBytes = types.Int64Value(capacity.StorageRawFreeBytes())
+	state.MemoryTotalBytes = types.Int64Value(capacity.MemoryTotalBytes)
+	state.MemoryUsedBytes = types.Int64Value(capacity.MemoryUsedBytes)
+	state.MemoryFreeBytes = types.Int64Value(capacity.MemoryFreeBytes())
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
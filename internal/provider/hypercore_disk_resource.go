@@ -8,13 +8,19 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
@@ -23,6 +29,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &HypercoreDiskResource{}
 var _ resource.ResourceWithImportState = &HypercoreDiskResource{}
+var _ resource.ResourceWithValidateConfig = &HypercoreDiskResource{}
+var _ resource.ResourceWithModifyPlan = &HypercoreDiskResource{}
 
 func NewHypercoreDiskResource() resource.Resource {
 	return &HypercoreDiskResource{}
@@ -35,14 +43,21 @@ type HypercoreDiskResource struct {
 
 // HypercoreDiskResourceModel describes the resource data model.
 type HypercoreDiskResourceModel struct {
-	Id                  types.String  `tfsdk:"id"`
-	VmUUID              types.String  `tfsdk:"vm_uuid"`
-	Slot                types.Int64   `tfsdk:"slot"`
-	FlashPriority       types.Int64   `tfsdk:"flash_priority"`
-	Type                types.String  `tfsdk:"type"`
-	Size                types.Float64 `tfsdk:"size"`
-	SourceVirtualDiskID types.String  `tfsdk:"source_virtual_disk_id"`
-	IsoUUID             types.String  `tfsdk:"iso_uuid"`
+	Id                   types.String    `tfsdk:"id"`
+	VmUUID               types.String    `tfsdk:"vm_uuid"`
+	Slot                 types.Int64     `tfsdk:"slot"`
+	FlashPriority        types.Int64     `tfsdk:"flash_priority"`
+	Type                 types.String    `tfsdk:"type"`
+	Size                 DiskSizeGBValue `tfsdk:"size"`
+	SizeBytes            types.Int64     `tfsdk:"size_bytes"`
+	SizeGiB              types.Int64     `tfsdk:"size_gib"`
+	SourceVirtualDiskID  types.String    `tfsdk:"source_virtual_disk_id"`
+	IsoUUID              types.String    `tfsdk:"iso_uuid"`
+	CacheMode            types.String    `tfsdk:"cache_mode"`
+	ReadOnly             types.Bool      `tfsdk:"read_only"`
+	DisableSnapshotting  types.Bool      `tfsdk:"disable_snapshotting"`
+	RegenerateDiskID     types.Bool      `tfsdk:"regenerate_disk_id"`
+	OnIncompatibleChange types.String    `tfsdk:"on_incompatible_change"`
 }
 
 func (r *HypercoreDiskResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -83,25 +98,260 @@ func (r *HypercoreDiskResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 			},
 			"type": schema.StringAttribute{
-				MarkdownDescription: "Disk type. Can be: `IDE_DISK`, `IDE_CDROM`, `SCSI_DISK`, `VIRTIO_DISK`, `IDE_FLOPPY`, `NVRAM`, `VTPM`",
-				Optional:            true,
+				MarkdownDescription: "" +
+					"Disk type. Can be: `IDE_DISK`, `IDE_CDROM`, `SCSI_DISK`, `VIRTIO_DISK`, `IDE_FLOPPY`, `NVRAM`, `VTPM`. " +
+					"Type of an existing disk can not be changed, see `on_incompatible_change`.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					diskTypeChangePlanModifier{},
+				},
 			},
 			"size": schema.Float64Attribute{
-				MarkdownDescription: "Disk size in `GB`. Must be larger than the current size of the disk if specified.",
-				Optional:            true,
+				MarkdownDescription: "" +
+					"Disk size in `GB` (1 GB = 1000^3 bytes). Must be larger than the current size of the disk if specified. " +
+					"Shrinking is not possible, see `on_incompatible_change`. " +
+					"Only one of `size`, `size_bytes` and `size_gib` can be set.",
+				CustomType: DiskSizeGBType{},
+				Optional:   true,
+				Computed:   true,
+			},
+			"size_bytes": schema.Int64Attribute{
+				MarkdownDescription: "" +
+					"Disk size in bytes. Alternative to `size`. " +
+					"Only one of `size`, `size_bytes` and `size_gib` can be set.",
+				Optional: true,
+				Computed: true,
+			},
+			"size_gib": schema.Int64Attribute{
+				MarkdownDescription: "" +
+					"Disk size in `GiB` (1 GiB = 1024^3 bytes), as shown in HC3 UI. Alternative to `size`. " +
+					"Only one of `size`, `size_bytes` and `size_gib` can be set. " +
+					"Is `null` if the disk size is not a whole number of GiB.",
+				Optional: true,
+				Computed: true,
 			},
 			"source_virtual_disk_id": schema.StringAttribute{
 				MarkdownDescription: "UUID of the virtual disk to use to clone and attach to the VM.",
 				Optional:            true,
 			},
 			"iso_uuid": schema.StringAttribute{
-				MarkdownDescription: "ISO UUID we want to attach to the disk, only available with disk type `IDE_CDROM`.",
+				MarkdownDescription: "ISO UUID we want to attach to the disk, only available with disk type `IDE_CDROM`. Prefer `hypercore_vm_cdrom` resource to insert and eject ISOs.",
 				Optional:            true,
 			},
+			"cache_mode": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"Disk cache mode. Can be: `WRITETHROUGH`, `WRITEBACK`, `NONE`. " +
+					"If not provided, HC3 default is used (or the current cache mode, if imported).",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					diskCacheModeValidator(),
+				},
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Set to `true` to make the disk read-only for the guest OS. Default is `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"disable_snapshotting": schema.BoolAttribute{
+				MarkdownDescription: "" +
+					"Set to `true` to exclude the disk from VM snapshots, for example for swap or scratch disks. " +
+					"Default is `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"regenerate_disk_id": schema.BoolAttribute{
+				MarkdownDescription: "" +
+					"Set to `true` to generate a new disk ID (serial number) when attaching `source_virtual_disk_id`. " +
+					"Keep it `false` if the guest OS identifies disks by ID. " +
+					"Used only when the disk is created, changing it replaces the disk. Default is `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"on_incompatible_change": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"What to do when `size` is decreased or `type` is changed, which HC3 can not do on an existing disk. " +
+					"Can be: `fail`, `replace`. Default is `fail`.<br>" +
+					"`fail` stops `terraform plan` with an error. " +
+					"`replace` plans to destroy the disk and create a new one - **all data on the disk is lost**.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("fail"),
+				Validators: []validator.String{
+					onIncompatibleChangeValidator(),
+				},
+			},
 		},
 	}
 }
 
+// getOnIncompatibleChange returns configured on_incompatible_change, defaulting to "fail".
+func getOnIncompatibleChange(ctx context.Context, config tfsdk.Config) (string, diag.Diagnostics) {
+	var onIncompatibleChange types.String
+	diags := config.GetAttribute(ctx, path.Root("on_incompatible_change"), &onIncompatibleChange)
+	if onIncompatibleChange.IsNull() || onIncompatibleChange.IsUnknown() {
+		return "fail", diags
+	}
+	return onIncompatibleChange.ValueString(), diags
+}
+
+// handleIncompatibleDiskChange either fails the plan or requires replacement.
+func handleIncompatibleDiskChange(ctx context.Context, config tfsdk.Config, incompatible diag.Diagnostic, requiresReplace *bool, diags *diag.Diagnostics) {
+	onIncompatibleChange, configDiags := getOnIncompatibleChange(ctx, config)
+	diags.Append(configDiags...)
+	if onIncompatibleChange == "replace" {
+		*requiresReplace = true
+		return
+	}
+	diags.AddError(
+		incompatible.Summary(),
+		incompatible.Detail()+"\nSet 'on_incompatible_change = \"replace\"' to replace the disk instead - all data on the disk will be lost.",
+	)
+}
+
+// diskTypeChangePlanModifier handles disk type change at plan time.
+type diskTypeChangePlanModifier struct{}
+
+func (m diskTypeChangePlanModifier) Description(_ context.Context) string {
+	return "Disk type can not be changed. Fails the plan or requires replacement, based on on_incompatible_change."
+}
+
+func (m diskTypeChangePlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m diskTypeChangePlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+	var diskUUID types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &diskUUID)...)
+
+	incompatible := utils.ValidateDiskTypeUnchanged(diskUUID.ValueString(), req.StateValue.ValueString(), req.PlanValue.ValueString())
+	if incompatible != nil {
+		handleIncompatibleDiskChange(ctx, req.Config, incompatible, &resp.RequiresReplace, &resp.Diagnostics)
+	}
+}
+
+// setDiskSizes sets size, size_bytes and size_gib from a size in bytes.
+func (data *HypercoreDiskResourceModel) setDiskSizes(sizeBytes int64) {
+	data.Size = NewDiskSizeGBValue(utils.DiskSizeBytesToGB(sizeBytes))
+	data.SizeBytes = types.Int64Value(sizeBytes)
+	data.SizeGiB = types.Int64Null()
+	if sizeGiB, ok := utils.DiskSizeBytesToGiB(sizeBytes); ok {
+		data.SizeGiB = types.Int64Value(sizeGiB)
+	}
+}
+
+// getConfiguredDiskSizeBytes returns disk size in bytes from whichever of size, size_bytes
+// or size_gib is configured, and the path of that attribute. Path is nil if no size is configured.
+// If the configured size is not known yet, known is false.
+func getConfiguredDiskSizeBytes(config HypercoreDiskResourceModel) (sizeBytes int64, sizePath *path.Path, known bool) {
+	switch {
+	case !config.SizeBytes.IsNull():
+		p := path.Root("size_bytes")
+		return config.SizeBytes.ValueInt64(), &p, !config.SizeBytes.IsUnknown()
+	case !config.SizeGiB.IsNull():
+		p := path.Root("size_gib")
+		return utils.DiskSizeGiBToBytes(config.SizeGiB.ValueInt64()), &p, !config.SizeGiB.IsUnknown()
+	case !config.Size.IsNull():
+		p := path.Root("size")
+		return utils.DiskSizeGBToBytes(config.Size.ValueFloat64()), &p, !config.Size.IsUnknown()
+	}
+	return 0, nil, true
+}
+
+func (r *HypercoreDiskResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config HypercoreDiskResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configured := 0
+	for _, isNull := range []bool{config.Size.IsNull(), config.SizeBytes.IsNull(), config.SizeGiB.IsNull()} {
+		if !isNull {
+			configured++
+		}
+	}
+	if configured > 1 {
+		resp.Diagnostics.AddError(
+			"Conflicting disk size",
+			"Only one of 'size', 'size_bytes' and 'size_gib' can be set.",
+		)
+	}
+	if !config.SizeBytes.IsNull() && !config.SizeBytes.IsUnknown() && config.SizeBytes.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("size_bytes"), "Invalid disk size", "Disk size must not be negative.")
+	}
+	if !config.SizeGiB.IsNull() && !config.SizeGiB.IsUnknown() && config.SizeGiB.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("size_gib"), "Invalid disk size", "Disk size must not be negative.")
+	}
+}
+
+// ModifyPlan keeps size, size_bytes and size_gib consistent and handles disk shrinking.
+func (r *HypercoreDiskResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// Destroy
+		return
+	}
+
+	var config, plan HypercoreDiskResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	var state *HypercoreDiskResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &HypercoreDiskResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sizeBytes, sizePath, known := getConfiguredDiskSizeBytes(config)
+	if !known {
+		return
+	}
+	if sizePath == nil {
+		// No size configured, keep the current size.
+		if state != nil {
+			plan.Size = state.Size
+			plan.SizeBytes = state.SizeBytes
+			plan.SizeGiB = state.SizeGiB
+		}
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	configuredSize := plan.Size
+	plan.setDiskSizes(sizeBytes)
+	if !config.Size.IsNull() {
+		// Planned value of a configured attribute must match the configuration.
+		plan.Size = configuredSize
+	}
+
+	if state != nil && !state.SizeBytes.IsNull() && !state.SizeBytes.IsUnknown() {
+		incompatible := utils.ValidateDiskSizeBytes(state.Id.ValueString(), state.SizeBytes.ValueInt64(), sizeBytes)
+		if incompatible != nil {
+			requiresReplace := false
+			handleIncompatibleDiskChange(ctx, req.Config, incompatible, &requiresReplace, &resp.Diagnostics)
+			if requiresReplace {
+				resp.RequiresReplace = append(resp.RequiresReplace, *sizePath)
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
 func (r *HypercoreDiskResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	tflog.Info(ctx, "TTRT HypercoreDiskResource CONFIGURE")
 	// Prevent padisk if the provider has not been configured.
@@ -173,8 +423,13 @@ func (r *HypercoreDiskResource) Create(ctx context.Context, req resource.CreateR
 	createPayload := map[string]any{
 		"virDomainUUID":         data.VmUUID.ValueString(),
 		"type":                  data.Type.ValueString(),
-		"capacity":              data.Size.ValueFloat64() * 1000 * 1000 * 1000, // GB to B
+		"capacity":              data.SizeBytes.ValueInt64(),
 		"tieringPriorityFactor": utils.FROM_HUMAN_PRIORITY_FACTOR[data.FlashPriority.ValueInt64()],
+		"readOnly":              data.ReadOnly.ValueBool(),
+		"disableSnapshotting":   data.DisableSnapshotting.ValueBool(),
+	}
+	if !data.CacheMode.IsUnknown() && !data.CacheMode.IsNull() {
+		createPayload["cacheMode"] = data.CacheMode.ValueString()
 	}
 	if isAttachingISO {
 		createPayload["path"] = (*iso)["path"]
@@ -192,8 +447,8 @@ func (r *HypercoreDiskResource) Create(ctx context.Context, req resource.CreateR
 		originalVDSizeBytes := utils.AnyToInteger64((*sourceVirtualDiskHC3)["capacityBytes"])
 		attachPayload := map[string]any{
 			"options": map[string]any{
-				"regenerateDiskID": false,
-				"readOnly":         false,
+				"regenerateDiskID": data.RegenerateDiskID.ValueBool(),
+				"readOnly":         data.ReadOnly.ValueBool(),
 			},
 			"template": map[string]any{
 				"virDomainUUID":         data.VmUUID.ValueString(),
@@ -216,10 +471,13 @@ func (r *HypercoreDiskResource) Create(ctx context.Context, req resource.CreateR
 		}
 		tflog.Debug(ctx, fmt.Sprintf(
 			"TTRT Attach: Attached with original size - vm_uuid=%s, disk_uuid=%s, original_size=%v (GB), source_virtual_disk_uuid=%s",
-			data.VmUUID.ValueString(), diskUUID, float64(originalVDSizeBytes/1000/1000/1000), sourceVirtualDiskID),
+			data.VmUUID.ValueString(), diskUUID, utils.DiskSizeBytesToGB(originalVDSizeBytes), sourceVirtualDiskID),
 		)
 
-		// Then resize to desired size
+		// Then resize to desired size, or keep the virtual disk size if size is not set
+		if data.SizeBytes.IsUnknown() {
+			delete(createPayload, "capacity")
+		}
 		diag := utils.UpdateDisk(*r.client, diskUUID, createPayload, ctx)
 		if diag != nil {
 			resp.Diagnostics.AddWarning(diag.Summary(), diag.Detail())
@@ -239,6 +497,12 @@ func (r *HypercoreDiskResource) Create(ctx context.Context, req resource.CreateR
 	// save into the Terraform state.
 	data.Id = types.StringValue(diskUUID)
 	data.Slot = types.Int64Value(utils.AnyToInteger64(disk["slot"]))
+	// Disk might have been updated after attach, read cache mode from HC3.
+	if pDisk := utils.GetDiskByUUID(*r.client, diskUUID); pDisk != nil {
+		disk = *pDisk
+	}
+	data.CacheMode = types.StringValue(utils.AnyToString(disk["cacheMode"]))
+	data.setDiskSizes(utils.AnyToInteger64(disk["capacity"]))
 	// TODO MAC, IP address etc
 
 	// Write logs using the tflog package
@@ -281,11 +545,19 @@ func (r *HypercoreDiskResource) Read(ctx context.Context, req resource.ReadReque
 	data.VmUUID = types.StringValue(utils.AnyToString(disk["virDomainUUID"]))
 	data.Type = types.StringValue(utils.AnyToString(disk["type"]))
 	data.Slot = types.Int64Value(utils.AnyToInteger64(disk["slot"]))
-	data.Size = types.Float64Value(utils.AnyToFloat64(disk["capacity"]) / 1000 / 1000 / 1000)
+	data.setDiskSizes(utils.AnyToInteger64(disk["capacity"]))
 
 	hc3PriorityFactor := utils.AnyToInteger64(disk["tieringPriorityFactor"])
 	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreDiskResource: hc3PriorityFactor = %v\n", hc3PriorityFactor))
 	data.FlashPriority = types.Int64Value(utils.TO_HUMAN_PRIORITY_FACTOR[hc3PriorityFactor])
+	data.CacheMode = types.StringValue(utils.AnyToString(disk["cacheMode"]))
+	// Older HC3 versions do not report these, keep the prior state then.
+	if disk["readOnly"] != nil {
+		data.ReadOnly = types.BoolValue(utils.AnyToBool(disk["readOnly"]))
+	}
+	if disk["disableSnapshotting"] != nil {
+		data.DisableSnapshotting = types.BoolValue(utils.AnyToBool(disk["disableSnapshotting"]))
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -340,9 +612,9 @@ func (r *HypercoreDiskResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	// Validate the size
-	oldDiskSize := utils.AnyToFloat64(oldHc3Disk["capacity"]) / 1000 / 1000 / 1000 // B to GB
-	wantedDiskSize := data.Size.ValueFloat64()
-	diagDiskSize := utils.ValidateDiskSize(data.Id.ValueString(), oldDiskSize, wantedDiskSize)
+	oldDiskSizeBytes := utils.AnyToInteger64(oldHc3Disk["capacity"])
+	wantedDiskSizeBytes := data.SizeBytes.ValueInt64()
+	diagDiskSize := utils.ValidateDiskSizeBytes(data.Id.ValueString(), oldDiskSizeBytes, wantedDiskSizeBytes)
 
 	if diagDiskSize != nil {
 		resp.Diagnostics.AddError(diagDiskSize.Summary(), diagDiskSize.Detail())
@@ -370,8 +642,13 @@ func (r *HypercoreDiskResource) Update(ctx context.Context, req resource.UpdateR
 
 	updatePayload := map[string]any{
 		"type":                  data.Type.ValueString(),
-		"capacity":              data.Size.ValueFloat64() * 1000 * 1000 * 1000, // GB to B
+		"capacity":              data.SizeBytes.ValueInt64(),
 		"tieringPriorityFactor": utils.FROM_HUMAN_PRIORITY_FACTOR[data.FlashPriority.ValueInt64()],
+		"readOnly":              data.ReadOnly.ValueBool(),
+		"disableSnapshotting":   data.DisableSnapshotting.ValueBool(),
+	}
+	if !data.CacheMode.IsUnknown() && !data.CacheMode.IsNull() && data.CacheMode.ValueString() != utils.AnyToString(oldHc3Disk["cacheMode"]) {
+		updatePayload["cacheMode"] = data.CacheMode.ValueString()
 	}
 	if isAttachingISO {
 		updatePayload["path"] = (*iso)["path"]
@@ -394,6 +671,8 @@ func (r *HypercoreDiskResource) Update(ctx context.Context, req resource.UpdateR
 	newHc3Disk := *pDisk
 
 	data.Slot = types.Int64Value(utils.AnyToInteger64(newHc3Disk["slot"]))
+	data.CacheMode = types.StringValue(utils.AnyToString(newHc3Disk["cacheMode"]))
+	data.setDiskSizes(utils.AnyToInteger64(newHc3Disk["capacity"]))
 	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreDiskResource: vm_uuid=%s, disk_uuid=%s, disk=%v", vmUUID, diskUUID, newHc3Disk))
 
 	// Save updated data into Terraform state
@@ -452,13 +731,13 @@ func (r *HypercoreDiskResource) ImportState(ctx context.Context, req resource.Im
 	tflog.Info(ctx, fmt.Sprintf("TTRT hc3Disks=%v\n", hc3Disks))
 
 	var diskUUID string
-	var size float64
+	var sizeBytes int64
 	var flashPriority int64
 	for _, disk := range hc3Disks {
 		if utils.AnyToInteger64(disk["slot"]) == slot &&
 			utils.AnyToString(disk["type"]) == diskType {
 			diskUUID = utils.AnyToString(disk["uuid"])
-			size = utils.AnyToFloat64(disk["capacity"]) / 1000 / 1000 / 1000 // hc3 has B, so convert to GB
+			sizeBytes = utils.AnyToInteger64(disk["capacity"])
 			flashPriority = utils.AnyToInteger64(disk["tieringPriorityFactor"])
 			tflog.Debug(ctx, fmt.Sprintf("TTRT HUMAN FLASH PRIORITY = %v", flashPriority))
 			break
@@ -475,5 +754,11 @@ func (r *HypercoreDiskResource) ImportState(ctx context.Context, req resource.Im
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), diskType)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("slot"), slot)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("flash_priority"), flashPriority)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("size"), size)...)
+	var sizes HypercoreDiskResourceModel
+	sizes.setDiskSizes(sizeBytes)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("size"), sizes.Size)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("size_bytes"), sizes.SizeBytes)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("size_gib"), sizes.SizeGiB)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("regenerate_disk_id"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_incompatible_change"), "fail")...)
 }
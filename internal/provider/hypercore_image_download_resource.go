@@ -0,0 +1,319 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &HypercoreImageDownloadResource{}
+var _ resource.ResourceWithValidateConfig = &HypercoreImageDownloadResource{}
+
+func NewHypercoreImageDownloadResource() resource.Resource {
+	return &HypercoreImageDownloadResource{}
+}
+
+// HypercoreImageDownloadResource defines the resource implementation.
+type HypercoreImageDownloadResource struct {
+	client *utils.RestClient
+}
+
+// HypercoreImageDownloadResourceModel describes the resource data model.
+type HypercoreImageDownloadResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	SourceType      types.String `tfsdk:"source_type"`
+	SourceUUID      types.String `tfsdk:"source_uuid"`
+	SourceName      types.String `tfsdk:"source_name"`
+	Path            types.String `tfsdk:"path"`
+	SHA256          types.String `tfsdk:"sha256"`
+	SizeBytes       types.Int64  `tfsdk:"size_bytes"`
+	SourceSizeBytes types.Int64  `tfsdk:"source_size_bytes"`
+	SourceVersion   types.String `tfsdk:"source_version"`
+	ModifiedTime    types.String `tfsdk:"modified_time"`
+}
+
+func (r *HypercoreImageDownloadResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image_download"
+}
+
+func (r *HypercoreImageDownloadResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "" +
+			"Hypercore image download resource to download an ISO or a virtual disk from HC3 to a local file. <br><br>" +
+			"The file is downloaded again only if the local file was changed or removed, or if the ISO or virtual disk on HC3 changed. " +
+			"The local file is hashed on refresh only if its size matches and its modification time changed. " +
+			"Destroying the resource removes the local file. The ISO or virtual disk on HC3 is never modified.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Download identifier, same as `source_uuid`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_type": schema.StringAttribute{
+				MarkdownDescription: "What to download. Can be: `iso`, `virtual_disk`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					downloadSourceTypeValidator(),
+				},
+			},
+			"source_uuid": schema.StringAttribute{
+				MarkdownDescription: "UUID of the ISO or virtual disk. Conflicts with `source_name`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the ISO or virtual disk. Conflicts with `source_uuid`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Local file path to download to. Existing file is overwritten.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA256 checksum of the downloaded file.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Size of the downloaded file in bytes.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_size_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Size of the ISO or virtual disk as reported by HC3, when it was downloaded.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_version": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"Version of the ISO or virtual disk when it was downloaded. " +
+					"It is the checksum reported by HC3, or the modification timestamp if HC3 does not report a checksum.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified_time": schema.StringAttribute{
+				MarkdownDescription: "Modification time of the downloaded file, in RFC3339 format.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *HypercoreImageDownloadResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config HypercoreImageDownloadResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.SourceUUID.IsNull() && !config.SourceName.IsNull() {
+		resp.Diagnostics.AddError(
+			"Conflicting download source",
+			"Only one of 'source_uuid' and 'source_name' can be set.",
+		)
+	}
+	if config.SourceUUID.IsNull() && config.SourceName.IsNull() {
+		resp.Diagnostics.AddError(
+			"Missing download source",
+			"One of 'source_uuid' and 'source_name' must be set.",
+		)
+	}
+}
+
+func (r *HypercoreImageDownloadResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "TTRT HypercoreImageDownloadResource CONFIGURE")
+	// Prevent padisk if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	restClient, ok := req.ProviderData.(*utils.RestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = restClient
+}
+
+func (r *HypercoreImageDownloadResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreImageDownloadResource CREATE")
+	var data HypercoreImageDownloadResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restClient := *r.client
+	sourceType := data.SourceType.ValueString()
+	source, d := utils.GetDownloadSource(restClient, sourceType, data.SourceUUID.ValueString(), data.SourceName.ValueString())
+	if d != nil {
+		resp.Diagnostics.AddError(d.Summary(), d.Detail())
+		return
+	}
+	sourceUUID := utils.AnyToString((*source)["uuid"])
+
+	tflog.Info(ctx, fmt.Sprintf("TTRT Create: source_type=%s, source_uuid=%s, path=%s", sourceType, sourceUUID, data.Path.ValueString()))
+	size, checksum, d := utils.DownloadToFile(restClient, utils.GetDownloadEndpoint(sourceType, sourceUUID), data.Path.ValueString(), ctx)
+	if d != nil {
+		resp.Diagnostics.AddError(d.Summary(), d.Detail())
+		return
+	}
+
+	data.Id = types.StringValue(sourceUUID)
+	data.SourceUUID = types.StringValue(sourceUUID)
+	data.SHA256 = types.StringValue(checksum)
+	data.SizeBytes = types.Int64Value(size)
+	data.SourceSizeBytes = types.Int64Value(utils.GetDownloadSourceSize(sourceType, *source))
+	data.SourceVersion = types.StringValue(utils.GetDownloadSourceVersion(sourceType, *source))
+	modifiedTime, err := utils.FileModifiedTime(data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Couldn't read local file", fmt.Sprintf("Couldn't read file '%s': %s", data.Path.ValueString(), err.Error()))
+		return
+	}
+	data.ModifiedTime = types.StringValue(modifiedTime)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreImageDownloadResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreImageDownloadResource READ")
+	var data HypercoreImageDownloadResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Removing the resource from state makes Terraform plan a new download.
+	localPath := data.Path.ValueString()
+	unchanged, modifiedTime, err := utils.CheckLocalFile(localPath, data.SizeBytes.ValueInt64(), data.ModifiedTime.ValueString(), data.SHA256.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Couldn't read local file", fmt.Sprintf("Couldn't read file '%s': %s", localPath, err.Error()))
+		return
+	}
+	if !unchanged {
+		tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreImageDownloadResource: local file %s changed or removed", localPath))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	// File was touched, but its content is the same.
+	data.ModifiedTime = types.StringValue(modifiedTime)
+
+	restClient := *r.client
+	sourceType := data.SourceType.ValueString()
+	source, d := utils.GetDownloadSource(restClient, sourceType, data.SourceUUID.ValueString(), "")
+	if d != nil {
+		tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreImageDownloadResource: source %s not found", data.SourceUUID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	sourceVersion := utils.GetDownloadSourceVersion(sourceType, *source)
+	if sourceVersion != data.SourceVersion.ValueString() {
+		tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreImageDownloadResource: source %s changed, version=%s", data.SourceUUID.ValueString(), sourceVersion))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreImageDownloadResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreImageDownloadResource UPDATE")
+	var data HypercoreImageDownloadResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// All configurable attributes require replacement, nothing to update on HC3 or locally.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreImageDownloadResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreImageDownloadResource DELETE")
+	var data HypercoreImageDownloadResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	localPath := data.Path.ValueString()
+	if err := os.Remove(localPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		resp.Diagnostics.AddError("Couldn't remove local file", fmt.Sprintf("Couldn't remove file '%s': %s", localPath, err.Error()))
+	}
+}
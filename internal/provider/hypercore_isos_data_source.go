@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &hypercoreISOsDataSource{}
+	_ datasource.DataSourceWithConfigure = &hypercoreISOsDataSource{}
+)
+
+// NewHypercoreISOsDataSource is a helper function to simplify the provider implementation.
+func NewHypercoreISOsDataSource() datasource.DataSource {
+	return &hypercoreISOsDataSource{}
+}
+
+// hypercoreISOsDataSource is the data source implementation.
+type hypercoreISOsDataSource struct {
+	client *utils.RestClient
+}
+
+// hypercoreISOsDataSourceModel maps the data source schema data.
+type hypercoreISOsDataSourceModel struct {
+	FilterName       types.String          `tfsdk:"name"`
+	FilterNamePrefix types.String          `tfsdk:"name_prefix"`
+	FilterNameRegex  types.String          `tfsdk:"name_regex"`
+	ISOs             []hypercoreImageModel `tfsdk:"isos"`
+}
+
+// hypercoreImageModel maps ISO and virtual disk schema data.
+type hypercoreImageModel struct {
+	UUID           types.String   `tfsdk:"uuid"`
+	Name           types.String   `tfsdk:"name"`
+	SizeBytes      types.Int64    `tfsdk:"size_bytes"`
+	Path           types.String   `tfsdk:"path"`
+	ReadyForInsert types.Bool     `tfsdk:"ready_for_insert"`
+	VMUUIDs        []types.String `tfsdk:"vm_uuids"`
+}
+
+// hypercoreImageFilterAttributes returns name filter attributes, shared by ISO and virtual disk data sources.
+func hypercoreImageFilterAttributes(kind string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Return only %s with exactly this name.", kind),
+			Optional:            true,
+		},
+		"name_prefix": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Return only %s with name starting with this prefix.", kind),
+			Optional:            true,
+		},
+		"name_regex": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Return only %s with name matching this regular expression (Go `regexp` syntax).", kind),
+			Optional:            true,
+		},
+	}
+}
+
+// hypercoreImageAttributes returns attributes of a single listed ISO or virtual disk.
+func hypercoreImageAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"uuid": schema.StringAttribute{
+			Computed: true,
+		},
+		"name": schema.StringAttribute{
+			Computed: true,
+		},
+		"size_bytes": schema.Int64Attribute{
+			MarkdownDescription: "Size in bytes.",
+			Computed:            true,
+		},
+		"path": schema.StringAttribute{
+			MarkdownDescription: "Path used by VM block devices to reference the image. Empty if not reported by HC3.",
+			Computed:            true,
+		},
+		"ready_for_insert": schema.BoolAttribute{
+			MarkdownDescription: "Whether the image is fully uploaded and can be used. Null if not reported by HC3.",
+			Computed:            true,
+		},
+		"vm_uuids": schema.ListAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "UUIDs of VMs with a block device currently using the image.",
+			Computed:            true,
+		},
+	}
+}
+
+// buildHypercoreImageModel converts an ISO or VirtualDisk record. sizeField is the record field holding size in bytes.
+func buildHypercoreImageModel(image map[string]any, sizeField string, vms []map[string]any) hypercoreImageModel {
+	path := utils.AnyToStringOrEmpty(image["path"])
+	readyForInsert := types.BoolNull()
+	if image["readyForInsert"] != nil {
+		readyForInsert = types.BoolValue(utils.AnyToBool(image["readyForInsert"]))
+	}
+	sizeBytes := int64(0)
+	if image[sizeField] != nil {
+		sizeBytes = utils.AnyToInteger64(image[sizeField])
+	}
+	vmUUIDs := []types.String{}
+	for _, vmUUID := range utils.GetImageUsage(vms, path) {
+		vmUUIDs = append(vmUUIDs, types.StringValue(vmUUID))
+	}
+	return hypercoreImageModel{
+		UUID:           types.StringValue(utils.AnyToString(image["uuid"])),
+		Name:           types.StringValue(utils.AnyToString(image["name"])),
+		SizeBytes:      types.Int64Value(sizeBytes),
+		Path:           types.StringValue(path),
+		ReadyForInsert: readyForInsert,
+		VMUUIDs:        vmUUIDs,
+	}
+}
+
+// Metadata returns the data source type name.
+func (d *hypercoreISOsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_isos"
+}
+
+// Schema defines the schema for the data source.
+func (d *hypercoreISOsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := hypercoreImageFilterAttributes("ISOs")
+	attributes["isos"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Matching ISOs, sorted by name.",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: hypercoreImageAttributes(),
+		},
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "" +
+			"Lists ISOs matching all of the given filters. Filters that are not set are ignored. <br>" +
+			"For each ISO, VMs with the ISO inserted into a CD-ROM drive are listed in `vm_uuids`.",
+		Attributes: attributes,
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *hypercoreISOsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	restClient, ok := req.ProviderData.(*utils.RestClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = restClient
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *hypercoreISOsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	var conf hypercoreISOsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &conf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := utils.ImageFilter{
+		Name:       conf.FilterName.ValueString(),
+		NamePrefix: conf.FilterNamePrefix.ValueString(),
+		NameRegex:  conf.FilterNameRegex.ValueString(),
+	}
+	diagFilter := utils.ValidateImageFilter(&filter)
+	if diagFilter != nil {
+		resp.Diagnostics.AddError(diagFilter.Summary(), diagFilter.Detail())
+		return
+	}
+
+	query := map[string]any{}
+	if filter.Name != "" {
+		query = map[string]any{"name": filter.Name}
+	}
+	hc3_isos := d.client.ListRecords(
+		"/rest/v1/ISO",
+		query,
+		-1.0,
+		false,
+	)
+	hc3_isos = utils.FilterImages(hc3_isos, filter)
+	hc3_vms := d.client.ListRecords(
+		"/rest/v1/VirDomain",
+		map[string]any{},
+		-1.0,
+		false,
+	)
+	tflog.Debug(ctx, fmt.Sprintf("TTRT: filter=%v iso_count=%d\n", filter, len(hc3_isos)))
+
+	state := conf
+	state.ISOs = []hypercoreImageModel{}
+	for _, iso := range hc3_isos {
+		state.ISOs = append(state.ISOs, buildHypercoreImageModel(iso, "size", hc3_vms))
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
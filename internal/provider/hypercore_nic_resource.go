@@ -8,11 +8,15 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
@@ -33,11 +37,22 @@ type HypercoreNicResource struct {
 
 // HypercoreNicResourceModel describes the resource data model.
 type HypercoreNicResourceModel struct {
-	Id         types.String `tfsdk:"id"`
-	VmUUID     types.String `tfsdk:"vm_uuid"`
-	Vlan       types.Int64  `tfsdk:"vlan"`
-	Type       types.String `tfsdk:"type"`
-	MacAddress types.String `tfsdk:"mac_address"`
+	Id            types.String    `tfsdk:"id"`
+	VmUUID        types.String    `tfsdk:"vm_uuid"`
+	Vlan          types.Int64     `tfsdk:"vlan"`
+	Type          types.String    `tfsdk:"type"`
+	MacAddress    MacAddressValue `tfsdk:"mac_address"`
+	Connected     types.Bool      `tfsdk:"connected"`
+	Ipv4Addresses types.List      `tfsdk:"ipv4_addresses"`
+}
+
+// setFromHC3Nic copies HC3 reported NIC attributes which can change outside of Terraform.
+func (data *HypercoreNicResourceModel) setFromHC3Nic(ctx context.Context, nic map[string]any, diags *diag.Diagnostics) {
+	data.MacAddress = NewMacAddressValue(utils.AnyToString(nic["macAddress"]))
+	data.Connected = types.BoolValue(utils.GetNicConnected(nic))
+	ipv4Addresses, d := types.ListValueFrom(ctx, types.StringType, utils.GetNicIpv4Addresses(nic))
+	diags.Append(d...)
+	data.Ipv4Addresses = ipv4Addresses
 }
 
 func (r *HypercoreNicResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -69,15 +84,38 @@ func (r *HypercoreNicResource) Schema(ctx context.Context, req resource.SchemaRe
 			"type": schema.StringAttribute{
 				MarkdownDescription: "NIC type. Can be: `VIRTIO`, `INTEL_E1000`, `RTL8139`",
 				Required:            true,
+				Validators: []validator.String{
+					nicTypeValidator(),
+				},
 			},
 			"mac_address": schema.StringAttribute{
-				MarkdownDescription: "NIC MAC address.",
-				Optional:            true,
-				Computed:            true,
+				MarkdownDescription: "" +
+					"NIC MAC address, like `52:54:00:AB:CD:EF`. Letter case and `:` or `-` separators are ignored when comparing with HC3. " +
+					"If not set, HC3 assigns one.",
+				Optional:   true,
+				Computed:   true,
+				CustomType: MacAddressType{},
 				// Default:             stringDefault.StaticString Int64(4),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
+				Validators: []validator.String{
+					macAddressValidator(),
+				},
+			},
+			"connected": schema.BoolAttribute{
+				MarkdownDescription: "NIC link state. Set to `false` to disconnect the NIC, like unplugging the network cable. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"ipv4_addresses": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "IPv4 addresses reported by the guest agent. Empty if guest agent is not running.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -128,16 +166,15 @@ func (r *HypercoreNicResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("TTRT Create: vm_uuid=%s, type=%s, vlan=%d mac=%v", data.VmUUID.ValueString(), data.Type.ValueString(), data.Vlan.ValueInt64(), data.MacAddress.ValueString()))
+	tflog.Info(ctx, fmt.Sprintf("TTRT Create: vm_uuid=%s, type=%s, vlan=%d mac=%v connected=%t", data.VmUUID.ValueString(), data.Type.ValueString(), data.Vlan.ValueInt64(), data.MacAddress.ValueString(), data.Connected.ValueBool()))
 
-	nicUUID, nic := utils.CreateNic(*r.client, data.VmUUID.ValueString(), data.Type.ValueString(), data.Vlan.ValueInt64(), data.MacAddress.ValueString(), ctx)
+	nicUUID, nic := utils.CreateNic(*r.client, data.VmUUID.ValueString(), data.Type.ValueString(), data.Vlan.ValueInt64(), data.MacAddress.ValueString(), data.Connected.ValueBool(), ctx)
 	tflog.Info(ctx, fmt.Sprintf("TTRT Created: vm_uuid=%s, nic_uuid=%s, nic=%v", data.VmUUID.ValueString(), nicUUID, nic))
 
 	// TODO: Check if HC3 matches TF
 	// save into the Terraform state.
 	data.Id = types.StringValue(nicUUID)
-	data.MacAddress = types.StringValue(utils.AnyToString(nic["macAddress"]))
-	// TODO MAC, IP address etc
+	data.setFromHC3Nic(ctx, nic, &resp.Diagnostics)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -179,8 +216,7 @@ func (r *HypercoreNicResource) Read(ctx context.Context, req resource.ReadReques
 	data.VmUUID = types.StringValue(utils.AnyToString(nic["virDomainUUID"]))
 	data.Type = types.StringValue(utils.AnyToString(nic["type"]))
 	data.Vlan = types.Int64Value(utils.AnyToInteger64(nic["vlan"]))
-	data.MacAddress = types.StringValue(utils.AnyToString(nic["macAddress"]))
-	// TODO MAC, IP address etc
+	data.setFromHC3Nic(ctx, nic, &resp.Diagnostics)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -228,7 +264,8 @@ func (r *HypercoreNicResource) Update(ctx context.Context, req resource.UpdateRe
 	updatePayload := map[string]any{
 		"type":       data.Type.ValueString(),
 		"vlan":       data.Vlan.ValueInt64(),
-		"macAddress": data.MacAddress.ValueString(),
+		"macAddress": utils.NormalizeMacAddress(data.MacAddress.ValueString()),
+		"connected":  data.Connected.ValueBool(),
 	}
 	diag := utils.UpdateNic(restClient, nicUUID, updatePayload, ctx)
 	if diag != nil {
@@ -247,8 +284,7 @@ func (r *HypercoreNicResource) Update(ctx context.Context, req resource.UpdateRe
 
 	//
 	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreNicResource: vm_uuid=%s, nic_uuid=%s, nic=%v", vmUUID, nicUUID, newHc3Nic))
-
-	// TODO MAC, IP address etc
+	data.setFromHC3Nic(ctx, newHc3Nic, &resp.Diagnostics)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -289,41 +325,33 @@ func (r *HypercoreNicResource) ImportState(ctx context.Context, req resource.Imp
 	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
 
 	tflog.Info(ctx, "TTRT HypercoreNicResource IMPORT_STATE")
-	idParts := strings.Split(req.ID, ":")
-	if len(idParts) != 3 {
-		msg := fmt.Sprintf("NIC import composite ID format is 'vm_uuid:nic_type:nic_vlan'. ID='%s' is invalid.", req.ID)
+	vmUUID, nicSelector, found := strings.Cut(req.ID, ":")
+	if !found || vmUUID == "" {
+		msg := fmt.Sprintf("NIC import composite ID format is 'vm_uuid:mac_address', 'vm_uuid:nic_index' (0-based, NICs ordered by slot) or 'vm_uuid:nic_type:nic_vlan'. ID='%s' is invalid.", req.ID)
 		resp.Diagnostics.AddError("NIC import requires a composite ID", msg)
 		return
 	}
-	vmUUID := idParts[0]
-	nicType := idParts[1]
-	vlan := utils.AnyToInteger64(idParts[2])
-	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreNicResource: vmUUID=%s, type=%s, vlan=%d", vmUUID, nicType, vlan))
+	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreNicResource: vmUUID=%s, nicSelector=%s", vmUUID, nicSelector))
 
 	restClient := *r.client
 	hc3VM := utils.GetOneVM(vmUUID, restClient)
 	hc3Nics := utils.AnyToListOfMap(hc3VM["netDevs"])
 	tflog.Info(ctx, fmt.Sprintf("TTRT hc3Nics=%v\n", hc3Nics))
 
-	var nicUUID string
-	var macAddress string
-	for _, nic := range hc3Nics {
-		if utils.AnyToInteger64(nic["vlan"]) == vlan &&
-			utils.AnyToString(nic["type"]) == nicType {
-			nicUUID = utils.AnyToString(nic["uuid"])
-			macAddress = utils.AnyToString(nic["macAddress"])
-			break
-		}
-	}
-	if nicUUID == "" {
-		msg := fmt.Sprintf("NIC import, NIC not found -  'vm_uuid:nic_type:nic_vlan'='%s'.", req.ID)
-		resp.Diagnostics.AddError("NIC import error, NIC not found", msg)
+	nic, d := utils.FindNicForImport(hc3Nics, nicSelector)
+	if d != nil {
+		resp.Diagnostics.AddError(d.Summary(), fmt.Sprintf("ID='%s': %s", req.ID, d.Detail()))
 		return
 	}
+	nicUUID := utils.AnyToString(nic["uuid"])
+	nicType := utils.AnyToString(nic["type"])
+	vlan := utils.AnyToInteger64(nic["vlan"])
+	macAddress := utils.AnyToString(nic["macAddress"])
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), nicUUID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vm_uuid"), vmUUID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), nicType)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vlan"), vlan)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mac_address"), macAddress)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("connected"), utils.GetNicConnected(nic))...)
 }
@@ -35,16 +35,33 @@ type hypercoreNodesDataSource struct {
 
 // coffeesDataSourceModel maps the data source schema data.
 type hypercoreNodesDataSourceModel struct {
-	FilterPeerID types.Int64          `tfsdk:"peer_id"`
-	Nodes        []hypercoreNodeModel `tfsdk:"nodes"`
+	FilterPeerID      types.Int64          `tfsdk:"peer_id"`
+	FilterOnline      types.Bool           `tfsdk:"online"`
+	FilterSchedulable types.Bool           `tfsdk:"schedulable"`
+	Nodes             []hypercoreNodeModel `tfsdk:"nodes"`
 }
 
 // hypercoreVMModel maps VM schema data.
 type hypercoreNodeModel struct {
-	UUID        types.String `tfsdk:"uuid"`
-	BackplaneIP types.String `tfsdk:"backplane_ip"`
-	LanIP       types.String `tfsdk:"lan_ip"`
-	PeerID      types.Int64  `tfsdk:"peer_id"`
+	UUID                 types.String   `tfsdk:"uuid"`
+	BackplaneIP          types.String   `tfsdk:"backplane_ip"`
+	LanIP                types.String   `tfsdk:"lan_ip"`
+	PeerID               types.Int64    `tfsdk:"peer_id"`
+	CPUSockets           types.Int64    `tfsdk:"cpu_sockets"`
+	CPUCores             types.Int64    `tfsdk:"cpu_cores"`
+	CPUThreads           types.Int64    `tfsdk:"cpu_threads"`
+	CPUHz                types.Int64    `tfsdk:"cpu_hz"`
+	VMCPUTypes           []types.String `tfsdk:"vm_cpu_types"`
+	MemoryTotalBytes     types.Int64    `tfsdk:"memory_total_bytes"`
+	MemoryUsedBytes      types.Int64    `tfsdk:"memory_used_bytes"`
+	MemoryFreeBytes      types.Int64    `tfsdk:"memory_free_bytes"`
+	VMCount              types.Int64    `tfsdk:"vm_count"`
+	NetworkStatus        types.String   `tfsdk:"network_status"`
+	VirtualizationOnline types.Bool     `tfsdk:"virtualization_online"`
+	Online               types.Bool     `tfsdk:"online"`
+	AllowRunningVMs      types.Bool     `tfsdk:"allow_running_vms"`
+	Maintenance          types.Bool     `tfsdk:"maintenance"`
+	Schedulable          types.Bool     `tfsdk:"schedulable"`
 }
 
 // Metadata returns the data source type name.
@@ -55,12 +72,25 @@ func (d *hypercoreNodesDataSource) Metadata(_ context.Context, req datasource.Me
 // Schema defines the schema for the data source.
 func (d *hypercoreNodesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "" +
+			"Lists cluster nodes matching all of the given filters. Filters that are not set are ignored. <br>" +
+			"Use `schedulable = true` to pick `preferred_node_uuid` and `backup_node_uuid` of a VM.",
 		Attributes: map[string]schema.Attribute{
 			"peer_id": schema.Int64Attribute{
-				Optional: true,
+				MarkdownDescription: "Return only the node with this peer ID.",
+				Optional:            true,
+			},
+			"online": schema.BoolAttribute{
+				MarkdownDescription: "Return only nodes with this `online` value.",
+				Optional:            true,
+			},
+			"schedulable": schema.BoolAttribute{
+				MarkdownDescription: "Return only nodes with this `schedulable` value.",
+				Optional:            true,
 			},
 			"nodes": schema.ListNestedAttribute{
-				Computed: true,
+				MarkdownDescription: "Matching nodes.",
+				Computed:            true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"uuid": schema.StringAttribute{
@@ -75,6 +105,63 @@ func (d *hypercoreNodesDataSource) Schema(_ context.Context, _ datasource.Schema
 						"peer_id": schema.Int64Attribute{
 							Computed: true,
 						},
+						"cpu_sockets": schema.Int64Attribute{
+							Computed: true,
+						},
+						"cpu_cores": schema.Int64Attribute{
+							MarkdownDescription: "Number of physical CPU cores, all sockets together.",
+							Computed:            true,
+						},
+						"cpu_threads": schema.Int64Attribute{
+							MarkdownDescription: "Number of logical CPUs, all sockets together.",
+							Computed:            true,
+						},
+						"cpu_hz": schema.Int64Attribute{
+							MarkdownDescription: "CPU clock frequency.",
+							Computed:            true,
+						},
+						"vm_cpu_types": schema.ListAttribute{
+							MarkdownDescription: "" +
+								"QEMU CPU types the node can present to VMs (HC3 `supportedCPUTypes`), e.g. `clusterBaseline`. " +
+								"This is not the physical CPU model, HC3 does not report it.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"memory_total_bytes": schema.Int64Attribute{
+							Computed: true,
+						},
+						"memory_used_bytes": schema.Int64Attribute{
+							Computed: true,
+						},
+						"memory_free_bytes": schema.Int64Attribute{
+							Computed: true,
+						},
+						"vm_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of VMs placed on this node.",
+							Computed:            true,
+						},
+						"network_status": schema.StringAttribute{
+							MarkdownDescription: "Network status reported by HC3, e.g. `ONLINE`.",
+							Computed:            true,
+						},
+						"virtualization_online": schema.BoolAttribute{
+							Computed: true,
+						},
+						"online": schema.BoolAttribute{
+							MarkdownDescription: "Node is reachable on network and runs virtualization.",
+							Computed:            true,
+						},
+						"allow_running_vms": schema.BoolAttribute{
+							Computed: true,
+						},
+						"maintenance": schema.BoolAttribute{
+							MarkdownDescription: "Node is in maintenance mode, or is entering or leaving it.",
+							Computed:            true,
+						},
+						"schedulable": schema.BoolAttribute{
+							MarkdownDescription: "VMs can be started on this node: it is `online`, allows running VMs and is not in `maintenance`.",
+							Computed:            true,
+						},
 					},
 				},
 			},
@@ -107,9 +194,16 @@ func (d *hypercoreNodesDataSource) Read(ctx context.Context, req datasource.Read
 	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
 
 	var conf hypercoreNodesDataSourceModel
-	req.Config.Get(ctx, &conf)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &conf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// use float64, because this is the type of loaded json data
 	filter_peer_id := float64(conf.FilterPeerID.ValueInt64())
+	filter := utils.NodeFilter{
+		Online:      conf.FilterOnline.ValueBoolPointer(),
+		Schedulable: conf.FilterSchedulable.ValueBoolPointer(),
+	}
 
 	query := map[string]any{}
 	// peerID=0 is reserved value, never returned by HC3
@@ -123,15 +217,46 @@ func (d *hypercoreNodesDataSource) Read(ctx context.Context, req datasource.Read
 		-1.0,
 		false,
 	)
-	tflog.Info(ctx, fmt.Sprintf("TTRT: filter_peer_id=%v node_count=%d\n", filter_peer_id, len(hc3_nodes)))
+	hc3_nodes = utils.FilterNodes(hc3_nodes, filter)
+	tflog.Info(ctx, fmt.Sprintf("TTRT: filter_peer_id=%v filter=%v node_count=%d\n", filter_peer_id, filter, len(hc3_nodes)))
 
-	var state hypercoreNodesDataSourceModel
+	vmCounts := utils.CountVMsByNode(d.client.ListRecords(
+		"/rest/v1/VirDomain",
+		map[string]any{},
+		-1.0,
+		false,
+	))
+
+	state := conf
+	state.Nodes = []hypercoreNodeModel{}
 	for _, node := range hc3_nodes {
+		nodeUUID := utils.AnyToString(node["uuid"])
+		cpu := utils.GetNodeCPU(node)
+		vmCPUTypes := []types.String{}
+		for _, cpuType := range cpu.VMTypes {
+			vmCPUTypes = append(vmCPUTypes, types.StringValue(cpuType))
+		}
+		memoryTotal, memoryUsed := utils.GetNodeMemoryBytes(node)
 		hypercoreNodeState := hypercoreNodeModel{
-			UUID:        types.StringValue(utils.AnyToString(node["uuid"])),
-			BackplaneIP: types.StringValue(utils.AnyToString(node["backplaneIP"])),
-			LanIP:       types.StringValue(utils.AnyToString(node["lanIP"])),
-			PeerID:      types.Int64Value(utils.AnyToInteger64(node["peerID"])),
+			UUID:                 types.StringValue(nodeUUID),
+			BackplaneIP:          types.StringValue(utils.AnyToString(node["backplaneIP"])),
+			LanIP:                types.StringValue(utils.AnyToString(node["lanIP"])),
+			PeerID:               types.Int64Value(utils.AnyToInteger64(node["peerID"])),
+			CPUSockets:           types.Int64Value(cpu.Sockets),
+			CPUCores:             types.Int64Value(cpu.Cores),
+			CPUThreads:           types.Int64Value(cpu.Threads),
+			CPUHz:                types.Int64Value(cpu.Hz),
+			VMCPUTypes:           vmCPUTypes,
+			MemoryTotalBytes:     types.Int64Value(memoryTotal),
+			MemoryUsedBytes:      types.Int64Value(memoryUsed),
+			MemoryFreeBytes:      types.Int64Value(max(memoryTotal-memoryUsed, 0)),
+			VMCount:              types.Int64Value(vmCounts[nodeUUID]),
+			NetworkStatus:        types.StringValue(utils.AnyToStringOrEmpty(node["networkStatus"])),
+			VirtualizationOnline: types.BoolValue(utils.GetNodeVirtualizationOnline(node)),
+			Online:               types.BoolValue(utils.IsNodeOnline(node)),
+			AllowRunningVMs:      types.BoolValue(utils.GetNodeAllowRunningVMs(node)),
+			Maintenance:          types.BoolValue(utils.IsNodeInMaintenance(node)),
+			Schedulable:          types.BoolValue(utils.IsNodeSchedulable(node)),
 		}
 		state.Nodes = append(state.Nodes, hypercoreNodeState)
 	}
@@ -0,0 +1,389 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &HypercoreRemoteClusterConnectionResource{}
+var _ resource.ResourceWithImportState = &HypercoreRemoteClusterConnectionResource{}
+
+func NewHypercoreRemoteClusterConnectionResource() resource.Resource {
+	return &HypercoreRemoteClusterConnectionResource{}
+}
+
+// HypercoreRemoteClusterConnectionResource defines the resource implementation.
+type HypercoreRemoteClusterConnectionResource struct {
+	client *utils.RestClient
+}
+
+// HypercoreRemoteClusterConnectionResourceModel describes the resource data model.
+type HypercoreRemoteClusterConnectionResourceModel struct {
+	Id                 types.String         `tfsdk:"id"`
+	RemoteHost         types.String         `tfsdk:"remote_host"`
+	Username           types.String         `tfsdk:"username"`
+	Password           types.String         `tfsdk:"password"`
+	CredentialsVersion types.Int64          `tfsdk:"credentials_version"`
+	Compression        types.Bool           `tfsdk:"compression"`
+	WaitForHealthy     *WaitForHealthyModel `tfsdk:"wait_for_healthy"`
+	ClusterName        types.String         `tfsdk:"cluster_name"`
+	ConnectionStatus   types.String         `tfsdk:"connection_status"`
+	ReplicationOk      types.Bool           `tfsdk:"replication_ok"`
+	RemoteNodeIPs      types.List           `tfsdk:"remote_node_ips"`
+	RemoteNodeUUIDs    types.List           `tfsdk:"remote_node_uuids"`
+}
+
+type WaitForHealthyModel struct {
+	Timeout types.Int32 `tfsdk:"timeout"`
+}
+
+// setFromHC3Connection stores connection options and status read from HC3. Credentials are never read back.
+func setFromHC3Connection(ctx context.Context, data *HypercoreRemoteClusterConnectionResourceModel, hc3Connection map[string]any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	remoteClusterInfo := utils.AnyToMap(hc3Connection["remoteClusterInfo"])
+	data.ClusterName = types.StringValue(utils.AnyToString(remoteClusterInfo["clusterName"]))
+	data.ConnectionStatus = types.StringValue(utils.AnyToStringOrEmpty(hc3Connection["connectionStatus"]))
+	data.ReplicationOk = types.BoolValue(utils.IsRemoteClusterConnectionHealthy(hc3Connection))
+	if hc3Connection["compression"] != nil {
+		data.Compression = types.BoolValue(utils.AnyToBool(hc3Connection["compression"]))
+	}
+
+	remoteNodeIPs, d := types.ListValueFrom(ctx, types.StringType, utils.AnyToListOfStringsOrEmpty(hc3Connection["remoteNodeIPs"]))
+	diags.Append(d...)
+	remoteNodeUUIDs, d := types.ListValueFrom(ctx, types.StringType, utils.AnyToListOfStringsOrEmpty(hc3Connection["remoteNodeUUIDs"]))
+	diags.Append(d...)
+	data.RemoteNodeIPs = remoteNodeIPs
+	data.RemoteNodeUUIDs = remoteNodeUUIDs
+	return diags
+}
+
+// remoteHostChangesCluster requires replacement only if the new remote_host is not one of the paired remote nodes.
+func remoteHostChangesCluster(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var remoteNodeIPs []string
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("remote_node_ips"), &remoteNodeIPs)...)
+	resp.RequiresReplace = !slices.Contains(remoteNodeIPs, req.PlanValue.ValueString())
+}
+
+func (r *HypercoreRemoteClusterConnectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_remote_cluster_connection"
+}
+
+func (r *HypercoreRemoteClusterConnectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "" +
+			"Hypercore remote cluster connection resource to pair this cluster with a remote cluster for VM replication. <br><br>" +
+			"`username` and `password` are write-only, they are not stored in the Terraform state. " +
+			"Requires Terraform 1.11 or later.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Remote cluster connection identifier, use it as `connection_uuid` of `hypercore_vm_replication`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"remote_host": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"IP address of a node of the remote cluster. " +
+					"Changing it to another node of the same remote cluster does not recreate the connection.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						remoteHostChangesCluster,
+						"Requires replace if the new host is not a node of the paired remote cluster.",
+						"Requires replace if the new host is not a node of the paired remote cluster.",
+					),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the remote cluster.",
+				Required:            true,
+				WriteOnly:           true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password of the remote cluster.",
+				Required:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+			},
+			"credentials_version": schema.Int64Attribute{
+				MarkdownDescription: "" +
+					"Change this value to send `username` and `password` to HC3 again, for example after a password rotation. " +
+					"Changes of write-only credentials are not detected otherwise.",
+				Optional: true,
+			},
+			"compression": schema.BoolAttribute{
+				MarkdownDescription: "Compress replication traffic. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"wait_for_healthy": schema.SingleNestedAttribute{
+				MarkdownDescription: "Wait after create or update until `replication_ok` is `true`. Apply fails if `timeout` expires.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"timeout": schema.Int32Attribute{
+						MarkdownDescription: "Seconds to wait. Default is `300`.",
+						Optional:            true,
+					},
+				},
+			},
+			"cluster_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the remote cluster.",
+				Computed:            true,
+			},
+			"connection_status": schema.StringAttribute{
+				MarkdownDescription: "Connection status reported by HC3.",
+				Computed:            true,
+			},
+			"replication_ok": schema.BoolAttribute{
+				MarkdownDescription: "Whether VMs can be replicated over the connection.",
+				Computed:            true,
+			},
+			"remote_node_ips": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "IP addresses of the remote cluster nodes.",
+				Computed:            true,
+			},
+			"remote_node_uuids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "UUIDs of the remote cluster nodes.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *HypercoreRemoteClusterConnectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "TTRT HypercoreRemoteClusterConnectionResource CONFIGURE")
+	// Prevent padisk if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	restClient, ok := req.ProviderData.(*utils.RestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = restClient
+}
+
+// waitForHealthy waits on wait_for_healthy, if it is configured.
+func (r *HypercoreRemoteClusterConnectionResource) waitForHealthy(ctx context.Context, data *HypercoreRemoteClusterConnectionResourceModel) diag.Diagnostic {
+	if data.WaitForHealthy == nil {
+		return nil
+	}
+	timeout := int32(300)
+	if !data.WaitForHealthy.Timeout.IsNull() {
+		timeout = data.WaitForHealthy.Timeout.ValueInt32()
+	}
+	return utils.WaitRemoteClusterConnectionHealthy(*r.client, data.Id.ValueString(), timeout, ctx)
+}
+
+// refresh reads connection from HC3 into data.
+func (r *HypercoreRemoteClusterConnectionResource) refresh(ctx context.Context, data *HypercoreRemoteClusterConnectionResourceModel, diags *diag.Diagnostics) {
+	connectionUUID := data.Id.ValueString()
+	pHc3Connection := utils.GetRemoteClusterConnectionByUUID(*r.client, connectionUUID)
+	if pHc3Connection == nil {
+		diags.AddError("Remote cluster connection not found", fmt.Sprintf("Remote cluster connection not found - connectionUUID=%s", connectionUUID))
+		return
+	}
+	diags.Append(setFromHC3Connection(ctx, data, *pHc3Connection)...)
+}
+
+func (r *HypercoreRemoteClusterConnectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreRemoteClusterConnectionResource CREATE")
+	var data HypercoreRemoteClusterConnectionResourceModel
+	var config HypercoreRemoteClusterConnectionResourceModel
+
+	// Read Terraform plan data into the model. Write-only credentials are available only in config.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restClient := *r.client
+	remoteHost := data.RemoteHost.ValueString()
+	payload := map[string]any{
+		"remoteNodeIP": remoteHost,
+		"username":     config.Username.ValueString(),
+		"password":     config.Password.ValueString(),
+		"compression":  data.Compression.ValueBool(),
+	}
+	tflog.Info(ctx, fmt.Sprintf("TTRT Create: remote_host=%s, compression=%t", remoteHost, data.Compression.ValueBool()))
+
+	connectionUUID, _, _diag := utils.CreateRemoteClusterConnection(restClient, payload, ctx)
+	if _diag != nil {
+		resp.Diagnostics.AddError(_diag.Summary(), _diag.Detail())
+		return
+	}
+	data.Id = types.StringValue(connectionUUID)
+
+	waitDiag := r.waitForHealthy(ctx, &data)
+
+	r.refresh(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	// Connection exists, so it is saved even if it did not become healthy.
+	if waitDiag != nil {
+		resp.Diagnostics.Append(waitDiag)
+	}
+}
+
+func (r *HypercoreRemoteClusterConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreRemoteClusterConnectionResource READ")
+	var data HypercoreRemoteClusterConnectionResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connectionUUID := data.Id.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("TTRT HypercoreRemoteClusterConnectionResource Read oldState connectionUUID=%s\n", connectionUUID))
+
+	// Pairing removed on HC3, create it again.
+	if utils.GetRemoteClusterConnectionByUUID(*r.client, connectionUUID) == nil {
+		tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreRemoteClusterConnectionResource: connection_uuid=%s not found", connectionUUID))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.refresh(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreRemoteClusterConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreRemoteClusterConnectionResource UPDATE")
+	var data_state HypercoreRemoteClusterConnectionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data_state)...)
+	var data HypercoreRemoteClusterConnectionResourceModel
+	var config HypercoreRemoteClusterConnectionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restClient := *r.client
+	connectionUUID := data.Id.ValueString()
+	payload := map[string]any{}
+	if !data.Compression.Equal(data_state.Compression) {
+		payload["compression"] = data.Compression.ValueBool()
+	}
+	if !data.CredentialsVersion.Equal(data_state.CredentialsVersion) {
+		payload["username"] = config.Username.ValueString()
+		payload["password"] = config.Password.ValueString()
+	}
+	tflog.Info(ctx, fmt.Sprintf("TTRT Update: connection_uuid=%s, compression=%t, credentials_version=%s", connectionUUID, data.Compression.ValueBool(), data.CredentialsVersion.String()))
+
+	if len(payload) > 0 {
+		if _diag := utils.UpdateRemoteClusterConnection(restClient, connectionUUID, payload, ctx); _diag != nil {
+			resp.Diagnostics.AddWarning(_diag.Summary(), _diag.Detail())
+		}
+	}
+
+	waitDiag := r.waitForHealthy(ctx, &data)
+
+	r.refresh(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	// Connection exists, so it is saved even if it did not become healthy.
+	if waitDiag != nil {
+		resp.Diagnostics.Append(waitDiag)
+	}
+}
+
+func (r *HypercoreRemoteClusterConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreRemoteClusterConnectionResource DELETE")
+	var data HypercoreRemoteClusterConnectionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	utils.DeleteRemoteClusterConnection(*r.client, data.Id.ValueString(), ctx)
+}
+
+func (r *HypercoreRemoteClusterConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreRemoteClusterConnectionResource IMPORT_STATE")
+
+	connectionUUID := req.ID
+	hc3Connection := utils.GetRemoteClusterConnectionByUUID(*r.client, connectionUUID)
+	if hc3Connection == nil {
+		msg := fmt.Sprintf("Remote cluster connection import, connection not found - 'connection_uuid'='%s'.", req.ID)
+		resp.Diagnostics.AddError("Remote cluster connection import error, connection not found", msg)
+		return
+	}
+
+	// remote_host is taken from the first remote node, credentials stay unknown to Terraform.
+	remoteHost := ""
+	if remoteNodeIPs := utils.AnyToListOfStringsOrEmpty((*hc3Connection)["remoteNodeIPs"]); len(remoteNodeIPs) > 0 {
+		remoteHost = remoteNodeIPs[0]
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), connectionUUID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("remote_host"), remoteHost)...)
+}
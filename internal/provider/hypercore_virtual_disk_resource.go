@@ -7,9 +7,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -20,6 +22,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &HypercoreVirtualDiskResource{}
 var _ resource.ResourceWithImportState = &HypercoreVirtualDiskResource{}
+var _ resource.ResourceWithValidateConfig = &HypercoreVirtualDiskResource{}
 
 func NewHypercoreVirtualDiskResource() resource.Resource {
 	return &HypercoreVirtualDiskResource{}
@@ -32,9 +35,13 @@ type HypercoreVirtualDiskResource struct {
 
 // HypercoreVirtualDiskResourceModel describes the resource data model.
 type HypercoreVirtualDiskResourceModel struct {
-	Id        types.String `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	SourceURL types.String `tfsdk:"source_url"`
+	Id                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	SourceURL          types.String `tfsdk:"source_url"`
+	SourceDiskUUID     types.String `tfsdk:"source_disk_uuid"`
+	SourceSnapshotUUID types.String `tfsdk:"source_snapshot_uuid"`
+	Format             types.String `tfsdk:"format"`
+	VirtualSizeBytes   types.Int64  `tfsdk:"virtual_size_bytes"`
 }
 
 func (r *HypercoreVirtualDiskResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -44,7 +51,10 @@ func (r *HypercoreVirtualDiskResource) Metadata(ctx context.Context, req resourc
 func (r *HypercoreVirtualDiskResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Hypercore virtual disk resource to manage VM virtual disks",
+		MarkdownDescription: "" +
+			"Hypercore virtual disk resource to manage VM virtual disks. <br><br>" +
+			"Virtual disk is either uploaded from `source_url`, or created from an existing VM disk (`source_disk_uuid`) on the cluster, " +
+			"without downloading and re-uploading the image.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -58,16 +68,75 @@ func (r *HypercoreVirtualDiskResource) Schema(ctx context.Context, req resource.
 				Required:            true,
 			},
 			"source_url": schema.StringAttribute{
-				MarkdownDescription: "Source URL from where to fetch that disk from. URL can start with: `http://`, `https://`, `file:///`",
-				Optional:            true,
+				MarkdownDescription: "" +
+					"Source URL from where to fetch that disk from. URL can start with: `http://`, `https://`, `file:///`. " +
+					"Conflicts with `source_disk_uuid`.",
+				Optional: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"source_disk_uuid": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"UUID of an existing VM disk (`hypercore_disk.id`) to create the virtual disk from. " +
+					"Conflicts with `source_url`. Changing it creates a new virtual disk.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"Image format detected from `source_url` content. Can be: `qcow2`, `vmdk`, `vhd`, `vhdx`, `raw`. " +
+					"Image is rejected before upload if the format is not supported or does not match `name` extension. " +
+					"`null` if the virtual disk was not uploaded by Terraform.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"virtual_size_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Virtual disk size in bytes, as seen by the guest OS.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_snapshot_uuid": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"UUID of a VM snapshot. If set, the `source_disk_uuid` disk content from this snapshot is used, " +
+					"instead of the current disk content. Requires `source_disk_uuid`. Changing it creates a new virtual disk.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 		},
 	}
 }
 
+func (r *HypercoreVirtualDiskResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config HypercoreVirtualDiskResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.SourceURL.IsNull() && !config.SourceDiskUUID.IsNull() {
+		resp.Diagnostics.AddError(
+			"Conflicting virtual disk source",
+			"Only one of 'source_url' and 'source_disk_uuid' can be set.",
+		)
+	}
+	if !config.SourceSnapshotUUID.IsNull() && config.SourceDiskUUID.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source_snapshot_uuid"),
+			"Missing source disk",
+			"'source_snapshot_uuid' requires 'source_disk_uuid' to be set.",
+		)
+	}
+}
+
 func (r *HypercoreVirtualDiskResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	tflog.Info(ctx, "TTRT HypercoreVirtualDiskResource CONFIGURE")
 	// Prevent padisk if the provider has not been configured.
@@ -109,27 +178,64 @@ func (r *HypercoreVirtualDiskResource) Create(ctx context.Context, req resource.
 		return
 	}
 
-	// Validate the SourceURL (check if it's in the supported URL types)
-	diagSourceURL := utils.ValidateVirtualDiskSourceURL(data.SourceURL.ValueString())
-	if diagSourceURL != nil {
-		resp.Diagnostics.AddError(diagSourceURL.Summary(), diagSourceURL.Detail())
-		return
-	}
-
-	tflog.Info(ctx, fmt.Sprintf("TTRT Create: name=%s, source=%s", data.Name.ValueString(), data.SourceURL.ValueString()))
-
 	restClient := *r.client
-	vdUUID, virtualDisk, diag := utils.UploadVirtualDisk(restClient, data.Name.ValueString(), data.SourceURL.ValueString(), ctx)
+	var vdUUID string
+	var virtualDisk *map[string]any
+	var diag diag.Diagnostic
+
+	if sourceDiskUUID := data.SourceDiskUUID.ValueString(); sourceDiskUUID != "" {
+		if utils.GetDiskByUUID(restClient, sourceDiskUUID) == nil {
+			resp.Diagnostics.AddError("Disk not found", fmt.Sprintf("Disk with UUID '%s' not found. Double check your Terraform configuration.", sourceDiskUUID))
+			return
+		}
+		sourceSnapshotUUID := data.SourceSnapshotUUID.ValueString()
+		if sourceSnapshotUUID != "" {
+			snapshot := utils.GetVMSnapshotByUUID(restClient, sourceSnapshotUUID)
+			if snapshot == nil {
+				resp.Diagnostics.AddError("VM snapshot not found", fmt.Sprintf("VM snapshot with UUID '%s' not found. Double check your Terraform configuration.", sourceSnapshotUUID))
+				return
+			}
+			diagSnapshotDisk := utils.ValidateSnapshotHasDisk(*snapshot, sourceDiskUUID)
+			if diagSnapshotDisk != nil {
+				resp.Diagnostics.AddError(diagSnapshotDisk.Summary(), diagSnapshotDisk.Detail())
+				return
+			}
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("TTRT Create: name=%s, source_disk_uuid=%s, source_snapshot_uuid=%s", data.Name.ValueString(), sourceDiskUUID, sourceSnapshotUUID))
+		vdUUID, virtualDisk, diag = utils.CreateVirtualDiskFromBlockDevice(restClient, data.Name.ValueString(), sourceDiskUUID, sourceSnapshotUUID, ctx)
+	} else {
+		// Validate the SourceURL (check if it's in the supported URL types)
+		diagSourceURL := utils.ValidateVirtualDiskSourceURL(data.SourceURL.ValueString())
+		if diagSourceURL != nil {
+			resp.Diagnostics.AddError(diagSourceURL.Summary(), diagSourceURL.Detail())
+			return
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("TTRT Create: name=%s, source=%s", data.Name.ValueString(), data.SourceURL.ValueString()))
+		var imageInfo *utils.ImageInfo
+		vdUUID, virtualDisk, imageInfo, diag = utils.UploadVirtualDisk(restClient, data.Name.ValueString(), data.SourceURL.ValueString(), ctx)
+		if imageInfo != nil {
+			data.Format = types.StringValue(imageInfo.Format)
+		}
+	}
 	if diag != nil {
 		resp.Diagnostics.AddError(diag.Summary(), diag.Detail())
 		return
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("TTRT Created: vd_uuid=%s, name=%s, source_url=%s, virtual_disk=%v", vdUUID, data.Name.ValueString(), data.SourceURL.ValueString(), virtualDisk))
+	tflog.Info(ctx, fmt.Sprintf("TTRT Created: vd_uuid=%s, name=%s, source_url=%s, source_disk_uuid=%s, virtual_disk=%v", vdUUID, data.Name.ValueString(), data.SourceURL.ValueString(), data.SourceDiskUUID.ValueString(), virtualDisk))
 
 	// TODO: Check if HC3 matches TF
 	// save into the Terraform state.
 	data.Id = types.StringValue(utils.AnyToString(vdUUID))
+	if data.Format.IsUnknown() {
+		data.Format = types.StringNull()
+	}
+	data.VirtualSizeBytes = types.Int64Null()
+	if virtualDisk != nil {
+		data.VirtualSizeBytes = types.Int64Value(utils.AnyToInteger64((*virtualDisk)["capacityBytes"]))
+	}
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -168,6 +274,7 @@ func (r *HypercoreVirtualDiskResource) Read(ctx context.Context, req resource.Re
 	// save into the Terraform state.
 	data.Id = types.StringValue(vdUUID)
 	data.Name = types.StringValue(utils.AnyToString(hc3VD["name"]))
+	data.VirtualSizeBytes = types.Int64Value(utils.AnyToInteger64(hc3VD["capacityBytes"]))
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &hypercoreVirtualDisksDataSource{}
+	_ datasource.DataSourceWithConfigure = &hypercoreVirtualDisksDataSource{}
+)
+
+// NewHypercoreVirtualDisksDataSource is a helper function to simplify the provider implementation.
+func NewHypercoreVirtualDisksDataSource() datasource.DataSource {
+	return &hypercoreVirtualDisksDataSource{}
+}
+
+// hypercoreVirtualDisksDataSource is the data source implementation.
+type hypercoreVirtualDisksDataSource struct {
+	client *utils.RestClient
+}
+
+// hypercoreVirtualDisksDataSourceModel maps the data source schema data.
+type hypercoreVirtualDisksDataSourceModel struct {
+	FilterName       types.String          `tfsdk:"name"`
+	FilterNamePrefix types.String          `tfsdk:"name_prefix"`
+	FilterNameRegex  types.String          `tfsdk:"name_regex"`
+	VirtualDisks     []hypercoreImageModel `tfsdk:"virtual_disks"`
+}
+
+// Metadata returns the data source type name.
+func (d *hypercoreVirtualDisksDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_virtual_disks"
+}
+
+// Schema defines the schema for the data source.
+func (d *hypercoreVirtualDisksDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := hypercoreImageFilterAttributes("virtual disks")
+	attributes["virtual_disks"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Matching virtual disks, sorted by name.",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: hypercoreImageAttributes(),
+		},
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "" +
+			"Lists virtual disks matching all of the given filters. Filters that are not set are ignored. <br>" +
+			"Attaching a virtual disk to a VM copies it, so `vm_uuids` lists only VMs with a block device still referencing the virtual disk path.",
+		Attributes: attributes,
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *hypercoreVirtualDisksDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	restClient, ok := req.ProviderData.(*utils.RestClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = restClient
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *hypercoreVirtualDisksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	var conf hypercoreVirtualDisksDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &conf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := utils.ImageFilter{
+		Name:       conf.FilterName.ValueString(),
+		NamePrefix: conf.FilterNamePrefix.ValueString(),
+		NameRegex:  conf.FilterNameRegex.ValueString(),
+	}
+	diagFilter := utils.ValidateImageFilter(&filter)
+	if diagFilter != nil {
+		resp.Diagnostics.AddError(diagFilter.Summary(), diagFilter.Detail())
+		return
+	}
+
+	query := map[string]any{}
+	if filter.Name != "" {
+		query = map[string]any{"name": filter.Name}
+	}
+	hc3_virtual_disks := d.client.ListRecords(
+		"/rest/v1/VirtualDisk",
+		query,
+		-1.0,
+		false,
+	)
+	hc3_virtual_disks = utils.FilterImages(hc3_virtual_disks, filter)
+	hc3_vms := d.client.ListRecords(
+		"/rest/v1/VirDomain",
+		map[string]any{},
+		-1.0,
+		false,
+	)
+	tflog.Debug(ctx, fmt.Sprintf("TTRT: filter=%v virtual_disk_count=%d\n", filter, len(hc3_virtual_disks)))
+
+	state := conf
+	state.VirtualDisks = []hypercoreImageModel{}
+	for _, virtualDisk := range hc3_virtual_disks {
+		state.VirtualDisks = append(state.VirtualDisks, buildHypercoreImageModel(virtualDisk, "capacityBytes", hc3_vms))
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
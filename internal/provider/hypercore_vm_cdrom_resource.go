@@ -0,0 +1,378 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &HypercoreVMCdromResource{}
+var _ resource.ResourceWithImportState = &HypercoreVMCdromResource{}
+var _ resource.ResourceWithValidateConfig = &HypercoreVMCdromResource{}
+
+func NewHypercoreVMCdromResource() resource.Resource {
+	return &HypercoreVMCdromResource{}
+}
+
+// HypercoreVMCdromResource defines the resource implementation.
+type HypercoreVMCdromResource struct {
+	client *utils.RestClient
+}
+
+// HypercoreVMCdromResourceModel describes the resource data model.
+type HypercoreVMCdromResourceModel struct {
+	Id      types.String `tfsdk:"id"`
+	VmUUID  types.String `tfsdk:"vm_uuid"`
+	Slot    types.Int64  `tfsdk:"slot"`
+	IsoUUID types.String `tfsdk:"iso_uuid"`
+	IsoName types.String `tfsdk:"iso_name"`
+	IsoPath types.String `tfsdk:"iso_path"`
+}
+
+func (r *HypercoreVMCdromResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_cdrom"
+}
+
+func (r *HypercoreVMCdromResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "" +
+			"Hypercore VM CD-ROM resource to insert an ISO into a VM CD-ROM drive, or eject it. <br><br>" +
+			"The `IDE_CDROM` drive is created if the VM does not have it yet. " +
+			"On destroy the ISO is ejected, the drive is left on the VM. " +
+			"The ISO itself is never modified or deleted by this resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "CD-ROM drive (block device) identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"vm_uuid": schema.StringAttribute{
+				MarkdownDescription: "VM UUID.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"slot": schema.Int64Attribute{
+				MarkdownDescription: "" +
+					"CD-ROM drive slot. If not set, the VM CD-ROM drive with the lowest slot is used. " +
+					"A new drive is created if no matching CD-ROM drive exists.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"iso_uuid": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"UUID of the ISO to insert. Conflicts with `iso_name`. " +
+					"If both `iso_uuid` and `iso_name` are null, the CD-ROM drive is empty.",
+				Optional: true,
+			},
+			"iso_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the ISO to insert. Conflicts with `iso_uuid`.",
+				Optional:            true,
+			},
+			"iso_path": schema.StringAttribute{
+				MarkdownDescription: "Path of the inserted ISO. Empty if the drive is empty.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *HypercoreVMCdromResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config HypercoreVMCdromResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.IsoUUID.IsNull() && !config.IsoName.IsNull() {
+		resp.Diagnostics.AddError(
+			"Conflicting ISO",
+			"Only one of 'iso_uuid' and 'iso_name' can be set.",
+		)
+	}
+}
+
+func (r *HypercoreVMCdromResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "TTRT HypercoreVMCdromResource CONFIGURE")
+	// Prevent padisk if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	restClient, ok := req.ProviderData.(*utils.RestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = restClient
+}
+
+// setCdromMedia inserts the configured ISO into the CD-ROM drive, or ejects the media if no ISO is configured.
+// Nothing is done if the drive already contains the wanted media.
+func (r *HypercoreVMCdromResource) setCdromMedia(ctx context.Context, data HypercoreVMCdromResourceModel, cdrom map[string]any, diags *diag.Diagnostics) {
+	restClient := *r.client
+	cdromUUID := utils.AnyToString(cdrom["uuid"])
+	currentPath := utils.AnyToStringOrEmpty(cdrom["path"])
+
+	if data.IsoUUID.IsNull() && data.IsoName.IsNull() {
+		if currentPath == "" {
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreVMCdromResource: ejecting cdrom_uuid=%s, path=%s", cdromUUID, currentPath))
+		if d := utils.EjectISO(restClient, cdromUUID, ctx); d != nil {
+			diags.Append(d)
+		}
+		return
+	}
+
+	iso, d := utils.ResolveISO(restClient, data.IsoUUID.ValueString(), data.IsoName.ValueString())
+	if d != nil {
+		diags.Append(d)
+		return
+	}
+	if utils.AnyToString((*iso)["path"]) == currentPath {
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreVMCdromResource: inserting cdrom_uuid=%s, iso_uuid=%s", cdromUUID, utils.AnyToString((*iso)["uuid"])))
+	if d := utils.InsertISO(restClient, cdromUUID, *iso, ctx); d != nil {
+		diags.Append(d)
+	}
+}
+
+// readCdrom sets data from HC3 CD-ROM drive. If the inserted media does not match
+// iso_uuid or iso_name, they are set to what is inserted (null if the drive is empty
+// or the media is not a known ISO), so the difference is shown in the plan.
+func (r *HypercoreVMCdromResource) readCdrom(data *HypercoreVMCdromResourceModel, cdrom map[string]any) {
+	restClient := *r.client
+	currentPath := utils.AnyToStringOrEmpty(cdrom["path"])
+
+	data.Id = types.StringValue(utils.AnyToString(cdrom["uuid"]))
+	data.VmUUID = types.StringValue(utils.AnyToString(cdrom["virDomainUUID"]))
+	data.Slot = types.Int64Value(utils.AnyToInteger64(cdrom["slot"]))
+	data.IsoPath = types.StringValue(currentPath)
+
+	if currentPath == "" {
+		// Media was ejected, possibly by the guest OS.
+		data.IsoUUID = types.StringNull()
+		data.IsoName = types.StringNull()
+		return
+	}
+
+	isoUUID, isoName := types.StringNull(), types.StringNull()
+	if iso := utils.GetISOByPath(restClient, currentPath); iso != nil {
+		isoUUID = types.StringValue(utils.AnyToString((*iso)["uuid"]))
+		isoName = types.StringValue(utils.AnyToString((*iso)["name"]))
+	}
+	if !data.IsoName.IsNull() {
+		data.IsoName = isoName
+	} else {
+		data.IsoUUID = isoUUID
+	}
+}
+
+func (r *HypercoreVMCdromResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMCdromResource CREATE")
+	var data HypercoreVMCdromResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restClient := *r.client
+	vmUUID := data.VmUUID.ValueString()
+	var slot *int64
+	if !data.Slot.IsNull() && !data.Slot.IsUnknown() {
+		slot = data.Slot.ValueInt64Pointer()
+	}
+
+	vm, err := utils.GetOneVMWithError(vmUUID, restClient)
+	if err != nil {
+		resp.Diagnostics.AddError("VM not found", err.Error())
+		return
+	}
+
+	cdrom := utils.GetVMCdrom(*vm, slot)
+	if cdrom == nil {
+		var cdromUUID string
+		cdromUUID, cdrom = utils.CreateVMCdrom(restClient, vmUUID, slot, ctx)
+		tflog.Info(ctx, fmt.Sprintf("TTRT Created: vm_uuid=%s, cdrom_uuid=%s, cdrom=%v", vmUUID, cdromUUID, cdrom))
+	}
+	cdromUUID := utils.AnyToString(cdrom["uuid"])
+
+	// The drive exists from here on. If inserting media fails, the state is still saved
+	// together with the error, so Terraform taints the resource instead of losing the drive.
+	r.setCdromMedia(ctx, data, cdrom, &resp.Diagnostics)
+
+	pCdrom := utils.GetDiskByUUID(restClient, cdromUUID)
+	if pCdrom == nil {
+		resp.Diagnostics.AddError("CD-ROM drive not found", fmt.Sprintf("CD-ROM drive not found - cdromUUID=%s, vmUUID=%s.", cdromUUID, vmUUID))
+		return
+	}
+	r.readCdrom(&data, *pCdrom)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreVMCdromResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMCdromResource READ")
+	var data HypercoreVMCdromResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restClient := *r.client
+	cdromUUID := data.Id.ValueString()
+	pCdrom := utils.GetDiskByUUID(restClient, cdromUUID)
+	if pCdrom == nil {
+		// Drive was removed outside of Terraform, it will be created again.
+		tflog.Warn(ctx, fmt.Sprintf("TTRT HypercoreVMCdromResource: CD-ROM drive not found - cdromUUID=%s, vmUUID=%s", cdromUUID, data.VmUUID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreVMCdromResource: cdrom_uuid=%s, cdrom=%v", cdromUUID, *pCdrom))
+	r.readCdrom(&data, *pCdrom)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreVMCdromResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMCdromResource UPDATE")
+	var data HypercoreVMCdromResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restClient := *r.client
+	cdromUUID := data.Id.ValueString()
+	pCdrom := utils.GetDiskByUUID(restClient, cdromUUID)
+	if pCdrom == nil {
+		resp.Diagnostics.AddError("CD-ROM drive not found", fmt.Sprintf("CD-ROM drive not found - cdromUUID=%s, vmUUID=%s.", cdromUUID, data.VmUUID.ValueString()))
+		return
+	}
+
+	r.setCdromMedia(ctx, data, *pCdrom, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Do not trust the update made what we asked for. Read new CD-ROM state from HC3.
+	pCdrom = utils.GetDiskByUUID(restClient, cdromUUID)
+	if pCdrom == nil {
+		resp.Diagnostics.AddError("CD-ROM drive not found", fmt.Sprintf("CD-ROM drive not found - cdromUUID=%s, vmUUID=%s.", cdromUUID, data.VmUUID.ValueString()))
+		return
+	}
+	r.readCdrom(&data, *pCdrom)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreVMCdromResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMCdromResource DELETE")
+	var data HypercoreVMCdromResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only eject the media. The drive stays on the VM and the ISO is not touched.
+	restClient := *r.client
+	cdromUUID := data.Id.ValueString()
+	pCdrom := utils.GetDiskByUUID(restClient, cdromUUID)
+	if pCdrom == nil || utils.AnyToStringOrEmpty((*pCdrom)["path"]) == "" {
+		return
+	}
+	if d := utils.EjectISO(restClient, cdromUUID, ctx); d != nil {
+		resp.Diagnostics.Append(d)
+	}
+}
+
+func (r *HypercoreVMCdromResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMCdromResource IMPORT_STATE")
+	idParts := strings.Split(req.ID, ":")
+	if len(idParts) != 2 {
+		msg := fmt.Sprintf("CD-ROM import composite ID format is 'vm_uuid:slot'. ID='%s' is invalid.", req.ID)
+		resp.Diagnostics.AddError("CD-ROM import requires a composite ID", msg)
+		return
+	}
+	vmUUID := idParts[0]
+	slot := utils.AnyToInteger64(idParts[1])
+
+	restClient := *r.client
+	vm, err := utils.GetOneVMWithError(vmUUID, restClient)
+	if err != nil {
+		resp.Diagnostics.AddError("CD-ROM import error, VM not found", err.Error())
+		return
+	}
+	cdrom := utils.GetVMCdrom(*vm, &slot)
+	if cdrom == nil {
+		msg := fmt.Sprintf("CD-ROM import, CD-ROM drive not found - 'vm_uuid:slot'='%s'.", req.ID)
+		resp.Diagnostics.AddError("CD-ROM import error, CD-ROM drive not found", msg)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), utils.AnyToString(cdrom["uuid"]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vm_uuid"), vmUUID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("slot"), slot)...)
+}
@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &hypercoreVMDataSource{}
+	_ datasource.DataSourceWithConfigure = &hypercoreVMDataSource{}
+)
+
+// NewHypercoreVMDataSource is a helper function to simplify the provider implementation.
+func NewHypercoreVMDataSource() datasource.DataSource {
+	return &hypercoreVMDataSource{}
+}
+
+// hypercoreVMDataSource is the data source implementation.
+type hypercoreVMDataSource struct {
+	client *utils.RestClient
+}
+
+// hypercoreVMDataSourceModel maps the data source schema data.
+type hypercoreVMDataSourceModel struct {
+	UUID types.String `tfsdk:"uuid"`
+	Name types.String `tfsdk:"name"`
+	Vm   types.Object `tfsdk:"vm"`
+}
+
+// Metadata returns the data source type name.
+func (d *hypercoreVMDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm"
+}
+
+// Schema defines the schema for the data source.
+func (d *hypercoreVMDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "" +
+			"Looks up a single VM by `uuid` or by `name`. Exactly one of them must be set. <br>" +
+			"Lookup fails if no VM or more than one VM matches.",
+		Attributes: map[string]schema.Attribute{
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "UUID of the VM.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the VM.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"vm": schema.SingleNestedAttribute{
+				MarkdownDescription: "VM details.",
+				Computed:            true,
+				Attributes:          hypercoreVMDataSourceAttributes(),
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *hypercoreVMDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	restClient, ok := req.ProviderData.(*utils.RestClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = restClient
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *hypercoreVMDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	var conf hypercoreVMDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &conf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vmUUID := conf.UUID.ValueString()
+	vmName := conf.Name.ValueString()
+	if (vmUUID == "") == (vmName == "") {
+		resp.Diagnostics.AddError(
+			"Invalid VM lookup",
+			"Exactly one of 'uuid' or 'name' must be set to look up a VM.",
+		)
+		return
+	}
+
+	var hc3VM map[string]any
+	if vmUUID != "" {
+		pHc3VM, err := utils.GetOneVMWithError(vmUUID, *d.client)
+		if err != nil {
+			resp.Diagnostics.AddError("VM not found", fmt.Sprintf("No VM with UUID %s found.", vmUUID))
+			return
+		}
+		hc3VM = *pHc3VM
+	} else {
+		hc3VMs := utils.GetVM(map[string]any{"name": vmName}, *d.client)
+		if len(hc3VMs) == 0 {
+			resp.Diagnostics.AddError("VM not found", fmt.Sprintf("No VM with name %s found.", vmName))
+			return
+		}
+		if len(hc3VMs) > 1 {
+			resp.Diagnostics.AddError("Multiple VMs found", fmt.Sprintf("Multiple VMs with name %s found.", vmName))
+			return
+		}
+		hc3VM = hc3VMs[0]
+	}
+
+	vmModel := BuildHypercoreVMModelFromAPIData(hc3VM, d.client, ctx)
+	vmObject, diag := ConvertVMModelToObject(ctx, vmModel)
+	if diag != nil {
+		resp.Diagnostics.AddError(diag.Summary(), diag.Detail())
+		return
+	}
+
+	state := hypercoreVMDataSourceModel{
+		UUID: vmModel.UUID,
+		Name: vmModel.Name,
+		Vm:   vmObject,
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &HypercoreVMFailoverResource{}
+var _ resource.ResourceWithValidateConfig = &HypercoreVMFailoverResource{}
+
+func NewHypercoreVMFailoverResource() resource.Resource {
+	return &HypercoreVMFailoverResource{}
+}
+
+// HypercoreVMFailoverResource defines the resource implementation.
+type HypercoreVMFailoverResource struct {
+	client *utils.RestClient
+}
+
+// HypercoreVMFailoverResourceModel describes the resource data model.
+type HypercoreVMFailoverResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	ReplicaVMUUID   types.String `tfsdk:"replica_vm_uuid"`
+	SnapshotUUID    types.String `tfsdk:"snapshot_uuid"`
+	SnapshotCreated types.String `tfsdk:"snapshot_created"`
+	Name            types.String `tfsdk:"name"`
+	NicVlanMap      types.Map    `tfsdk:"nic_vlan_map"`
+	PowerOn         types.Bool   `tfsdk:"power_on"`
+	BootDelay       types.Int64  `tfsdk:"boot_delay"`
+}
+
+func (r *HypercoreVMFailoverResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_failover"
+}
+
+func (r *HypercoreVMFailoverResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "" +
+			"Hypercore VM failover resource to bring up a replicated VM on the target cluster. " +
+			"Use it with a provider configured for the target cluster. <br><br>" +
+			"The replica VM is cloned from a replicated snapshot into a new runnable VM. " +
+			"NICs are moved to other VLANs if `nic_vlan_map` is set, then the VM is powered on. " +
+			"Chain failover resources with `depends_on` and `boot_delay` to power VMs on in order. <br>" +
+			"Destroying the resource deletes the failover VM, the replica VM is not changed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "UUID of the failover VM",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"replica_vm_uuid": schema.StringAttribute{
+				MarkdownDescription: "UUID of the replica VM on the target cluster, `target_vm_uuid` of `hypercore_vm_replication`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"snapshot_uuid": schema.StringAttribute{
+				MarkdownDescription: "UUID of the replicated snapshot to fail over from. If not set, the most recent snapshot of the replica VM is used.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"snapshot_created": schema.StringAttribute{
+				MarkdownDescription: "Creation time of the snapshot used, RFC3339 timestamp in UTC.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the failover VM.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"nic_vlan_map": schema.MapAttribute{
+				ElementType: types.Int64Type,
+				MarkdownDescription: "" +
+					"Move NICs to other VLANs on the target cluster. Keys are VLANs of the replicated VM, values are target VLANs. " +
+					"NICs on VLANs not in the map are not changed.",
+				Optional: true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"power_on": schema.BoolAttribute{
+				MarkdownDescription: "Power on the failover VM. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"boot_delay": schema.Int64Attribute{
+				MarkdownDescription: "Seconds to wait after the failover VM is running, before dependent resources are created. Default: `0`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+			},
+		},
+	}
+}
+
+func (r *HypercoreVMFailoverResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config HypercoreVMFailoverResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.NicVlanMap.IsNull() || config.NicVlanMap.IsUnknown() {
+		return
+	}
+
+	vlanMap := map[string]types.Int64{}
+	resp.Diagnostics.Append(config.NicVlanMap.ElementsAs(ctx, &vlanMap, false)...)
+	knownVlanMap := map[string]int64{}
+	for sourceVlan, targetVlan := range vlanMap {
+		if !targetVlan.IsUnknown() {
+			knownVlanMap[sourceVlan] = targetVlan.ValueInt64()
+		}
+	}
+	if d := utils.ValidateNicVlanMap(knownVlanMap); d != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("nic_vlan_map"), d.Summary(), d.Detail())
+	}
+}
+
+func (r *HypercoreVMFailoverResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "TTRT HypercoreVMFailoverResource CONFIGURE")
+	// Prevent padisk if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	restClient, ok := req.ProviderData.(*utils.RestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = restClient
+}
+
+func (r *HypercoreVMFailoverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMFailoverResource CREATE")
+	var data HypercoreVMFailoverResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vlanMap := map[string]int64{}
+	if !data.NicVlanMap.IsNull() {
+		resp.Diagnostics.Append(data.NicVlanMap.ElementsAs(ctx, &vlanMap, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	restClient := *r.client
+	replicaVMUUID := data.ReplicaVMUUID.ValueString()
+	snapshotUUID := ""
+	if !data.SnapshotUUID.IsUnknown() {
+		snapshotUUID = data.SnapshotUUID.ValueString()
+	}
+
+	// 1. Pick the replicated snapshot
+	hc3Snapshots := restClient.ListRecords("/rest/v1/VirDomainSnapshot", map[string]any{"domainUUID": replicaVMUUID}, -1.0, false)
+	snapshot, d := utils.GetFailoverSnapshot(hc3Snapshots, replicaVMUUID, snapshotUUID)
+	if d != nil {
+		resp.Diagnostics.AddError(d.Summary(), d.Detail())
+		return
+	}
+	snapshotUUID = utils.AnyToString(snapshot["uuid"])
+	tflog.Info(ctx, fmt.Sprintf("TTRT Create: replica_vm_uuid=%s, snapshot_uuid=%s, name=%s", replicaVMUUID, snapshotUUID, data.Name.ValueString()))
+
+	// 2. Clone it into a runnable VM
+	vmUUID, d := utils.CloneVMFromSnapshot(restClient, replicaVMUUID, snapshotUUID, data.Name.ValueString(), ctx)
+	if d != nil {
+		resp.Diagnostics.AddError(d.Summary(), d.Detail())
+		return
+	}
+	data.Id = types.StringValue(vmUUID)
+	data.SnapshotUUID = types.StringValue(snapshotUUID)
+	data.SnapshotCreated = types.StringValue(utils.GetVMSnapshotCreated(snapshot).UTC().Format(time.RFC3339))
+
+	// Save the VM now, so it is deleted on destroy even if following steps fail
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	// 3. Move NICs to target VLANs, before the VM starts
+	if len(vlanMap) > 0 {
+		if d := utils.RemapNicVlans(restClient, vmUUID, vlanMap, ctx); d != nil {
+			resp.Diagnostics.Append(d)
+			return
+		}
+	}
+
+	// 4. Power on
+	if data.PowerOn.ValueBool() {
+		if d := utils.StartFailoverVM(restClient, vmUUID, data.BootDelay.ValueInt64(), ctx); d != nil {
+			resp.Diagnostics.Append(d)
+		}
+	}
+}
+
+func (r *HypercoreVMFailoverResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMFailoverResource READ")
+	var data HypercoreVMFailoverResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Failover VM was removed, fail over again.
+	vmUUID := data.Id.ValueString()
+	if _, err := utils.GetOneVMWithError(vmUUID, *r.client); err != nil {
+		tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreVMFailoverResource: vm_uuid=%s not found", vmUUID))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreVMFailoverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMFailoverResource UPDATE")
+	var data HypercoreVMFailoverResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only boot_delay can change without replacement, it applies to the next failover.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreVMFailoverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMFailoverResource DELETE")
+	var data HypercoreVMFailoverResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restClient := *r.client
+	vmUUID := data.Id.ValueString()
+	if _, err := utils.GetOneVMWithError(vmUUID, restClient); err != nil {
+		return
+	}
+	if d := ShutdownVM(ctx, vmUUID, &restClient); d != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to shutdown VM, got error: %s", d.Detail()))
+		return
+	}
+
+	taskTag := restClient.DeleteRecord(
+		fmt.Sprintf("/rest/v1/VirDomain/%s", vmUUID),
+		-1,
+		ctx,
+	)
+	taskTag.WaitTask(restClient, ctx)
+}
@@ -8,12 +8,16 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
@@ -22,6 +26,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &HypercoreVMPowerStateResource{}
 var _ resource.ResourceWithImportState = &HypercoreVMPowerStateResource{}
+var _ resource.ResourceWithValidateConfig = &HypercoreVMPowerStateResource{}
 
 func NewHypercoreVMPowerStateResource() resource.Resource {
 	return &HypercoreVMPowerStateResource{}
@@ -34,12 +39,16 @@ type HypercoreVMPowerStateResource struct {
 
 // HypercoreVMPowerStateResourceModel describes the resource data model.
 type HypercoreVMPowerStateResourceModel struct {
-	Id                     types.String `tfsdk:"id"`
-	VmUUID                 types.String `tfsdk:"vm_uuid"`
-	State                  types.String `tfsdk:"state"`
-	ForceSutoff            types.Bool   `tfsdk:"force_shutoff"`
-	WaitForGuestNetTimeout types.Int32  `tfsdk:"wait_for_guest_net_timeout"`
-	Vm 					   types.Object `tfsdk:"vm"`
+	Id                     types.String       `tfsdk:"id"`
+	VmUUID                 types.String       `tfsdk:"vm_uuid"`
+	State                  types.String       `tfsdk:"state"`
+	ForceSutoff            types.Bool         `tfsdk:"force_shutoff"`
+	WaitForGuestNetTimeout types.Int32        `tfsdk:"wait_for_guest_net_timeout"`
+	WaitForGuest           *WaitForGuestModel `tfsdk:"wait_for_guest"`
+	RestartTrigger         types.String       `tfsdk:"restart_trigger"`
+	RestartMethod          types.String       `tfsdk:"restart_method"`
+	RestartTimeout         types.Int32        `tfsdk:"restart_timeout"`
+	Vm                     types.Object       `tfsdk:"vm"`
 }
 
 func (r *HypercoreVMPowerStateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -65,6 +74,9 @@ func (r *HypercoreVMPowerStateResource) Schema(ctx context.Context, req resource
 			"state": schema.StringAttribute{
 				MarkdownDescription: "Desired power state of the VM. Can be: `SHUTOFF`, `RUNNING`, `PAUSED`",
 				Required:            true,
+				Validators: []validator.String{
+					powerStateValidator(),
+				},
 			},
 			"force_shutoff": schema.BoolAttribute{
 				MarkdownDescription: "" +
@@ -78,110 +90,66 @@ func (r *HypercoreVMPowerStateResource) Schema(ctx context.Context, req resource
 					"The guest OS needs to have guest tools installed (qemu-guest-agent).",
 				Optional: true,
 			},
-
-				"vm": schema.SingleNestedAttribute{
-					MarkdownDescription: "VM details.",
-					Computed:            true,
-					Attributes: map[string]schema.Attribute{
-						"uuid": schema.StringAttribute{
-							Computed: true,
-						},
-						"name": schema.StringAttribute{
-							Computed: true,
-						},
-						"vcpu": schema.Int32Attribute{
-							MarkdownDescription: "Number of CPUs",
-							Optional:            true,
-						},
-						"memory": schema.Int64Attribute{
-							MarkdownDescription: "Memory (RAM) size in MiB",
-							Optional:            true,
-						},
-						"snapshot_schedule_uuid": schema.StringAttribute{
-							MarkdownDescription: "UUID of the applied snapshot schedule for creating automated snapshots",
-							Computed:            true,
-						},
-						"description": schema.StringAttribute{
-							Computed: true,
-						},
-						"power_state": schema.StringAttribute{
-							Computed: true,
-						},
-						"tags": schema.ListAttribute{
-							ElementType: types.StringType,
-							Optional:    true,
-						},
-						"affinity_strategy": schema.ObjectAttribute{
-							MarkdownDescription: "VM node affinity.",
-							Computed:            true,
-							AttributeTypes: map[string]attr.Type{
-								"strict_affinity":     types.BoolType,
-								"preferred_node_uuid": types.StringType,
-								"backup_node_uuid":    types.StringType,
-							},
-						},
-
-						"disks": schema.ListNestedAttribute{
-							MarkdownDescription: "List of disks",
-							Computed:            true,
-							NestedObject: schema.NestedAttributeObject{
-								Attributes: map[string]schema.Attribute{
-									"uuid": schema.StringAttribute{
-										MarkdownDescription: "UUID",
-										Computed:            true,
-									},
-									"type": schema.StringAttribute{
-										MarkdownDescription: "type",
-										Computed:            true,
-									},
-									"slot": schema.Int64Attribute{
-										MarkdownDescription: "slot",
-										Computed:            true,
-									},
-									"size": schema.Float64Attribute{
-										MarkdownDescription: "size",
-										Computed:            true,
-									},
-								},
-							},
-						},
-						"nics": schema.ListNestedAttribute{
-							MarkdownDescription: "List of NICs",
-							Computed:            true,
-							NestedObject: schema.NestedAttributeObject{
-								Attributes: map[string]schema.Attribute{
-									"uuid": schema.StringAttribute{
-										MarkdownDescription: "UUID",
-										Computed:            true,
-									},
-									"type": schema.StringAttribute{
-										MarkdownDescription: "type",
-										Computed:            true,
-									},
-									"vlan": schema.Int64Attribute{
-										MarkdownDescription: "vlan",
-										Computed:            true,
-									},
-									"mac_address": schema.StringAttribute{
-										MarkdownDescription: "MAC address",
-										Computed:            true,
-									},
-									"ipv4_addresses": schema.ListAttribute{
-										ElementType:         types.StringType,
-										MarkdownDescription: "IPv4 addresses",
-										Computed:            true,
-									},
-								},
-							},
-						},
-					},
+			"wait_for_guest": waitForGuestAttribute("" +
+				"Readiness gates checked after the VM is started. " +
+				"The provider waits until all configured conditions are met, or fails when `timeout` expires.<br>" +
+				"Can be set only if `state` is `RUNNING`. " +
+				"Guest OS needs to have guest tools installed (qemu-guest-agent)."),
+			"restart_trigger": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"Arbitrary value. Changing it restarts the VM, using `restart_method`.<br>" +
+					"Restart is done only if `state` is `RUNNING` and the VM was already running. " +
+					"Use it to restart VMs after a configuration change, for example " +
+					"`restart_trigger = sha256(local.app_config)`.",
+				Optional: true,
+			},
+			"restart_method": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"How to restart the VM when `restart_trigger` changes. Can be: `REBOOT`, `RESET`. Default is `REBOOT`.<br>" +
+					"`REBOOT` sends HC3 `REBOOT` action, which asks the guest OS to reboot. `RESET` is a hard reset.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("REBOOT"),
+				Validators: []validator.String{
+					restartMethodValidator(),
 				},
+			},
+			"restart_timeout": schema.Int32Attribute{
+				MarkdownDescription: "" +
+					"Seconds to wait for the VM to be `RUNNING` after the restart, before apply fails. " +
+					"Default is `300`.",
+				Optional: true,
+				Computed: true,
+				Default:  int32default.StaticInt32(utils.SHUTDOWN_TIMEOUT_SECONDS),
+				Validators: []validator.Int32{
+					int32AtLeastValidator{min: 1},
+				},
+			},
 
-
+			"vm": schema.SingleNestedAttribute{
+				MarkdownDescription: "VM details.",
+				Computed:            true,
+				Attributes:          hypercoreVMResourceAttributes(),
+			},
 		},
 	}
 }
 
+func (r *HypercoreVMPowerStateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data HypercoreVMPowerStateResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.WaitForGuest == nil || data.State.IsUnknown() {
+		return
+	}
+	if data.State.ValueString() != utils.POWER_STATE_RUNNING {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("wait_for_guest"),
+			"Invalid wait_for_guest",
+			fmt.Sprintf("Guest can become ready only in 'RUNNING' state, but state is '%s'.", data.State.ValueString()),
+		)
+	}
+}
+
 func (r *HypercoreVMPowerStateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	tflog.Info(ctx, "TTRT HypercoreVMPowerStateResource CONFIGURE")
 	// Prevent padisk if the provider has not been configured.
@@ -232,17 +200,21 @@ func (r *HypercoreVMPowerStateResource) Create(ctx context.Context, req resource
 	}
 
 	// Power state is not the same as action.
-	// Power state is the end state of the VM that was the result of the performed action,
-	// so to get what action we need to perform to get to the desired end state of the VM,
-	// we need to check with the NEEDED_ACTION_FOR_POWER_STATE.
-	// actionType := utils.NEEDED_ACTION_FOR_POWER_STATE[data.State.ValueString()]
-	actionType := utils.GetNeededActionForState(data.State.ValueString(), data.ForceSutoff.ValueBool())
-	diag := utils.ModifyVMPowerState(*r.client, data.VmUUID.ValueString(), actionType, ctx)
+	// Power state is the end state of the VM that was the result of the performed actions,
+	// so to get what actions we need to perform to get to the desired end state of the VM,
+	// we need to check the current state - see GetNeededActionsForTransition.
+	currentPowerState, diag := utils.GetVMPowerState(data.VmUUID.ValueString(), *r.client)
+	if diag != nil {
+		resp.Diagnostics.AddError(diag.Summary(), diag.Detail())
+		return
+	}
+	actionTypes := utils.GetNeededActionsForTransition(currentPowerState, data.State.ValueString(), data.ForceSutoff.ValueBool())
+	diag = utils.ModifyVMPowerStateSteps(*r.client, data.VmUUID.ValueString(), actionTypes, ctx)
 	if diag != nil {
 		resp.Diagnostics.AddWarning(diag.Summary(), diag.Detail())
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("TTRT Created: vm_uuid=%s, state=%s, action_performed=%s", data.VmUUID.ValueString(), data.State.ValueString(), actionType))
+	tflog.Info(ctx, fmt.Sprintf("TTRT Created: vm_uuid=%s, state=%s, actions_performed=%v", data.VmUUID.ValueString(), data.State.ValueString(), actionTypes))
 
 	// TODO: Check if HC3 matches TF
 	hc3PowerState, diag := utils.GetVMPowerState(data.VmUUID.ValueString(), *r.client)
@@ -274,6 +246,10 @@ func (r *HypercoreVMPowerStateResource) Create(ctx context.Context, req resource
 		wait_ok := vm.WaitGuestNetwork(waitForGuestNetTimeout, *r.client, ctx)
 		tflog.Debug(ctx, fmt.Sprintf("Waiting on guest OS IP address - wait_ok=%v", wait_ok))
 	}
+	// VM is running, so state is saved even if the guest is not ready.
+	if d := waitForGuest(ctx, *r.client, data.VmUUID.ValueString(), data.WaitForGuest); d != nil {
+		resp.Diagnostics.Append(d)
+	}
 
 	// save into the Terraform state.
 	data.Id = types.StringValue(data.VmUUID.ValueString())
@@ -330,7 +306,7 @@ func (r *HypercoreVMPowerStateResource) Read(ctx context.Context, req resource.R
 	// save into the Terraform state.
 	data.Id = types.StringValue(vmUUID)
 	data.VmUUID = types.StringValue(utils.AnyToString(hc3VM["uuid"]))
-	data.State = types.StringValue(utils.AnyToString(hc3VM["desiredDisposition"]))
+	data.State = types.StringValue(utils.PowerStateFromHypercore(hc3VM["desiredDisposition"]))
 	//
 	vmModel := BuildHypercoreVMModelFromAPIData(hc3VM, &restClient, ctx)
 	vmObject, diag := ConvertVMModelToObject(ctx, vmModel)
@@ -383,16 +359,32 @@ func (r *HypercoreVMPowerStateResource) Update(ctx context.Context, req resource
 	}
 
 	// Power state is not the same as action.
-	// Power state is the end state of the VM that was the result of the performed action,
-	// so to get what action we need to perform to get to the desired end state of the VM,
-	// we need to check with the NEEDED_ACTION_FOR_POWER_STATE.
-	// actionType := utils.NEEDED_ACTION_FOR_POWER_STATE[vmDesiredState]
-	actionType := utils.GetNeededActionForState(vmDesiredState, forceShutoff)
-	diag := utils.ModifyVMPowerState(restClient, vmUUID, actionType, ctx)
+	// Power state is the end state of the VM that was the result of the performed actions,
+	// so to get what actions we need to perform to get to the desired end state of the VM,
+	// we need to check the current state - see GetNeededActionsForTransition.
+	currentPowerState, diag := utils.GetVMPowerState(vmUUID, restClient)
+	if diag != nil {
+		resp.Diagnostics.AddError(diag.Summary(), diag.Detail())
+		return
+	}
+	actionTypes := utils.GetNeededActionsForTransition(currentPowerState, vmDesiredState, forceShutoff)
+	diag = utils.ModifyVMPowerStateSteps(restClient, vmUUID, actionTypes, ctx)
 	if diag != nil {
 		resp.Diagnostics.AddWarning(diag.Summary(), diag.Detail())
 	}
 
+	// Restart only a VM that was already running - a VM that was just started needs no restart.
+	restartRequested := !data.RestartTrigger.Equal(data_state.RestartTrigger)
+	if restartRequested && vmDesiredState == utils.POWER_STATE_RUNNING && currentPowerState == utils.POWER_STATE_RUNNING {
+		restartMethod := data.RestartMethod.ValueString()
+		tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreVMPowerStateResource Update vm_uuid=%s restart_method=%s", vmUUID, restartMethod))
+		diag = utils.RestartVM(restClient, vmUUID, restartMethod, data.RestartTimeout.ValueInt32(), ctx)
+		if diag != nil {
+			resp.Diagnostics.AddWarning(diag.Summary(), diag.Detail())
+		}
+		actionTypes = append(actionTypes, restartMethod)
+	}
+
 	// TODO: Check if HC3 matches TF
 	hc3PowerState, diag := utils.GetVMPowerState(vmUUID, restClient)
 	if diag != nil {
@@ -423,8 +415,11 @@ func (r *HypercoreVMPowerStateResource) Update(ctx context.Context, req resource
 		wait_ok := vm.WaitGuestNetwork(waitForGuestNetTimeout, *r.client, ctx)
 		tflog.Debug(ctx, fmt.Sprintf("Waiting on guest OS IP address - wait_ok=%v", wait_ok))
 	}
+	if d := waitForGuest(ctx, restClient, vmUUID, data.WaitForGuest); d != nil {
+		resp.Diagnostics.Append(d)
+	}
 
-	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreVMPowerStateResource: vm_uuid=%s, state=%s, action_performed=%s", vmUUID, hc3PowerState, actionType))
+	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreVMPowerStateResource: vm_uuid=%s, state=%s, actions_performed=%v", vmUUID, hc3PowerState, actionTypes))
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -482,135 +477,81 @@ func (r *HypercoreVMPowerStateResource) ImportState(ctx context.Context, req res
 		return
 	}
 
-	state := utils.AnyToString((*hc3VM)["desiredDisposition"])
+	state := utils.PowerStateFromHypercore((*hc3VM)["desiredDisposition"])
 	tflog.Info(ctx, fmt.Sprintf("TTRT state=%v\n", state))
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), vmUUID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vm_uuid"), vmUUID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("state"), state)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("restart_method"), "REBOOT")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("restart_timeout"), int32(utils.SHUTDOWN_TIMEOUT_SECONDS))...)
 }
 
-// ConvertVMModelToObject converts a HypercoreVMModel to types.Object.
-func ConvertVMModelToObject(ctx context.Context, vmModel HypercoreVMModel) (types.Object, diag.Diagnostic) {
-	// Convert disks
-	diskObjects := make([]attr.Value, len(vmModel.Disks))
-	for i, disk := range vmModel.Disks {
-		diskAttrs := map[string]attr.Value{
-			"uuid": disk.UUID,
-			"type": disk.Type,
-			"slot": disk.Slot,
-			"size": disk.Size,
-		}
-		diskObj, diags := types.ObjectValue(map[string]attr.Type{
-			"uuid": types.StringType,
-			"type": types.StringType,
-			"slot": types.Int64Type,
-			"size": types.Float64Type,
-		}, diskAttrs)
-		if diags.HasError() {
-			return types.ObjectNull(getVMAttributeTypes()), diag.NewErrorDiagnostic("Error creating disk object", fmt.Sprintf("Failed to create disk object: %v", diags.Errors()))
-		}
-		diskObjects[i] = diskObj
-	}
-	disksList, diags := types.ListValue(types.ObjectType{AttrTypes: map[string]attr.Type{
-		"uuid": types.StringType,
-		"type": types.StringType,
-		"slot": types.Int64Type,
-		"size": types.Float64Type,
-	}}, diskObjects)
-	if diags.HasError() {
-		return types.ObjectNull(getVMAttributeTypes()), diag.NewErrorDiagnostic("Error creating disks list", fmt.Sprintf("Failed to create disks list: %v", diags.Errors()))
-	}
-
-	// Convert nics
-	nicObjects := make([]attr.Value, len(vmModel.Nics))
-	for i, nic := range vmModel.Nics {
-		ipv4List := make([]attr.Value, len(nic.Ipv4Adresses))
-		for j, ip := range nic.Ipv4Adresses {
-			ipv4List[j] = ip
-		}
-		ipv4ListValue, diags := types.ListValue(types.StringType, ipv4List)
-		if diags.HasError() {
-			return types.ObjectNull(getVMAttributeTypes()), diag.NewErrorDiagnostic("Error creating IPv4 list", fmt.Sprintf("Failed to create IPv4 list: %v", diags.Errors()))
-		}
+// hypercoreVMResourceAttributes returns the nested attributes of the computed `vm` object.
+// They are generated from hypercoreVMDataSourceAttributes, so the same HypercoreVMModel can be used for both.
+func hypercoreVMResourceAttributes() map[string]schema.Attribute {
+	return resourceAttributesFromDataSource(hypercoreVMDataSourceAttributes())
+}
 
-		nicAttrs := map[string]attr.Value{
-			"uuid":         nic.UUID,
-			"type":         nic.Type,
-			"vlan":         nic.Vlan,
-			"mac_address":  nic.MacAddress,
-			"ipv4_addresses": ipv4ListValue,
-		}
-		nicObj, diags := types.ObjectValue(map[string]attr.Type{
-			"uuid":         types.StringType,
-			"type":         types.StringType,
-			"vlan":         types.Int64Type,
-			"mac_address":  types.StringType,
-			"ipv4_addresses": types.ListType{ElemType: types.StringType},
-		}, nicAttrs)
-		if diags.HasError() {
-			return types.ObjectNull(getVMAttributeTypes()), diag.NewErrorDiagnostic("Error creating NIC object", fmt.Sprintf("Failed to create NIC object: %v", diags.Errors()))
+// resourceAttributesFromDataSource converts data source attributes into equivalent resource attributes.
+// Only attribute kinds used by the VM attributes are supported.
+func resourceAttributesFromDataSource(dsAttributes map[string]dsschema.Attribute) map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{}
+	for name, dsAttribute := range dsAttributes {
+		switch a := dsAttribute.(type) {
+		case dsschema.StringAttribute:
+			attributes[name] = schema.StringAttribute{MarkdownDescription: a.MarkdownDescription, Computed: a.Computed, Optional: a.Optional}
+		case dsschema.BoolAttribute:
+			attributes[name] = schema.BoolAttribute{MarkdownDescription: a.MarkdownDescription, Computed: a.Computed, Optional: a.Optional}
+		case dsschema.Int32Attribute:
+			attributes[name] = schema.Int32Attribute{MarkdownDescription: a.MarkdownDescription, Computed: a.Computed, Optional: a.Optional}
+		case dsschema.Int64Attribute:
+			attributes[name] = schema.Int64Attribute{MarkdownDescription: a.MarkdownDescription, Computed: a.Computed, Optional: a.Optional}
+		case dsschema.Float64Attribute:
+			attributes[name] = schema.Float64Attribute{MarkdownDescription: a.MarkdownDescription, Computed: a.Computed, Optional: a.Optional}
+		case dsschema.ListAttribute:
+			attributes[name] = schema.ListAttribute{
+				ElementType: a.ElementType, MarkdownDescription: a.MarkdownDescription, Computed: a.Computed, Optional: a.Optional,
+			}
+		case dsschema.ObjectAttribute:
+			attributes[name] = schema.ObjectAttribute{
+				AttributeTypes: a.AttributeTypes, MarkdownDescription: a.MarkdownDescription, Computed: a.Computed, Optional: a.Optional,
+			}
+		case dsschema.ListNestedAttribute:
+			attributes[name] = schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: resourceAttributesFromDataSource(a.NestedObject.Attributes),
+				},
+				MarkdownDescription: a.MarkdownDescription,
+				Computed:            a.Computed,
+				Optional:            a.Optional,
+			}
+		default:
+			panic(fmt.Sprintf("unsupported data source attribute %s of type %T", name, dsAttribute))
 		}
-		nicObjects[i] = nicObj
-	}
-	nicsList, diags := types.ListValue(types.ObjectType{AttrTypes: map[string]attr.Type{
-		"uuid":         types.StringType,
-		"type":         types.StringType,
-		"vlan":         types.Int64Type,
-		"mac_address":  types.StringType,
-		"ipv4_addresses": types.ListType{ElemType: types.StringType},
-	}}, nicObjects)
-	if diags.HasError() {
-		return types.ObjectNull(getVMAttributeTypes()), diag.NewErrorDiagnostic("Error creating NICs list", fmt.Sprintf("Failed to create NICs list: %v", diags.Errors()))
-	}
-
-	// Convert affinity strategy
-	affinityAttrs := map[string]attr.Value{
-		"strict_affinity":     vmModel.AffinityStrategy.StrictAffinity,
-		"preferred_node_uuid": vmModel.AffinityStrategy.PreferredNodeUUID,
-		"backup_node_uuid":    vmModel.AffinityStrategy.BackupNodeUUID,
-	}
-	affinityObj, diags := types.ObjectValue(map[string]attr.Type{
-		"strict_affinity":     types.BoolType,
-		"preferred_node_uuid": types.StringType,
-		"backup_node_uuid":    types.StringType,
-	}, affinityAttrs)
-	if diags.HasError() {
-		return types.ObjectNull(getVMAttributeTypes()), diag.NewErrorDiagnostic("Error creating affinity object", fmt.Sprintf("Failed to create affinity object: %v", diags.Errors()))
-	}
-
-	// Convert tags
-	tagValues := make([]attr.Value, len(vmModel.Tags))
-	for i, tag := range vmModel.Tags {
-		tagValues[i] = tag
-	}
-	tagsList, diags := types.ListValue(types.StringType, tagValues)
-	if diags.HasError() {
-		return types.ObjectNull(getVMAttributeTypes()), diag.NewErrorDiagnostic("Error creating tags list", fmt.Sprintf("Failed to create tags list: %v", diags.Errors()))
-	}
-
-	// Create the VM object
-	vmAttrs := map[string]attr.Value{
-		"uuid":                   vmModel.UUID,
-		"name":                   vmModel.Name,
-		"vcpu":                   vmModel.VCPU,
-		"memory":                 vmModel.Memory,
-		"snapshot_schedule_uuid": vmModel.SnapshotScheduleUUID,
-		"description":            vmModel.Description,
-		"power_state":            vmModel.PowerState,
-		"tags":                   tagsList,
-		"affinity_strategy":      affinityObj,
-		"disks":                  disksList,
-		"nics":                   nicsList,
 	}
+	return attributes
+}
 
-	vmObject, diags := types.ObjectValue(getVMAttributeTypes(), vmAttrs)
+// ConvertVMModelToObject converts a HypercoreVMModel to types.Object.
+func ConvertVMModelToObject(ctx context.Context, vmModel HypercoreVMModel) (types.Object, diag.Diagnostic) {
+	vmObject, diags := types.ObjectValueFrom(ctx, getVMAttributeTypes(), vmModel)
 	if diags.HasError() {
 		return types.ObjectNull(getVMAttributeTypes()), diag.NewErrorDiagnostic("Error creating VM object", fmt.Sprintf("Failed to create VM object: %v", diags.Errors()))
 	}
 	return vmObject, nil
 }
 
+// getVMConsoleAttributeTypes returns the attribute types for the VM console object.
+func getVMConsoleAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"type":   types.StringType,
+		"ip":     types.StringType,
+		"port":   types.Int64Type,
+		"keymap": types.StringType,
+	}
+}
+
 // getVMAttributeTypes returns the attribute types for the VM object.
 func getVMAttributeTypes() map[string]attr.Type {
 	return map[string]attr.Type{
@@ -621,7 +562,17 @@ func getVMAttributeTypes() map[string]attr.Type {
 		"snapshot_schedule_uuid": types.StringType,
 		"description":            types.StringType,
 		"power_state":            types.StringType,
+		"desired_disposition":    types.StringType,
+		"machine_type":           types.StringType,
+		"os_type":                types.StringType,
+		"node_uuid":              types.StringType,
+		"guest_agent_state":      types.StringType,
+		"boot_devices":           types.ListType{ElemType: types.StringType},
+		"snapshot_uuids":         types.ListType{ElemType: types.StringType},
+		"replication_uuids":      types.ListType{ElemType: types.StringType},
+		"source_vm_uuid":         types.StringType,
 		"tags":                   types.ListType{ElemType: types.StringType},
+		"console":                types.ObjectType{AttrTypes: getVMConsoleAttributeTypes()},
 		"affinity_strategy": types.ObjectType{
 			AttrTypes: map[string]attr.Type{
 				"strict_affinity":     types.BoolType,
@@ -632,20 +583,25 @@ func getVMAttributeTypes() map[string]attr.Type {
 		"disks": types.ListType{
 			ElemType: types.ObjectType{
 				AttrTypes: map[string]attr.Type{
-					"uuid": types.StringType,
-					"type": types.StringType,
-					"slot": types.Int64Type,
-					"size": types.Float64Type,
+					"uuid":           types.StringType,
+					"type":           types.StringType,
+					"slot":           types.Int64Type,
+					"size":           types.Float64Type,
+					"size_bytes":     types.Int64Type,
+					"size_gib":       types.Int64Type,
+					"flash_priority": types.Int64Type,
+					"iso_path":       types.StringType,
 				},
 			},
 		},
 		"nics": types.ListType{
 			ElemType: types.ObjectType{
 				AttrTypes: map[string]attr.Type{
-					"uuid":         types.StringType,
-					"type":         types.StringType,
-					"vlan":         types.Int64Type,
-					"mac_address":  types.StringType,
+					"uuid":           types.StringType,
+					"type":           types.StringType,
+					"vlan":           types.Int64Type,
+					"mac_address":    types.StringType,
+					"connected":      types.BoolType,
 					"ipv4_addresses": types.ListType{ElemType: types.StringType},
 				},
 			},
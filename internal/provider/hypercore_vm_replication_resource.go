@@ -6,13 +6,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -34,12 +36,66 @@ type HypercoreVMReplicationResource struct {
 
 // HypercoreVMReplicationResourceModel describes the resource data model.
 type HypercoreVMReplicationResourceModel struct {
-	Id             types.String `tfsdk:"id"`
-	VmUUID         types.String `tfsdk:"vm_uuid"`
-	Label          types.String `tfsdk:"label"`
-	ConnectionUUID types.String `tfsdk:"connection_uuid"`
-	Enable         types.Bool   `tfsdk:"enable"`
-	TargetVmUUID   types.String `tfsdk:"target_vm_uuid"`
+	Id                       types.String             `tfsdk:"id"`
+	VmUUID                   types.String             `tfsdk:"vm_uuid"`
+	Label                    types.String             `tfsdk:"label"`
+	ConnectionUUID           types.String             `tfsdk:"connection_uuid"`
+	Enable                   types.Bool               `tfsdk:"enable"`
+	TargetVmUUID             types.String             `tfsdk:"target_vm_uuid"`
+	WaitForInitialSync       *WaitForInitialSyncModel `tfsdk:"wait_for_initial_sync"`
+	State                    types.String             `tfsdk:"state"`
+	ConnectionStatus         types.String             `tfsdk:"connection_status"`
+	ProgressPercent          types.Int64              `tfsdk:"progress_percent"`
+	LastReplication          types.String             `tfsdk:"last_replication"`
+	LastReplicationTimestamp types.Int64              `tfsdk:"last_replication_timestamp"`
+	LagSeconds               types.Int64              `tfsdk:"lag_seconds"`
+	StartedTimestamp         types.Int64              `tfsdk:"started_timestamp"`
+}
+
+type WaitForInitialSyncModel struct {
+	Timeout types.Int32 `tfsdk:"timeout"`
+}
+
+// replicationStarted returns started_timestamp. If not known yet (after import), it is set to now.
+func (data *HypercoreVMReplicationResourceModel) replicationStarted() time.Time {
+	if data.StartedTimestamp.IsNull() || data.StartedTimestamp.IsUnknown() {
+		data.StartedTimestamp = types.Int64Value(time.Now().Unix())
+	}
+	return time.Unix(data.StartedTimestamp.ValueInt64(), 0)
+}
+
+// setFromHC3Replication stores replication settings and status read from HC3.
+// hc3Connection is the remote cluster connection of the replication, nil if it was not found.
+func setFromHC3Replication(data *HypercoreVMReplicationResourceModel, hc3Replication map[string]any, hc3Connection *map[string]any, hc3Snapshots []map[string]any) {
+	since := data.replicationStarted()
+	data.TargetVmUUID = types.StringValue(utils.AnyToString(hc3Replication["targetDomainUUID"]))
+	data.VmUUID = types.StringValue(utils.AnyToString(hc3Replication["sourceDomainUUID"]))
+	data.ConnectionUUID = types.StringValue(utils.AnyToString(hc3Replication["connectionUUID"]))
+	data.Label = types.StringValue(utils.AnyToString(hc3Replication["label"]))
+	data.Enable = types.BoolValue(utils.AnyToBool(hc3Replication["enable"]))
+
+	state := utils.GetReplicationState(hc3Replication, hc3Connection, hc3Snapshots, since)
+	data.State = types.StringValue(state)
+	data.ConnectionStatus = types.StringNull()
+	if hc3Connection != nil {
+		data.ConnectionStatus = types.StringValue(utils.AnyToStringOrEmpty((*hc3Connection)["connectionStatus"]))
+	}
+	data.ProgressPercent = types.Int64Null()
+	if percent, ok := utils.GetReplicationProgressPercent(hc3Replication); ok {
+		data.ProgressPercent = types.Int64Value(percent)
+	}
+	data.LastReplication = types.StringNull()
+	data.LastReplicationTimestamp = types.Int64Null()
+	data.LagSeconds = types.Int64Null()
+	if state == utils.REPLICATION_STATE_UNKNOWN || state == utils.REPLICATION_STATE_ERROR {
+		// Snapshots can't be confirmed to reach the target.
+		return
+	}
+	if lastSync, ok := utils.GetReplicationLastSync(hc3Replication, hc3Snapshots, since); ok {
+		data.LastReplication = types.StringValue(lastSync.UTC().Format(time.RFC3339))
+		data.LastReplicationTimestamp = types.Int64Value(lastSync.Unix())
+		data.LagSeconds = types.Int64Value(int64(time.Since(lastSync).Seconds()))
+	}
 }
 
 func (r *HypercoreVMReplicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -49,7 +105,10 @@ func (r *HypercoreVMReplicationResource) Metadata(ctx context.Context, req resou
 func (r *HypercoreVMReplicationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Hypercore VM replication resource to manage VM boot devices' order",
+		MarkdownDescription: "" +
+			"Hypercore VM replication resource to manage VM replication to a remote cluster. <br><br>" +
+			"If the source VM already has a replication configured, it is adopted and updated instead of creating a new one. " +
+			"Replication status is refreshed on every read.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -93,6 +152,57 @@ func (r *HypercoreVMReplicationResource) Schema(ctx context.Context, req resourc
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"wait_for_initial_sync": schema.SingleNestedAttribute{
+				MarkdownDescription: "" +
+					"Wait after create or update until the first snapshot taken after `started_timestamp` is fully replicated. " +
+					"Apply fails if `timeout` expires, or if the replication is disabled.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"timeout": schema.Int32Attribute{
+						MarkdownDescription: "Seconds to wait. Default is `3600`.",
+						Optional:            true,
+					},
+				},
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"Replication state. Can be: `DISABLED`, `UNKNOWN` (remote cluster connection not found), " +
+					"`ERROR` (remote cluster connection is not healthy), `SYNCING` (initial sync or a transfer is in progress), " +
+					"`ACTIVE` (all snapshots are replicated).",
+				Computed: true,
+			},
+			"connection_status": schema.StringAttribute{
+				MarkdownDescription: "Status of the remote cluster connection, as reported by HC3. Null if the connection is not found.",
+				Computed:            true,
+			},
+			"progress_percent": schema.Int64Attribute{
+				MarkdownDescription: "Progress of the current transfer in percent. Null if not reported by HC3.",
+				Computed:            true,
+			},
+			"last_replication": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"Creation time of the newest fully replicated snapshot, RFC3339 timestamp in UTC. " +
+					"Only snapshots taken after `started_timestamp` are counted. " +
+					"Null if nothing was replicated yet, or `state` is `UNKNOWN` or `ERROR`.",
+				Computed: true,
+			},
+			"last_replication_timestamp": schema.Int64Attribute{
+				MarkdownDescription: "Same as `last_replication`, Unix timestamp.",
+				Computed:            true,
+			},
+			"lag_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Seconds between `last_replication` and the last refresh. Null if `last_replication` is null.",
+				Computed:            true,
+			},
+			"started_timestamp": schema.Int64Attribute{
+				MarkdownDescription: "" +
+					"When Terraform created, adopted or imported the replication, Unix timestamp. " +
+					"Older snapshots are not counted as replicated, because HC3 does not report which snapshots reached the target.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -118,6 +228,18 @@ func (r *HypercoreVMReplicationResource) Configure(ctx context.Context, req reso
 	r.client = restClient
 }
 
+// waitForInitialSync waits on wait_for_initial_sync, if it is configured.
+func (r *HypercoreVMReplicationResource) waitForInitialSync(ctx context.Context, data *HypercoreVMReplicationResourceModel, replicationUUID string) diag.Diagnostic {
+	if data.WaitForInitialSync == nil {
+		return nil
+	}
+	timeout := int32(3600)
+	if !data.WaitForInitialSync.Timeout.IsNull() {
+		timeout = data.WaitForInitialSync.Timeout.ValueInt32()
+	}
+	return utils.WaitVMReplicationInitialSync(*r.client, replicationUUID, data.replicationStarted(), timeout, ctx)
+}
+
 func (r *HypercoreVMReplicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
 
@@ -148,38 +270,63 @@ func (r *HypercoreVMReplicationResource) Create(ctx context.Context, req resourc
 		enable = true // default it to true, like in the API
 	}
 
-	if connectionUUID == "" {
-		resp.Diagnostics.AddError(
-			"Missing connection_uuid",
-			"Parameter 'connection_uuid' is required for creating a VM replication",
-		)
-	}
+	// Snapshots taken before were not replicated by this replication, or not since it is managed by Terraform.
+	data.StartedTimestamp = types.Int64Value(time.Now().Unix())
 
-	tflog.Info(ctx, fmt.Sprintf("TTRT Create: vm_uuid=%s, connection_uuid=%s, label=%s, enable=%t", vmUUID, connectionUUID, label, enable))
+	var replicationUUID string
+	if pExisting := utils.GetVMReplicationBySourceVM(restClient, vmUUID); pExisting != nil {
+		// HC3 allows one replication per VM, adopt it. Settings not given in config are kept.
+		existing := *pExisting
+		replicationUUID = utils.AnyToString(existing["uuid"])
+		if connectionUUID == "" {
+			connectionUUID = utils.AnyToString(existing["connectionUUID"])
+		}
+		if data.Label.IsUnknown() || data.Label.IsNull() {
+			label = utils.AnyToString(existing["label"])
+		}
+		if data.Enable.IsUnknown() || data.Enable.IsNull() {
+			enable = utils.AnyToBool(existing["enable"])
+		}
+		tflog.Info(ctx, fmt.Sprintf("TTRT Create: adopting replication_uuid=%s, vm_uuid=%s, connection_uuid=%s, label=%s, enable=%t", replicationUUID, vmUUID, connectionUUID, label, enable))
 
-	replicationUUID, replication, _diag := utils.CreateVMReplication(restClient, vmUUID, connectionUUID, label, enable, ctx)
-	if _diag != nil {
-		if _diag.Severity() == 0 { // if is error
-			resp.Diagnostics.AddError(_diag.Summary(), _diag.Detail())
+		_diag := utils.UpdateVMReplication(restClient, replicationUUID, connectionUUID, label, enable, ctx)
+		if _diag != nil {
+			resp.Diagnostics.AddWarning(_diag.Summary(), _diag.Detail())
+		}
+	} else {
+		if connectionUUID == "" {
+			resp.Diagnostics.AddError(
+				"Missing connection_uuid",
+				"Parameter 'connection_uuid' is required for creating a VM replication",
+			)
 			return
 		}
 
-		// otherwise it's a warning
-		resp.Diagnostics.AddWarning(_diag.Summary(), _diag.Detail())
+		tflog.Info(ctx, fmt.Sprintf("TTRT Create: vm_uuid=%s, connection_uuid=%s, label=%s, enable=%t", vmUUID, connectionUUID, label, enable))
+
+		var _diag diag.Diagnostic
+		replicationUUID, _, _diag = utils.CreateVMReplication(restClient, vmUUID, connectionUUID, label, enable, ctx)
+		if _diag != nil {
+			resp.Diagnostics.AddError(_diag.Summary(), _diag.Detail())
+			return
+		}
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("TTRT Created: vm_uuid=%s, connection_uuid=%s, label=%s, enable=%t, replication=%v", vmUUID, connectionUUID, label, enable, replication))
+	if _diag := r.waitForInitialSync(ctx, &data, replicationUUID); _diag != nil {
+		resp.Diagnostics.Append(_diag)
+	}
 
-	targetVmUUID := ""
-	if replication["targetDomainUUID"] != nil {
-		targetVmUUID = utils.AnyToString(targetVmUUID)
+	pReplication := utils.GetVMReplicationByUUID(restClient, replicationUUID)
+	if pReplication == nil {
+		resp.Diagnostics.AddError("VM replication not found", fmt.Sprintf("VM replication not found - replicationUUID=%s", replicationUUID))
+		return
 	}
+	replication := *pReplication
+	tflog.Info(ctx, fmt.Sprintf("TTRT Created: vm_uuid=%s, connection_uuid=%s, label=%s, enable=%t, replication=%v", vmUUID, connectionUUID, label, enable, replication))
 
-	// TODO: Check if HC3 matches TF
 	// save into the Terraform state.
 	data.Id = types.StringValue(replicationUUID)
-	data.TargetVmUUID = types.StringValue(targetVmUUID)
-	data.Enable = types.BoolValue(enable)
+	setFromHC3Replication(&data, replication, utils.GetVMReplicationConnection(restClient, replication), utils.ListVMReplicationSnapshots(restClient, vmUUID))
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -219,11 +366,7 @@ func (r *HypercoreVMReplicationResource) Read(ctx context.Context, req resource.
 
 	// save into the Terraform state.
 	data.Id = types.StringValue(replicationUUID)
-	data.TargetVmUUID = types.StringValue(utils.AnyToString(hc3Replication["targetDomainUUID"]))
-	data.VmUUID = types.StringValue(utils.AnyToString(hc3Replication["sourceDomainUUID"]))
-	data.ConnectionUUID = types.StringValue(utils.AnyToString(hc3Replication["connectionUUID"]))
-	data.Label = types.StringValue(utils.AnyToString(hc3Replication["label"]))
-	data.Enable = types.BoolValue(utils.AnyToBool(hc3Replication["enable"]))
+	setFromHC3Replication(&data, hc3Replication, utils.GetVMReplicationConnection(restClient, hc3Replication), utils.ListVMReplicationSnapshots(restClient, vmUUID))
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -281,10 +424,13 @@ func (r *HypercoreVMReplicationResource) Update(ctx context.Context, req resourc
 		return
 	}
 
+	_diag := utils.UpdateVMReplication(restClient, replicationUUID, connectionUUID, label, enable, ctx)
+	if _diag != nil {
+		resp.Diagnostics.AddWarning(_diag.Summary(), _diag.Detail())
+	}
 
-	diag := utils.UpdateVMReplication(restClient, replicationUUID, connectionUUID, label, enable, ctx)
-	if diag != nil {
-		resp.Diagnostics.AddWarning(diag.Summary(), diag.Detail())
+	if _diag := r.waitForInitialSync(ctx, &data, replicationUUID); _diag != nil {
+		resp.Diagnostics.Append(_diag)
 	}
 
 	// TODO: Check if HC3 matches TF
@@ -298,6 +444,7 @@ func (r *HypercoreVMReplicationResource) Update(ctx context.Context, req resourc
 	newHc3Replication := *pReplication
 
 	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreVMReplicationResource: replication_uuid=%s, replication=%v", replicationUUID, newHc3Replication))
+	setFromHC3Replication(&data, newHc3Replication, utils.GetVMReplicationConnection(restClient, newHc3Replication), utils.ListVMReplicationSnapshots(restClient, newVMUUID))
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -18,9 +18,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
@@ -50,6 +52,9 @@ type HypercoreVMResourceModel struct {
 	SnapshotScheduleUUID types.String           `tfsdk:"snapshot_schedule_uuid"`
 	Clone                *CloneModel            `tfsdk:"clone"`
 	AffinityStrategy     *AffinityStrategyModel `tfsdk:"affinity_strategy"`
+	WaitForGuest         *WaitForGuestModel     `tfsdk:"wait_for_guest"`
+	Ipv4Addresses        types.List             `tfsdk:"ipv4_addresses"`
+	PrimaryIpv4          types.String           `tfsdk:"primary_ipv4"`
 	Id                   types.String           `tfsdk:"id"`
 }
 
@@ -69,6 +74,16 @@ type CloneModel struct {
 	PreserveMacAddress types.Bool   `tfsdk:"preserve_mac_address"`
 }
 
+type WaitForGuestModel struct {
+	Timeout          types.Int32  `tfsdk:"timeout"`
+	GuestAgent       types.Bool   `tfsdk:"guest_agent"`
+	MinIpv4Addresses types.Int64  `tfsdk:"min_ipv4_addresses"`
+	Ipv4CIDR         types.String `tfsdk:"ipv4_cidr"`
+	NicVlan          types.Int64  `tfsdk:"nic_vlan"`
+	NicUUID          types.String `tfsdk:"nic_uuid"`
+	NicMacAddress    types.String `tfsdk:"nic_mac_address"`
+}
+
 type AffinityStrategyModel struct {
 	StrictAffinity    types.Bool   `tfsdk:"strict_affinity"`
 	PreferredNodeUUID types.String `tfsdk:"preferred_node_uuid"`
@@ -120,10 +135,14 @@ The provider will currently try to shutdown VM only before VM delete.`,
 				Optional: true,
 			},
 			"snapshot_schedule_uuid": schema.StringAttribute{
-				MarkdownDescription: "UUID of the snapshot schedule to create automatic snapshots",
-				Optional:            true,
-				Computed:            true,
-				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "" +
+					"UUID of the snapshot schedule to create automatic snapshots. " +
+					"If not set, the schedule is removed from the VM. <br>" +
+					"If the schedule is assigned with `hypercore_vm_snapshot_schedule_assignment`, " +
+					"add `snapshot_schedule_uuid` to `lifecycle.ignore_changes`, otherwise this resource removes it again.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
 			"import": schema.SingleNestedAttribute{
 				MarkdownDescription: "Options for importing a VM through a SMB server or some other HTTP location. <br>" +
@@ -203,6 +222,30 @@ The provider will currently try to shutdown VM only before VM delete.`,
 					},
 				},
 			},
+			"wait_for_guest": waitForGuestAttribute("" +
+				"Readiness gates checked after the VM is created or updated. " +
+				"The provider waits until all configured conditions are met, or fails when `timeout` expires.<br>" +
+				"Conditions are checked only if the VM is `RUNNING` or being started, otherwise they are skipped. " +
+				"New VMs are created powered off; use `wait_for_guest` of `hypercore_vm_power_state` to wait after start. " +
+				"Guest OS needs to have guest tools installed (qemu-guest-agent)."),
+			"ipv4_addresses": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "IPv4 addresses reported by the guest OS, for all NICs.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"primary_ipv4": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"First IPv4 address reported by the guest OS. " +
+					"If `wait_for_guest` limits `ipv4_cidr` or the NIC, first matching address is used. " +
+					"Empty if no address is reported.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "HypercoreVM identifier",
@@ -279,6 +322,112 @@ func validateParameters(data *HypercoreVMResourceModel, ctx context.Context) (*s
 	}
 	return description, tags, diags
 }
+
+// waitForGuestAttribute is the wait_for_guest schema, shared by resources which can wait on a running VM.
+func waitForGuestAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"timeout": schema.Int32Attribute{
+				MarkdownDescription: "Seconds to wait. Default is `300`.",
+				Optional:            true,
+			},
+			"guest_agent": schema.BoolAttribute{
+				MarkdownDescription: "Wait until guest agent is available.",
+				Optional:            true,
+			},
+			"min_ipv4_addresses": schema.Int64Attribute{
+				MarkdownDescription: "" +
+					"Wait until guest reports at least this many IPv4 addresses. " +
+					"Default is `1` if `ipv4_cidr` or a NIC is set, `0` otherwise.",
+				Optional: true,
+			},
+			"ipv4_cidr": schema.StringAttribute{
+				MarkdownDescription: "Count only IPv4 addresses inside this CIDR, for example `10.0.0.0/24`.",
+				Optional:            true,
+				Validators: []validator.String{
+					ipv4CIDRValidator(),
+				},
+			},
+			"nic_vlan": schema.Int64Attribute{
+				MarkdownDescription: "Count only IPv4 addresses of NICs on this VLAN.",
+				Optional:            true,
+			},
+			"nic_uuid": schema.StringAttribute{
+				MarkdownDescription: "Count only IPv4 addresses of the NIC with this UUID.",
+				Optional:            true,
+			},
+			"nic_mac_address": schema.StringAttribute{
+				MarkdownDescription: "Count only IPv4 addresses of the NIC with this MAC address.",
+				Optional:            true,
+				Validators: []validator.String{
+					macAddressValidator(),
+				},
+			},
+		},
+	}
+}
+
+func (m *WaitForGuestModel) toGuestReadiness() utils.GuestReadiness {
+	if m == nil {
+		return utils.GuestReadiness{}
+	}
+	return utils.GuestReadiness{
+		GuestAgent:       m.GuestAgent.ValueBool(),
+		MinIpv4Addresses: m.MinIpv4Addresses.ValueInt64(),
+		Ipv4CIDR:         m.Ipv4CIDR.ValueString(),
+		Nic: utils.GuestNic{
+			UUID:       m.NicUUID.ValueString(),
+			MacAddress: m.NicMacAddress.ValueString(),
+			Vlan:       m.NicVlan.ValueInt64Pointer(),
+		},
+	}
+}
+
+// waitForGuest waits on wait_for_guest readiness gates, if they are configured.
+func waitForGuest(ctx context.Context, restClient utils.RestClient, vmUUID string, m *WaitForGuestModel) diag.Diagnostic {
+	if m == nil {
+		return nil
+	}
+	readiness := m.toGuestReadiness()
+	if d := utils.ValidateGuestReadiness(readiness); d != nil {
+		return d
+	}
+	timeout := int32(utils.SHUTDOWN_TIMEOUT_SECONDS)
+	if !m.Timeout.IsNull() {
+		timeout = m.Timeout.ValueInt32()
+	}
+	return utils.WaitGuestReadiness(vmUUID, readiness, timeout, restClient, ctx)
+}
+
+// waitForGuestIfStarted waits on wait_for_guest readiness gates only if the VM is running or being started.
+// A stopped VM can't become ready, so the gates are skipped.
+func waitForGuestIfStarted(ctx context.Context, restClient utils.RestClient, vmUUID string, m *WaitForGuestModel) diag.Diagnostic {
+	if m == nil {
+		return nil
+	}
+	vm, err := utils.GetOneVMWithError(vmUUID, restClient)
+	if err != nil {
+		return diag.NewErrorDiagnostic("VM not found", err.Error())
+	}
+	powerState := utils.PowerStateFromHypercore((*vm)["state"])
+	desiredState := utils.PowerStateFromHypercore((*vm)["desiredDisposition"])
+	if powerState != utils.POWER_STATE_RUNNING && desiredState != utils.POWER_STATE_RUNNING {
+		tflog.Info(ctx, fmt.Sprintf("TTRT waitForGuestIfStarted: VM %s is not started (state=%s), wait_for_guest skipped", vmUUID, powerState))
+		return nil
+	}
+	return waitForGuest(ctx, restClient, vmUUID, m)
+}
+
+// setGuestNetwork stores IPv4 addresses reported by the guest OS.
+func setGuestNetwork(ctx context.Context, data *HypercoreVMResourceModel, hc3VM map[string]any) diag.Diagnostics {
+	ipv4Addresses, diags := types.ListValueFrom(ctx, types.StringType, utils.GetVMIpv4Addresses(hc3VM, utils.GuestNic{}))
+	data.Ipv4Addresses = ipv4Addresses
+	data.PrimaryIpv4 = types.StringValue(utils.GetVMPrimaryIpv4(hc3VM, data.WaitForGuest.toGuestReadiness()))
+	return diags
+}
+
 func isHTTPImport(data *HypercoreVMResourceModel) bool {
 	// Check if HTTP URI is being used for VM import
 	httpUri := data.Import.HTTPUri.ValueString()
@@ -403,6 +552,16 @@ func (r *HypercoreVMResource) Create(ctx context.Context, req resource.CreateReq
 
 	// Right now handles import or clone TODO: Add other VM create options here
 	r.doCreateLogic(&data, ctx, resp, description, tags)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d := waitForGuestIfStarted(ctx, *r.client, data.Id.ValueString(), data.WaitForGuest); d != nil {
+		resp.Diagnostics.Append(d)
+	}
+
+	hc3_vm := utils.GetOneVM(data.Id.ValueString(), *r.client)
+	resp.Diagnostics.Append(setGuestNetwork(ctx, &data, hc3_vm)...)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -461,17 +620,8 @@ func (r *HypercoreVMResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	// NOTE: power state not needed here anymore because of the hypercore_vm_power_state resource
-	// hc3_power_state := utils.AnyToString(hc3_vm["state"])
-	// // line below look like correct thing to do. But "terraform plan -refresh-only"
-	// // complains about change 'power_state = "stop" -> "stopped"
-	// tf_power_state := types.StringValue(utils.FromHypercoreToTerraformPowerState[hc3_power_state])
-	// // TEMP make "terraform plan -refresh-only" report "nothing changed"
-	// hc3_stopped_states := []string{"SHUTOFF", "CRASHED"}
-	// if slices.Contains(hc3_stopped_states, hc3_power_state) {
-	// 	tf_power_state = types.StringValue("stop")
-	// }
-	// data.PowerState = tf_power_state
+	// NOTE: power state not needed here anymore because of the hypercore_vm_power_state resource.
+	// It uses canonical power states, see utils.PowerStateFromHypercore.
 
 	// desiredDisposition TODO
 	// uiState TODO
@@ -485,6 +635,8 @@ func (r *HypercoreVMResource) Read(ctx context.Context, req resource.ReadRequest
 	data.AffinityStrategy.PreferredNodeUUID = types.StringValue(utils.AnyToString(affinityStrategy["preferredNodeUUID"]))
 	data.AffinityStrategy.BackupNodeUUID = types.StringValue(utils.AnyToString(affinityStrategy["backupNodeUUID"]))
 
+	resp.Diagnostics.Append(setGuestNetwork(ctx, &data, hc3_vm)...)
+
 	// ==============================================================================
 
 	// Save updated data into Terraform state
@@ -572,6 +724,11 @@ func (r *HypercoreVMResource) Update(ctx context.Context, req resource.UpdateReq
 	)
 	taskTag.WaitTask(restClient, ctx)
 
+	if d := waitForGuestIfStarted(ctx, restClient, vm_uuid, data.WaitForGuest); d != nil {
+		resp.Diagnostics.Append(d)
+	}
+	resp.Diagnostics.Append(setGuestNetwork(ctx, &data, utils.GetOneVM(vm_uuid, restClient))...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
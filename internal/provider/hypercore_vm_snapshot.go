@@ -6,12 +6,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
@@ -32,10 +36,84 @@ type HypercoreVMSnapshotResource struct {
 
 // HypercoreVMSnapshotResourceModel describes the resource data model.
 type HypercoreVMSnapshotResourceModel struct {
-	Id     types.String `tfsdk:"id"`
-	VmUUID types.String `tfsdk:"vm_uuid"`
-	Type   types.String `tfsdk:"type"`
-	Label  types.String `tfsdk:"label"`
+	Id                         types.String `tfsdk:"id"`
+	VmUUID                     types.String `tfsdk:"vm_uuid"`
+	Type                       types.String `tfsdk:"type"`
+	Label                      types.String `tfsdk:"label"`
+	LocalRetainUntil           types.String `tfsdk:"local_retain_until"`
+	RemoteRetainUntil          types.String `tfsdk:"remote_retain_until"`
+	Replicate                  types.Bool   `tfsdk:"replicate"`
+	LocalRetainUntilTimestamp  types.Int64  `tfsdk:"local_retain_until_timestamp"`
+	RemoteRetainUntilTimestamp types.Int64  `tfsdk:"remote_retain_until_timestamp"`
+}
+
+// retentionPayload returns HC3 retention fields. Durations are relative to snapshot creation time.
+func (data *HypercoreVMSnapshotResourceModel) retentionPayload(created time.Time) (map[string]any, diag.Diagnostic) {
+	if created.Unix() == 0 {
+		// HC3 did not report creation time, durations are relative to now instead.
+		created = time.Now()
+	}
+	localRetainUntil, d := utils.ParseRetainUntil(data.LocalRetainUntil.ValueString(), created)
+	if d != nil {
+		return nil, d
+	}
+	remoteRetainUntil, d := utils.ParseRetainUntil(data.RemoteRetainUntil.ValueString(), created)
+	if d != nil {
+		return nil, d
+	}
+	return map[string]any{
+		"localRetainUntilTimestamp":  localRetainUntil,
+		"remoteRetainUntilTimestamp": remoteRetainUntil,
+		"replication":                data.Replicate.ValueBool(),
+	}, nil
+}
+
+// validateRetainUntilChanged rejects retain-until timestamps in the past. Only values different
+// from prior are checked, so an applied timestamp does not fail later plans once it passes.
+func (data *HypercoreVMSnapshotResourceModel) validateRetainUntilChanged(prior *HypercoreVMSnapshotResourceModel, diags *diag.Diagnostics) {
+	now := time.Now()
+	if prior == nil || !data.LocalRetainUntil.Equal(prior.LocalRetainUntil) {
+		if d := utils.ValidateRetainUntilNotPast(data.LocalRetainUntil.ValueString(), now); d != nil {
+			diags.AddAttributeError(path.Root("local_retain_until"), d.Summary(), d.Detail())
+		}
+	}
+	if prior == nil || !data.RemoteRetainUntil.Equal(prior.RemoteRetainUntil) {
+		if d := utils.ValidateRetainUntilNotPast(data.RemoteRetainUntil.ValueString(), now); d != nil {
+			diags.AddAttributeError(path.Root("remote_retain_until"), d.Summary(), d.Detail())
+		}
+	}
+}
+
+// setFromHC3Snapshot copies HC3 snapshot into the model. Configured retain-until values are kept
+// if they still match HC3, otherwise they are replaced with the HC3 value so the drift is planned.
+func (data *HypercoreVMSnapshotResourceModel) setFromHC3Snapshot(hc3Snap map[string]any) {
+	created := utils.GetVMSnapshotCreated(hc3Snap)
+	localRetainUntil := utils.AnyToInteger64(hc3Snap["localRetainUntilTimestamp"])
+	remoteRetainUntil := utils.AnyToInteger64(hc3Snap["remoteRetainUntilTimestamp"])
+
+	data.VmUUID = types.StringValue(utils.AnyToString(hc3Snap["domainUUID"]))
+	data.Label = types.StringValue(utils.AnyToString(hc3Snap["label"]))
+	data.Type = types.StringValue(utils.AnyToString(hc3Snap["type"]))
+	data.LocalRetainUntil = reconcileRetainUntil(data.LocalRetainUntil, localRetainUntil, created)
+	data.RemoteRetainUntil = reconcileRetainUntil(data.RemoteRetainUntil, remoteRetainUntil, created)
+	data.LocalRetainUntilTimestamp = types.Int64Value(localRetainUntil)
+	data.RemoteRetainUntilTimestamp = types.Int64Value(remoteRetainUntil)
+	if hc3Snap["replication"] != nil {
+		data.Replicate = types.BoolValue(utils.AnyToBool(hc3Snap["replication"]))
+	} else if data.Replicate.IsNull() || data.Replicate.IsUnknown() {
+		data.Replicate = types.BoolValue(true)
+	}
+}
+
+func reconcileRetainUntil(configured types.String, actual int64, created time.Time) types.String {
+	expected, d := utils.ParseRetainUntil(configured.ValueString(), created)
+	if d == nil && expected == actual {
+		return configured
+	}
+	if actual == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(utils.FormatRetainUntil(actual))
 }
 
 func (r *HypercoreVMSnapshotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -45,7 +123,10 @@ func (r *HypercoreVMSnapshotResource) Metadata(ctx context.Context, req resource
 func (r *HypercoreVMSnapshotResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Hypercore VM snapshot resource to manage VM snapshots",
+		MarkdownDescription: "" +
+			"Hypercore VM snapshot resource to manage VM snapshots. <br><br>" +
+			"Without `local_retain_until`, a snapshot is kept until the resource is destroyed. " +
+			"With it, HC3 removes the snapshot after the given time, and Terraform plans to create it again.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -57,6 +138,9 @@ func (r *HypercoreVMSnapshotResource) Schema(ctx context.Context, req resource.S
 			"vm_uuid": schema.StringAttribute{
 				MarkdownDescription: "VM UUID of which we want to create a snapshot.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"type": schema.StringAttribute{
 				MarkdownDescription: "Snapshot type. Can be: USER, AUTOMATED, SUPPORT",
@@ -66,9 +150,46 @@ func (r *HypercoreVMSnapshotResource) Schema(ctx context.Context, req resource.S
 				},
 			},
 			"label": schema.StringAttribute{
-				MarkdownDescription: "Snapshot label.",
+				MarkdownDescription: "Snapshot label. HC3 can't rename a snapshot, so changing it creates a new snapshot.",
 				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"local_retain_until": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"When HC3 removes the snapshot from this cluster. " +
+					"Either future RFC3339 timestamp (`2030-01-31T00:00:00Z`) or duration after snapshot creation (`12h`, `30d`, `2w`). " +
+					"If not set, the snapshot is retained until the resource is destroyed. " +
+					"A timestamp in the past is rejected when the value is set or changed.",
+				Optional: true,
+				Validators: []validator.String{
+					retainUntilValidator(),
+				},
+			},
+			"remote_retain_until": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"When HC3 removes the replicated snapshot from the remote cluster. " +
+					"Same format as `local_retain_until`. If not set, the replicated snapshot is retained forever.",
+				Optional: true,
+				Validators: []validator.String{
+					retainUntilValidator(),
+				},
+			},
+			"replicate": schema.BoolAttribute{
+				MarkdownDescription: "Replicate the snapshot to the remote cluster, if VM replication is configured. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"local_retain_until_timestamp": schema.Int64Attribute{
+				MarkdownDescription: "`local_retain_until` as Unix timestamp, as reported by HC3. `0` means retained forever.",
+				Computed:            true,
+			},
+			"remote_retain_until_timestamp": schema.Int64Attribute{
+				MarkdownDescription: "`remote_retain_until` as Unix timestamp, as reported by HC3. `0` means retained forever.",
+				Computed:            true,
 			},
 		},
 	}
@@ -127,6 +248,10 @@ func (r *HypercoreVMSnapshotResource) Create(ctx context.Context, req resource.C
 		)
 		return
 	}
+	data.validateRetainUntilChanged(nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Create VM snapshot
 	payload := map[string]any{
@@ -140,18 +265,32 @@ func (r *HypercoreVMSnapshotResource) Create(ctx context.Context, req resource.C
 		"localRetainUntilTimestamp":      0,
 		"remoteRetainUntilTimestamp":     0,
 		"blockCountDiffFromSerialNumber": -1,
-		"replication":                    true,
+		"replication":                    data.Replicate.ValueBool(),
 	}
 	snapUUID, snap, _diag := utils.CreateVMSnapshot(restClient, vmUUID, payload, ctx)
 	if _diag != nil {
-		resp.Diagnostics.AddWarning(_diag.Summary(), _diag.Detail())
+		resp.Diagnostics.AddError(_diag.Summary(), _diag.Detail())
+		return
 	}
 	tflog.Info(ctx, fmt.Sprintf("TTRT Created: vm_uuid=%s, label=%s, type=%s, snap=%v", vmUUID, snapLabel, snapType, snap))
 
-	// TODO: Check if HC3 matches TF
+	// Retention durations are relative to snapshot creation time, known only after the snapshot is created.
+	if !data.LocalRetainUntil.IsNull() || !data.RemoteRetainUntil.IsNull() {
+		retentionPayload, d := data.retentionPayload(utils.GetVMSnapshotCreated(snap))
+		if d != nil {
+			resp.Diagnostics.AddError(d.Summary(), d.Detail())
+			return
+		}
+		d = utils.UpdateVMSnapshot(restClient, snapUUID, retentionPayload, ctx)
+		if d != nil {
+			resp.Diagnostics.AddWarning(d.Summary(), d.Detail())
+		}
+		snap = *utils.GetVMSnapshotByUUID(restClient, snapUUID)
+	}
+
 	// save into the Terraform state.
 	data.Id = types.StringValue(snapUUID)
-	data.Type = types.StringValue(snapType)
+	data.setFromHC3Snapshot(snap)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -180,6 +319,12 @@ func (r *HypercoreVMSnapshotResource) Read(ctx context.Context, req resource.Rea
 
 	pHc3Snap := utils.GetVMSnapshotByUUID(restClient, snapUUID)
 	if pHc3Snap == nil {
+		if !data.LocalRetainUntil.IsNull() {
+			// Expired snapshot was removed by HC3, plan to create a new one.
+			tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreSnapshot: snap_uuid=%s not found, local_retain_until=%s", snapUUID, data.LocalRetainUntil.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Snapshot not found", fmt.Sprintf("Snapshot not found - snapUUID=%s", snapUUID))
 		return
 	}
@@ -187,37 +332,63 @@ func (r *HypercoreVMSnapshotResource) Read(ctx context.Context, req resource.Rea
 
 	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreSnapshot: snap_uuid=%s, vm_uuid=%s, label=%s, type=%s\n", snapUUID, data.VmUUID.ValueString(), data.Label.ValueString(), data.Type.ValueString()))
 
-	vmUUID := utils.AnyToString(hc3Snap["domainUUID"])
-	snapLabel := utils.AnyToString(hc3Snap["label"])
-	snapType := utils.AnyToString(hc3Snap["type"])
-
 	// save into the Terraform state.
 	data.Id = types.StringValue(snapUUID)
-	data.VmUUID = types.StringValue(vmUUID)
-	data.Label = types.StringValue(snapLabel)
-	data.Type = types.StringValue(snapType)
+	data.setFromHC3Snapshot(hc3Snap)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *HypercoreVMSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// NOTE: /rest/v1/VirDomainSnapshot has no update endpoints, so update is not needed here
-
-	// tflog.Info(ctx, "TTRT HypercoreVMSnapshotResource UPDATE")
-	// var data_state HypercoreVMSnapshotResourceModel
-	// resp.Diagnostics.Append(req.State.Get(ctx, &data_state)...)
-	// var data HypercoreVMSnapshotResourceModel
-	//
-	// // Read Terraform plan data into the model
-	// resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	//
-	// if resp.Diagnostics.HasError() {
-	// 	return
-	// }
-	//
-	// // Save updated data into Terraform state
-	// resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMSnapshotResource UPDATE")
+	var data_state HypercoreVMSnapshotResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data_state)...)
+	var data HypercoreVMSnapshotResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.validateRetainUntilChanged(&data_state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// vm_uuid and label require replacement, only retention and replication can be updated.
+	restClient := *r.client
+	snapUUID := data.Id.ValueString()
+	pHc3Snap := utils.GetVMSnapshotByUUID(restClient, snapUUID)
+	if pHc3Snap == nil {
+		resp.Diagnostics.AddError("Snapshot not found", fmt.Sprintf("Snapshot not found - snapUUID=%s", snapUUID))
+		return
+	}
+
+	retentionPayload, d := data.retentionPayload(utils.GetVMSnapshotCreated(*pHc3Snap))
+	if d != nil {
+		resp.Diagnostics.AddError(d.Summary(), d.Detail())
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreVMSnapshotResource Update snap_uuid=%s payload=%v", snapUUID, retentionPayload))
+	d = utils.UpdateVMSnapshot(restClient, snapUUID, retentionPayload, ctx)
+	if d != nil {
+		resp.Diagnostics.AddWarning(d.Summary(), d.Detail())
+	}
+
+	// Do not trust UpdateVMSnapshot made what we asked for. Read new snapshot state from HC3.
+	pHc3Snap = utils.GetVMSnapshotByUUID(restClient, snapUUID)
+	if pHc3Snap == nil {
+		resp.Diagnostics.AddError("Snapshot not found", fmt.Sprintf("Snapshot not found - snapUUID=%s", snapUUID))
+		return
+	}
+	data.setFromHC3Snapshot(*pHc3Snap)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *HypercoreVMSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
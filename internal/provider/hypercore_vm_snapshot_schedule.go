@@ -6,6 +6,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -16,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
@@ -24,6 +26,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &HypercoreVMSnapshotScheduleResource{}
 var _ resource.ResourceWithImportState = &HypercoreVMSnapshotScheduleResource{}
+var _ resource.ResourceWithValidateConfig = &HypercoreVMSnapshotScheduleResource{}
+var _ resource.ResourceWithModifyPlan = &HypercoreVMSnapshotScheduleResource{}
 
 func NewHypercoreVMSnapshotScheduleResource() resource.Resource {
 	return &HypercoreVMSnapshotScheduleResource{}
@@ -45,6 +49,9 @@ type RulesModel struct {
 	Name                   types.String `tfsdk:"name"`
 	StartTimestamp         types.String `tfsdk:"start_timestamp"`
 	Frequency              types.String `tfsdk:"frequency"`
+	Every                  types.String `tfsdk:"every"`
+	At                     types.String `tfsdk:"at"`
+	Weekdays               types.List   `tfsdk:"weekdays"`
 	LocalRetentionSeconds  types.Int64  `tfsdk:"local_retention_seconds"`
 	RemoteRetentionSeconds types.Int64  `tfsdk:"remote_retention_seconds"`
 }
@@ -53,6 +60,9 @@ var rulesModelAttrType = map[string]attr.Type{
 	"name":                     types.StringType,
 	"start_timestamp":          types.StringType,
 	"frequency":                types.StringType,
+	"every":                    types.StringType,
+	"at":                       types.StringType,
+	"weekdays":                 types.ListType{ElemType: types.StringType},
 	"local_retention_seconds":  types.Int64Type,
 	"remote_retention_seconds": types.Int64Type,
 }
@@ -64,6 +74,9 @@ func GetRulesAttrValues(rules []RulesModel) ([]attr.Value, diag.Diagnostics) {
 			"name":                     rule.Name,
 			"start_timestamp":          rule.StartTimestamp,
 			"frequency":                rule.Frequency,
+			"every":                    rule.Every,
+			"at":                       rule.At,
+			"weekdays":                 rule.Weekdays,
 			"local_retention_seconds":  rule.LocalRetentionSeconds,
 			"remote_retention_seconds": rule.RemoteRetentionSeconds,
 		}
@@ -83,7 +96,10 @@ func (r *HypercoreVMSnapshotScheduleResource) Metadata(ctx context.Context, req
 func (r *HypercoreVMSnapshotScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Hypercore VM snapshot schedule resource to manage VM snapshots",
+		MarkdownDescription: "" +
+			"Hypercore VM snapshot schedule resource to manage VM snapshots. <br><br>" +
+			"Each rule is given either as RFC-2445 RRULE in `frequency`, or with `every`, `at` and `weekdays`, " +
+			"which are converted to RRULE and shown in `frequency` and `start_timestamp`.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -110,12 +126,42 @@ func (r *HypercoreVMSnapshotScheduleResource) Schema(ctx context.Context, req re
 							Required:            true,
 						},
 						"start_timestamp": schema.StringAttribute{
-							MarkdownDescription: "Local timezone timestamp (2010-01-01 00:00:00) of when a snapshot is to be taken",
-							Required:            true,
+							MarkdownDescription: "" +
+								"Timestamp of when a snapshot is to be taken. Either cluster local time (2010-01-01 00:00:00), " +
+								"or RFC3339 with timezone (2010-01-01T00:00:00Z), converted to cluster timezone. " +
+								"Conflicts with `at`. Default: `2010-01-01 00:00:00`.",
+							Optional: true,
+							Computed: true,
+							Validators: []validator.String{
+								scheduleStartTimestampValidator(),
+							},
 						},
 						"frequency": schema.StringAttribute{
-							MarkdownDescription: "Frequency based on RFC-2445 (FREQ=MINUTELY;INTERVAL=5)",
-							Required:            true,
+							MarkdownDescription: "Frequency based on RFC-2445 (FREQ=MINUTELY;INTERVAL=5). Conflicts with `every` and `weekdays`.",
+							Optional:            true,
+							Computed:            true,
+							Validators: []validator.String{
+								rruleValidator(),
+							},
+						},
+						"every": schema.StringAttribute{
+							MarkdownDescription: "Take a snapshot every given whole number of minutes, hours, days or weeks (`15m`, `1h`, `1d`, `2w`).",
+							Optional:            true,
+							Validators: []validator.String{
+								scheduleEveryValidator(),
+							},
+						},
+						"at": schema.StringAttribute{
+							MarkdownDescription: "Cluster local time of day (`02:30`) when the first snapshot of the day is taken. Conflicts with `start_timestamp`.",
+							Optional:            true,
+							Validators: []validator.String{
+								scheduleAtValidator(),
+							},
+						},
+						"weekdays": schema.ListAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "Take snapshots only on these weekdays. Can be: `MO`, `TU`, `WE`, `TH`, `FR`, `SA`, `SU`. Without `every`, a snapshot is taken once on each of these days.",
+							Optional:            true,
 						},
 						"local_retention_seconds": schema.Int64Attribute{
 							MarkdownDescription: "Number of seconds before snapshots are removed",
@@ -134,6 +180,170 @@ func (r *HypercoreVMSnapshotScheduleResource) Schema(ctx context.Context, req re
 	}
 }
 
+func (r *HypercoreVMSnapshotScheduleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config HypercoreVMSnapshotScheduleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Rules.IsNull() || config.Rules.IsUnknown() {
+		return
+	}
+
+	for _, element := range config.Rules.Elements() {
+		if element.IsUnknown() {
+			return
+		}
+	}
+	var rules []RulesModel
+	resp.Diagnostics.Append(config.Rules.ElementsAs(ctx, &rules, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, rule := range rules {
+		rulePath := path.Root("rules").AtListIndex(i)
+		structured := !rule.Every.IsNull() || !rule.Weekdays.IsNull()
+		if !rule.Frequency.IsNull() && structured {
+			resp.Diagnostics.AddAttributeError(
+				rulePath.AtName("frequency"),
+				"Conflicting snapshot schedule rule frequency",
+				"Only one of 'frequency' and 'every'/'weekdays' can be set.",
+			)
+		}
+		if rule.Frequency.IsNull() && !structured {
+			resp.Diagnostics.AddAttributeError(
+				rulePath,
+				"Missing snapshot schedule rule frequency",
+				"One of 'frequency', 'every' or 'weekdays' must be set.",
+			)
+		}
+		if !rule.StartTimestamp.IsNull() && !rule.At.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				rulePath.AtName("at"),
+				"Conflicting snapshot schedule rule start",
+				"Only one of 'start_timestamp' and 'at' can be set.",
+			)
+		}
+		if structured && !rule.Every.IsUnknown() && !rule.Weekdays.IsUnknown() {
+			var weekdays []string
+			resp.Diagnostics.Append(rule.Weekdays.ElementsAs(ctx, &weekdays, false)...)
+			if _, d := utils.CompileScheduleRRule(rule.Every.ValueString(), weekdays); d != nil {
+				resp.Diagnostics.AddAttributeError(rulePath, d.Summary(), d.Detail())
+			}
+		}
+	}
+}
+
+// ModifyPlan compiles rules given with every, at and weekdays to frequency and start_timestamp.
+func (r *HypercoreVMSnapshotScheduleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan HypercoreVMSnapshotScheduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Rules.IsNull() || plan.Rules.IsUnknown() {
+		return
+	}
+
+	for _, element := range plan.Rules.Elements() {
+		if element.IsUnknown() {
+			return
+		}
+	}
+	var rules []RulesModel
+	resp.Diagnostics.Append(plan.Rules.ElementsAs(ctx, &rules, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, rule := range rules {
+		if rule.Frequency.IsUnknown() && !rule.Every.IsUnknown() && !rule.Weekdays.IsUnknown() &&
+			(!rule.Every.IsNull() || !rule.Weekdays.IsNull()) {
+			var weekdays []string
+			resp.Diagnostics.Append(rule.Weekdays.ElementsAs(ctx, &weekdays, false)...)
+			rrule, d := utils.CompileScheduleRRule(rule.Every.ValueString(), weekdays)
+			if d != nil {
+				resp.Diagnostics.AddError(d.Summary(), d.Detail())
+				return
+			}
+			rules[i].Frequency = types.StringValue(rrule)
+		}
+		if rule.StartTimestamp.IsUnknown() && !rule.At.IsUnknown() {
+			start, d := utils.CompileScheduleStart(rule.At.ValueString())
+			if d != nil {
+				resp.Diagnostics.AddError(d.Summary(), d.Detail())
+				return
+			}
+			rules[i].StartTimestamp = types.StringValue(start)
+		}
+	}
+
+	ruleValues, diags := GetRulesAttrValues(rules)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Rules, diags = types.ListValue(types.ObjectType{AttrTypes: rulesModelAttrType}, ruleValues)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// scheduleRulesPayload converts rules to HC3 rrules. RFC3339 start timestamps are converted to cluster timezone.
+func scheduleRulesPayload(restClient utils.RestClient, scheduleRules []RulesModel) ([]map[string]any, diag.Diagnostic) {
+	payloadScheduleRules := []map[string]any{}
+	var clusterLocation *time.Location
+	for _, scheduleRule := range scheduleRules {
+		startTimestamp := scheduleRule.StartTimestamp.ValueString()
+		if !utils.IsScheduleStartInClusterTimezone(startTimestamp) {
+			if clusterLocation == nil {
+				var d diag.Diagnostic
+				clusterLocation, d = utils.GetClusterLocation(restClient)
+				if d != nil {
+					return nil, d
+				}
+			}
+			startTimestamp = utils.ScheduleStartToClusterLocal(startTimestamp, clusterLocation)
+		}
+		payloadScheduleRules = append(payloadScheduleRules, map[string]any{
+			"dtstart":                        startTimestamp,
+			"rrule":                          scheduleRule.Frequency.ValueString(),
+			"name":                           scheduleRule.Name.ValueString(),
+			"localRetentionDurationSeconds":  scheduleRule.LocalRetentionSeconds.ValueInt64(),
+			"remoteRetentionDurationSeconds": scheduleRule.RemoteRetentionSeconds.ValueInt64(),
+		})
+	}
+	return payloadScheduleRules, nil
+}
+
+// reconcileScheduleRule keeps the configured form of a rule read from HC3, if it is still equivalent.
+// Otherwise the HC3 value is used, so the drift is planned.
+func reconcileScheduleRule(restClient utils.RestClient, hc3Rule RulesModel, priorRule *RulesModel, clusterLocation **time.Location) RulesModel {
+	rule := hc3Rule
+	if priorRule == nil {
+		return rule
+	}
+	if utils.RRulesEqual(priorRule.Frequency.ValueString(), hc3Rule.Frequency.ValueString()) {
+		rule.Frequency = priorRule.Frequency
+		rule.Every = priorRule.Every
+		rule.Weekdays = priorRule.Weekdays
+	}
+	priorStart := priorRule.StartTimestamp.ValueString()
+	if !utils.IsScheduleStartInClusterTimezone(priorStart) {
+		if *clusterLocation == nil {
+			location, d := utils.GetClusterLocation(restClient)
+			if d != nil {
+				return rule
+			}
+			*clusterLocation = location
+		}
+		priorStart = utils.ScheduleStartToClusterLocal(priorStart, *clusterLocation)
+	}
+	if priorStart == hc3Rule.StartTimestamp.ValueString() {
+		rule.StartTimestamp = priorRule.StartTimestamp
+		rule.At = priorRule.At
+	}
+	return rule
+}
+
 func (r *HypercoreVMSnapshotScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	tflog.Info(ctx, "TTRT HypercoreVMSnapshotScheduleResource CONFIGURE")
 	// Prevent padisk if the provider has not been configured.
@@ -192,19 +402,10 @@ func (r *HypercoreVMSnapshotScheduleResource) Create(ctx context.Context, req re
 		}
 	}
 
-	var payloadScheduleRules []map[string]any
-	if len(scheduleRules) != 0 {
-		for _, scheduleRule := range scheduleRules {
-			payloadScheduleRules = append(payloadScheduleRules, map[string]any{
-				"dtstart":                        scheduleRule.StartTimestamp.ValueString(),
-				"rrule":                          scheduleRule.Frequency.ValueString(),
-				"name":                           scheduleRule.Name.ValueString(),
-				"localRetentionDurationSeconds":  scheduleRule.LocalRetentionSeconds.ValueInt64(),
-				"remoteRetentionDurationSeconds": scheduleRule.RemoteRetentionSeconds.ValueInt64(),
-			})
-		}
-	} else {
-		payloadScheduleRules = []map[string]any{} // empty list
+	payloadScheduleRules, d := scheduleRulesPayload(restClient, scheduleRules)
+	if d != nil {
+		resp.Diagnostics.AddError(d.Summary(), d.Detail())
+		return
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("TTRT Create: scheduleRules = %v", scheduleRules))
@@ -287,9 +488,22 @@ func (r *HypercoreVMSnapshotScheduleResource) Read(ctx context.Context, req reso
 	}
 	hc3Schedule := *pHc3Schedule
 
+	priorRules := map[string]RulesModel{}
+	if !data.Rules.IsNull() && !data.Rules.IsUnknown() {
+		var stateRules []RulesModel
+		resp.Diagnostics.Append(data.Rules.ElementsAs(ctx, &stateRules, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, stateRule := range stateRules {
+			priorRules[stateRule.Name.ValueString()] = stateRule
+		}
+	}
+
 	var scheduleRules []RulesModel
 	var ruleValues []attr.Value
 	var diags diag.Diagnostics
+	var clusterLocation *time.Location
 	if hc3Schedule["rrules"] != nil {
 		hc3Rules := utils.AnyToListOfMap(hc3Schedule["rrules"])
 		scheduleRules = make([]RulesModel, len(hc3Rules))
@@ -297,8 +511,17 @@ func (r *HypercoreVMSnapshotScheduleResource) Read(ctx context.Context, req reso
 			scheduleRules[i].Name = types.StringValue(utils.AnyToString(hc3Rule["name"]))
 			scheduleRules[i].Frequency = types.StringValue(utils.AnyToString(hc3Rule["rrule"]))
 			scheduleRules[i].StartTimestamp = types.StringValue(utils.AnyToString(hc3Rule["dtstart"]))
+			scheduleRules[i].Every = types.StringNull()
+			scheduleRules[i].At = types.StringNull()
+			scheduleRules[i].Weekdays = types.ListNull(types.StringType)
 			scheduleRules[i].LocalRetentionSeconds = types.Int64Value(utils.AnyToInteger64(hc3Rule["localRetentionDurationSeconds"]))
 			scheduleRules[i].RemoteRetentionSeconds = types.Int64Value(utils.AnyToInteger64(hc3Rule["remoteRetentionDurationSeconds"]))
+
+			var priorRule *RulesModel
+			if stateRule, ok := priorRules[scheduleRules[i].Name.ValueString()]; ok {
+				priorRule = &stateRule
+			}
+			scheduleRules[i] = reconcileScheduleRule(restClient, scheduleRules[i], priorRule, &clusterLocation)
 		}
 		ruleValues, diags = GetRulesAttrValues(scheduleRules)
 		if diags != nil {
@@ -381,19 +604,10 @@ func (r *HypercoreVMSnapshotScheduleResource) Update(ctx context.Context, req re
 		scheduleUUID, data_state.Name.ValueString(), dataStateScheduleRules),
 	)
 
-	var payloadScheduleRules []map[string]any
-	if len(scheduleRules) != 0 {
-		for _, scheduleRule := range scheduleRules {
-			payloadScheduleRules = append(payloadScheduleRules, map[string]any{
-				"dtstart":                        scheduleRule.StartTimestamp.ValueString(),
-				"rrule":                          scheduleRule.Frequency.ValueString(),
-				"name":                           scheduleRule.Name.ValueString(),
-				"localRetentionDurationSeconds":  scheduleRule.LocalRetentionSeconds.ValueInt64(),
-				"remoteRetentionDurationSeconds": scheduleRule.RemoteRetentionSeconds.ValueInt64(),
-			})
-		}
-	} else {
-		payloadScheduleRules = []map[string]any{} // empty list
+	payloadScheduleRules, d := scheduleRulesPayload(restClient, scheduleRules)
+	if d != nil {
+		resp.Diagnostics.AddError(d.Summary(), d.Detail())
+		return
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("TTRT Update: scheduleRules = %v", scheduleRules))
@@ -478,41 +692,24 @@ func (r *HypercoreVMSnapshotScheduleResource) Delete(ctx context.Context, req re
 }
 
 func (r *HypercoreVMSnapshotScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// NOTE: Do we need import state or would it be better to have a data source instead?
 	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
 
 	tflog.Info(ctx, "TTRT HypercoreVMSnapshotScheduleResource IMPORT_STATE")
 
-	scheduleUUID := req.ID
-	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreVMSnapshotScheduleResource: scheduleUUID=%s", scheduleUUID))
-
+	// Import ID is schedule UUID or schedule name.
 	restClient := *r.client
-	hc3Schedule := utils.GetVMSnapshotScheduleByUUID(restClient, scheduleUUID)
-
-	if hc3Schedule == nil {
-		msg := fmt.Sprintf("VM Schedule import, schedule not found -  'schedule_uuid'='%s'.", req.ID)
-		resp.Diagnostics.AddError("VM Schedule import error, schedule not found", msg)
+	hc3Schedules := restClient.ListRecords("/rest/v1/VirDomainSnapshotSchedule", map[string]any{}, -1.0, false)
+	hc3Schedule, d := utils.FindVMSnapshotScheduleForImport(hc3Schedules, req.ID)
+	if d != nil {
+		resp.Diagnostics.AddError(d.Summary(), d.Detail())
 		return
 	}
 
-	scheduleName := utils.AnyToString((*hc3Schedule)["name"])
-	tflog.Info(ctx, fmt.Sprintf("TTRT Import: schedule=%v", *hc3Schedule))
-
-	var scheduleRules []map[string]any
-	if (*hc3Schedule)["rrule"] != nil {
-		hc3Rules := utils.AnyToListOfMap((*hc3Schedule)["rrule"])
-		for _, hc3Rule := range hc3Rules {
-			scheduleRules = append(scheduleRules, map[string]any{
-				"name":                     hc3Rule["name"],
-				"start_timestamp":          hc3Rule["dtstart"],
-				"frequency":                hc3Rule["rrule"],
-				"local_retention_seconds":  hc3Rule["localRetentionDurationSeconds"],
-				"remote_retention_seconds": hc3Rule["remoteRetentionDurationSeconds"],
-			})
-		}
-	}
+	scheduleUUID := utils.AnyToString(hc3Schedule["uuid"])
+	scheduleName := utils.AnyToString(hc3Schedule["name"])
+	tflog.Info(ctx, fmt.Sprintf("TTRT Import: id=%s, schedule=%v", req.ID, hc3Schedule))
 
+	// Rules are loaded from HC3 by Read, which follows the import.
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), scheduleUUID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), scheduleName)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rules"), scheduleRules)...)
 }
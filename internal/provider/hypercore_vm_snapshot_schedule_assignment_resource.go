@@ -0,0 +1,380 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &HypercoreVMSnapshotScheduleAssignmentResource{}
+var _ resource.ResourceWithImportState = &HypercoreVMSnapshotScheduleAssignmentResource{}
+var _ resource.ResourceWithValidateConfig = &HypercoreVMSnapshotScheduleAssignmentResource{}
+var _ resource.ResourceWithModifyPlan = &HypercoreVMSnapshotScheduleAssignmentResource{}
+
+func NewHypercoreVMSnapshotScheduleAssignmentResource() resource.Resource {
+	return &HypercoreVMSnapshotScheduleAssignmentResource{}
+}
+
+// HypercoreVMSnapshotScheduleAssignmentResource defines the resource implementation.
+type HypercoreVMSnapshotScheduleAssignmentResource struct {
+	client *utils.RestClient
+}
+
+// HypercoreVMSnapshotScheduleAssignmentResourceModel describes the resource data model.
+type HypercoreVMSnapshotScheduleAssignmentResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	ScheduleUUID    types.String `tfsdk:"schedule_uuid"`
+	VMUUIDs         types.Set    `tfsdk:"vm_uuids"`
+	TagsAny         types.Set    `tfsdk:"tags_any"`
+	TagsAll         types.Set    `tfsdk:"tags_all"`
+	Exclusive       types.Bool   `tfsdk:"exclusive"`
+	AssignedVMUUIDs types.Set    `tfsdk:"assigned_vm_uuids"`
+}
+
+// selector converts configured VM UUIDs and tags. Null sets are returned as empty lists.
+func (data *HypercoreVMSnapshotScheduleAssignmentResourceModel) selector(ctx context.Context, diags *diag.Diagnostics) utils.ScheduleAssignmentSelector {
+	selector := utils.ScheduleAssignmentSelector{}
+	diags.Append(data.VMUUIDs.ElementsAs(ctx, &selector.VMUUIDs, false)...)
+	diags.Append(data.TagsAny.ElementsAs(ctx, &selector.TagsAny, false)...)
+	diags.Append(data.TagsAll.ElementsAs(ctx, &selector.TagsAll, false)...)
+	return selector
+}
+
+func (r *HypercoreVMSnapshotScheduleAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_snapshot_schedule_assignment"
+}
+
+func (r *HypercoreVMSnapshotScheduleAssignmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "" +
+			"Hypercore VM snapshot schedule assignment resource to attach one snapshot schedule to many VMs. <br><br>" +
+			"VMs are selected by UUID, by tags, or both. Tag selection is evaluated on every plan, " +
+			"so newly tagged VMs get the schedule on the next apply. " +
+			"This resource owns the schedule of the assigned VMs. `hypercore_vm` removes a schedule not set in its " +
+			"`snapshot_schedule_uuid`, so add `snapshot_schedule_uuid` to `lifecycle.ignore_changes` of assigned `hypercore_vm` resources.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Assignment identifier, same as `schedule_uuid`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"schedule_uuid": schema.StringAttribute{
+				MarkdownDescription: "UUID of the snapshot schedule to assign.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"vm_uuids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "UUIDs of VMs to assign the schedule to.",
+				Optional:            true,
+			},
+			"tags_any": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Assign the schedule to VMs with at least one of these tags.",
+				Optional:            true,
+			},
+			"tags_all": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Assign the schedule to VMs with all of these tags.",
+				Optional:            true,
+			},
+			"exclusive": schema.BoolAttribute{
+				MarkdownDescription: "" +
+					"If `true`, the schedule is removed from any other VM using it. " +
+					"If `false`, only VMs previously assigned by this resource are removed. Default: `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"assigned_vm_uuids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "UUIDs of VMs with the schedule assigned by this resource.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *HypercoreVMSnapshotScheduleAssignmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config HypercoreVMSnapshotScheduleAssignmentResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.VMUUIDs.IsNull() && config.TagsAny.IsNull() && config.TagsAll.IsNull() {
+		resp.Diagnostics.AddError(
+			"Missing VM selection",
+			"At least one of 'vm_uuids', 'tags_any' and 'tags_all' must be set.",
+		)
+	}
+}
+
+// ModifyPlan resolves selected VMs, so changes of VM tags on HC3 are planned.
+func (r *HypercoreVMSnapshotScheduleAssignmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var plan HypercoreVMSnapshotScheduleAssignmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.VMUUIDs.IsUnknown() || plan.TagsAny.IsUnknown() || plan.TagsAll.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("assigned_vm_uuids"), types.SetUnknown(types.StringType))...)
+		return
+	}
+
+	selector := plan.selector(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	vms := r.client.ListRecords("/rest/v1/VirDomain", map[string]any{}, -1.0, false)
+	desired, d := utils.SelectScheduleAssignmentVMs(vms, selector)
+	if d != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("vm_uuids"), d.Summary(), d.Detail())
+		return
+	}
+	assigned, diags := types.SetValueFrom(ctx, types.StringType, desired)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("assigned_vm_uuids"), assigned)...)
+}
+
+func (r *HypercoreVMSnapshotScheduleAssignmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "TTRT HypercoreVMSnapshotScheduleAssignmentResource CONFIGURE")
+	// Prevent padisk if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	restClient, ok := req.ProviderData.(*utils.RestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = restClient
+}
+
+// reconcile assigns the schedule to selected VMs and removes it from deselected ones.
+// managed are VMs assigned by this resource before. VMs assigned after reconciliation are saved into data.
+// Returns false if nothing was changed on HC3. Otherwise data needs to be saved, also if some VMs failed;
+// failed VMs keep their previous assignment in data.
+func (r *HypercoreVMSnapshotScheduleAssignmentResource) reconcile(ctx context.Context, data *HypercoreVMSnapshotScheduleAssignmentResourceModel, managed []string, diags *diag.Diagnostics) bool {
+	restClient := *r.client
+	scheduleUUID := data.ScheduleUUID.ValueString()
+	if utils.GetVMSnapshotScheduleByUUID(restClient, scheduleUUID) == nil {
+		diags.AddError("Schedule not found", fmt.Sprintf("Schedule not found - scheduleUUID=%s", scheduleUUID))
+		return false
+	}
+
+	selector := data.selector(ctx, diags)
+	if diags.HasError() {
+		return false
+	}
+	vms := restClient.ListRecords("/rest/v1/VirDomain", map[string]any{}, -1.0, false)
+	desired, d := utils.SelectScheduleAssignmentVMs(vms, selector)
+	if d != nil {
+		diags.AddError(d.Summary(), d.Detail())
+		return false
+	}
+	actual := utils.GetScheduleAssignedVMs(vms, scheduleUUID)
+	toAssign, toRemove := utils.PlanScheduleAssignment(desired, actual, managed, data.Exclusive.ValueBool())
+	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreVMSnapshotScheduleAssignment: schedule_uuid=%s, assign=%v, remove=%v", scheduleUUID, toAssign, toRemove))
+
+	for _, vmUUID := range toAssign {
+		if d := utils.AssignVMSnapshotSchedule(restClient, vmUUID, scheduleUUID, ctx); d != nil {
+			diags.AddError(d.Summary(), fmt.Sprintf("Assigning schedule to VM %s failed: %s", vmUUID, d.Detail()))
+		}
+	}
+	for _, vmUUID := range toRemove {
+		if d := utils.RemoveVMSnapshotSchedule(restClient, vmUUID, ctx); d != nil {
+			diags.AddError(d.Summary(), fmt.Sprintf("Removing schedule from VM %s failed: %s", vmUUID, d.Detail()))
+		}
+	}
+
+	// Do not trust the updates made what we asked for. Read new VM state from HC3.
+	vms = restClient.ListRecords("/rest/v1/VirDomain", map[string]any{}, -1.0, false)
+	// Report only VMs planned in assigned_vm_uuids, or managed ones which failed to be removed.
+	tracked := utils.TrackedScheduleAssignedVMs(
+		utils.GetScheduleAssignedVMs(vms, scheduleUUID),
+		append(slices.Clone(desired), managed...),
+		data.Exclusive.ValueBool(),
+	)
+	assigned, setDiags := types.SetValueFrom(ctx, types.StringType, tracked)
+	diags.Append(setDiags...)
+	data.Id = types.StringValue(scheduleUUID)
+	data.AssignedVMUUIDs = assigned
+	return true
+}
+
+func (r *HypercoreVMSnapshotScheduleAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMSnapshotScheduleAssignmentResource CREATE")
+	var data HypercoreVMSnapshotScheduleAssignmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save state also if some VMs failed, errors are already in diagnostics.
+	if !r.reconcile(ctx, &data, []string{}, &resp.Diagnostics) {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreVMSnapshotScheduleAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMSnapshotScheduleAssignmentResource READ")
+	var data HypercoreVMSnapshotScheduleAssignmentResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restClient := *r.client
+	scheduleUUID := data.ScheduleUUID.ValueString()
+	if utils.GetVMSnapshotScheduleByUUID(restClient, scheduleUUID) == nil {
+		tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreVMSnapshotScheduleAssignment: schedule_uuid=%s not found", scheduleUUID))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var managed []string
+	resp.Diagnostics.Append(data.AssignedVMUUIDs.ElementsAs(ctx, &managed, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without exclusive, VMs assigned outside of Terraform are not tracked.
+	// After import nothing is managed yet, so all assigned VMs are tracked.
+	vms := restClient.ListRecords("/rest/v1/VirDomain", map[string]any{}, -1.0, false)
+	assigned := utils.TrackedScheduleAssignedVMs(
+		utils.GetScheduleAssignedVMs(vms, scheduleUUID),
+		managed,
+		data.Exclusive.ValueBool() || data.AssignedVMUUIDs.IsNull(),
+	)
+	assignedSet, diags := types.SetValueFrom(ctx, types.StringType, assigned)
+	resp.Diagnostics.Append(diags...)
+	data.Id = types.StringValue(scheduleUUID)
+	data.AssignedVMUUIDs = assignedSet
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreVMSnapshotScheduleAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMSnapshotScheduleAssignmentResource UPDATE")
+	var data_state HypercoreVMSnapshotScheduleAssignmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data_state)...)
+	var data HypercoreVMSnapshotScheduleAssignmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var managed []string
+	resp.Diagnostics.Append(data_state.AssignedVMUUIDs.ElementsAs(ctx, &managed, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save state also if some VMs failed, errors are already in diagnostics.
+	if !r.reconcile(ctx, &data, managed, &resp.Diagnostics) {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreVMSnapshotScheduleAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMSnapshotScheduleAssignmentResource DELETE")
+	var data HypercoreVMSnapshotScheduleAssignmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var managed []string
+	resp.Diagnostics.Append(data.AssignedVMUUIDs.ElementsAs(ctx, &managed, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove the schedule only from VMs still using it, VM might have been moved to another schedule meanwhile.
+	restClient := *r.client
+	vms := restClient.ListRecords("/rest/v1/VirDomain", map[string]any{}, -1.0, false)
+	_, toRemove := utils.PlanScheduleAssignment([]string{}, utils.GetScheduleAssignedVMs(vms, data.ScheduleUUID.ValueString()), managed, false)
+	for _, vmUUID := range toRemove {
+		if d := utils.RemoveVMSnapshotSchedule(restClient, vmUUID, ctx); d != nil {
+			resp.Diagnostics.AddError(d.Summary(), fmt.Sprintf("Removing schedule from VM %s failed: %s", vmUUID, d.Detail()))
+		}
+	}
+}
+
+func (r *HypercoreVMSnapshotScheduleAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMSnapshotScheduleAssignmentResource IMPORT_STATE")
+
+	// All VMs using the schedule are imported as assigned, Read loads them.
+	scheduleUUID := req.ID
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), scheduleUUID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("schedule_uuid"), scheduleUUID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("exclusive"), false)...)
+}
@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &hypercoreVMSnapshotSchedulesDataSource{}
+	_ datasource.DataSourceWithConfigure = &hypercoreVMSnapshotSchedulesDataSource{}
+)
+
+// NewHypercoreVMSnapshotSchedulesDataSource is a helper function to simplify the provider implementation.
+func NewHypercoreVMSnapshotSchedulesDataSource() datasource.DataSource {
+	return &hypercoreVMSnapshotSchedulesDataSource{}
+}
+
+// hypercoreVMSnapshotSchedulesDataSource is the data source implementation.
+type hypercoreVMSnapshotSchedulesDataSource struct {
+	client *utils.RestClient
+}
+
+// hypercoreVMSnapshotSchedulesDataSourceModel maps the data source schema data.
+type hypercoreVMSnapshotSchedulesDataSourceModel struct {
+	FilterName types.String                       `tfsdk:"name"`
+	Schedules  []hypercoreVMSnapshotScheduleModel `tfsdk:"schedules"`
+}
+
+// hypercoreVMSnapshotScheduleModel maps VM snapshot schedule schema data.
+type hypercoreVMSnapshotScheduleModel struct {
+	UUID    types.String                           `tfsdk:"uuid"`
+	Name    types.String                           `tfsdk:"name"`
+	Rules   []hypercoreVMSnapshotScheduleRuleModel `tfsdk:"rules"`
+	VMUUIDs []types.String                         `tfsdk:"vm_uuids"`
+}
+
+// hypercoreVMSnapshotScheduleRuleModel maps VM snapshot schedule rule schema data.
+type hypercoreVMSnapshotScheduleRuleModel struct {
+	Name                   types.String `tfsdk:"name"`
+	StartTimestamp         types.String `tfsdk:"start_timestamp"`
+	Frequency              types.String `tfsdk:"frequency"`
+	LocalRetentionSeconds  types.Int64  `tfsdk:"local_retention_seconds"`
+	RemoteRetentionSeconds types.Int64  `tfsdk:"remote_retention_seconds"`
+}
+
+func buildHypercoreVMSnapshotScheduleModel(schedule map[string]any, vms []map[string]any) hypercoreVMSnapshotScheduleModel {
+	scheduleUUID := utils.AnyToString(schedule["uuid"])
+	rules := []hypercoreVMSnapshotScheduleRuleModel{}
+	if schedule["rrules"] != nil {
+		for _, rule := range utils.AnyToListOfMap(schedule["rrules"]) {
+			rules = append(rules, hypercoreVMSnapshotScheduleRuleModel{
+				Name:                   types.StringValue(utils.AnyToString(rule["name"])),
+				StartTimestamp:         types.StringValue(utils.AnyToString(rule["dtstart"])),
+				Frequency:              types.StringValue(utils.AnyToString(rule["rrule"])),
+				LocalRetentionSeconds:  types.Int64Value(utils.AnyToInteger64(rule["localRetentionDurationSeconds"])),
+				RemoteRetentionSeconds: types.Int64Value(utils.AnyToInteger64(rule["remoteRetentionDurationSeconds"])),
+			})
+		}
+	}
+	vmUUIDs := []types.String{}
+	for _, vmUUID := range utils.GetScheduleAssignedVMs(vms, scheduleUUID) {
+		vmUUIDs = append(vmUUIDs, types.StringValue(vmUUID))
+	}
+	return hypercoreVMSnapshotScheduleModel{
+		UUID:    types.StringValue(scheduleUUID),
+		Name:    types.StringValue(utils.AnyToString(schedule["name"])),
+		Rules:   rules,
+		VMUUIDs: vmUUIDs,
+	}
+}
+
+// Metadata returns the data source type name.
+func (d *hypercoreVMSnapshotSchedulesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_snapshot_schedules"
+}
+
+// Schema defines the schema for the data source.
+func (d *hypercoreVMSnapshotSchedulesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "" +
+			"Lists VM snapshot schedules, sorted by name. If `name` is not set, all schedules are returned. <br>" +
+			"For each schedule, VMs using it are listed in `vm_uuids`.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Return only schedules with exactly this name.",
+				Optional:            true,
+			},
+			"schedules": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching schedules.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"uuid": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"rules": schema.ListNestedAttribute{
+							MarkdownDescription: "Schedule rules.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Computed: true,
+									},
+									"start_timestamp": schema.StringAttribute{
+										MarkdownDescription: "Rule start, in cluster local time.",
+										Computed:            true,
+									},
+									"frequency": schema.StringAttribute{
+										MarkdownDescription: "Rule frequency, RFC-2445 RRULE.",
+										Computed:            true,
+									},
+									"local_retention_seconds": schema.Int64Attribute{
+										Computed: true,
+									},
+									"remote_retention_seconds": schema.Int64Attribute{
+										Computed: true,
+									},
+								},
+							},
+						},
+						"vm_uuids": schema.ListAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "UUIDs of VMs using the schedule.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *hypercoreVMSnapshotSchedulesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	restClient, ok := req.ProviderData.(*utils.RestClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = restClient
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *hypercoreVMSnapshotSchedulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	var conf hypercoreVMSnapshotSchedulesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &conf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filterName := conf.FilterName.ValueString()
+	query := map[string]any{}
+	if filterName != "" {
+		query = map[string]any{"name": filterName}
+	}
+	hc3_schedules := d.client.ListRecords(
+		"/rest/v1/VirDomainSnapshotSchedule",
+		query,
+		-1.0,
+		false,
+	)
+	hc3_schedules = utils.FilterVMSnapshotSchedules(hc3_schedules, filterName)
+	hc3_vms := d.client.ListRecords(
+		"/rest/v1/VirDomain",
+		map[string]any{},
+		-1.0,
+		false,
+	)
+	tflog.Debug(ctx, fmt.Sprintf("TTRT: name=%s schedule_count=%d\n", filterName, len(hc3_schedules)))
+
+	state := conf
+	state.Schedules = []hypercoreVMSnapshotScheduleModel{}
+	for _, schedule := range hc3_schedules {
+		state.Schedules = append(state.Schedules, buildHypercoreVMSnapshotScheduleModel(schedule, hc3_vms))
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &hypercoreVMSnapshotsDataSource{}
+	_ datasource.DataSourceWithConfigure = &hypercoreVMSnapshotsDataSource{}
+)
+
+// NewHypercoreVMSnapshotsDataSource is a helper function to simplify the provider implementation.
+func NewHypercoreVMSnapshotsDataSource() datasource.DataSource {
+	return &hypercoreVMSnapshotsDataSource{}
+}
+
+// hypercoreVMSnapshotsDataSource is the data source implementation.
+type hypercoreVMSnapshotsDataSource struct {
+	client *utils.RestClient
+}
+
+// hypercoreVMSnapshotsDataSourceModel maps the data source schema data.
+type hypercoreVMSnapshotsDataSourceModel struct {
+	FilterVMUUID        types.String               `tfsdk:"vm_uuid"`
+	FilterLabel         types.String               `tfsdk:"label"`
+	FilterLabelRegex    types.String               `tfsdk:"label_regex"`
+	FilterType          types.String               `tfsdk:"type"`
+	FilterCreatedAfter  types.String               `tfsdk:"created_after"`
+	FilterCreatedBefore types.String               `tfsdk:"created_before"`
+	Snapshots           []hypercoreVMSnapshotModel `tfsdk:"snapshots"`
+	Latest              *hypercoreVMSnapshotModel  `tfsdk:"latest"`
+}
+
+// hypercoreVMSnapshotModel maps VM snapshot schema data.
+type hypercoreVMSnapshotModel struct {
+	UUID                       types.String `tfsdk:"uuid"`
+	VMUUID                     types.String `tfsdk:"vm_uuid"`
+	Label                      types.String `tfsdk:"label"`
+	Type                       types.String `tfsdk:"type"`
+	Created                    types.String `tfsdk:"created"`
+	CreatedTimestamp           types.Int64  `tfsdk:"created_timestamp"`
+	LocalRetainUntilTimestamp  types.Int64  `tfsdk:"local_retain_until_timestamp"`
+	RemoteRetainUntilTimestamp types.Int64  `tfsdk:"remote_retain_until_timestamp"`
+}
+
+func hypercoreVMSnapshotAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"uuid": schema.StringAttribute{
+			Computed: true,
+		},
+		"vm_uuid": schema.StringAttribute{
+			Computed: true,
+		},
+		"label": schema.StringAttribute{
+			Computed: true,
+		},
+		"type": schema.StringAttribute{
+			Computed: true,
+		},
+		"created": schema.StringAttribute{
+			MarkdownDescription: "Snapshot creation time, RFC3339 timestamp in UTC.",
+			Computed:            true,
+		},
+		"created_timestamp": schema.Int64Attribute{
+			MarkdownDescription: "Snapshot creation time, Unix timestamp.",
+			Computed:            true,
+		},
+		"local_retain_until_timestamp": schema.Int64Attribute{
+			MarkdownDescription: "When HC3 removes the snapshot, Unix timestamp. `0` means retained forever.",
+			Computed:            true,
+		},
+		"remote_retain_until_timestamp": schema.Int64Attribute{
+			MarkdownDescription: "When HC3 removes the replicated snapshot, Unix timestamp. `0` means retained forever.",
+			Computed:            true,
+		},
+	}
+}
+
+func buildHypercoreVMSnapshotModel(snapshot map[string]any) hypercoreVMSnapshotModel {
+	created := utils.GetVMSnapshotCreated(snapshot)
+	return hypercoreVMSnapshotModel{
+		UUID:                       types.StringValue(utils.AnyToString(snapshot["uuid"])),
+		VMUUID:                     types.StringValue(utils.AnyToString(snapshot["domainUUID"])),
+		Label:                      types.StringValue(utils.AnyToString(snapshot["label"])),
+		Type:                       types.StringValue(utils.AnyToString(snapshot["type"])),
+		Created:                    types.StringValue(created.UTC().Format(time.RFC3339)),
+		CreatedTimestamp:           types.Int64Value(created.Unix()),
+		LocalRetainUntilTimestamp:  types.Int64Value(utils.AnyToInteger64(snapshot["localRetainUntilTimestamp"])),
+		RemoteRetainUntilTimestamp: types.Int64Value(utils.AnyToInteger64(snapshot["remoteRetainUntilTimestamp"])),
+	}
+}
+
+// Metadata returns the data source type name.
+func (d *hypercoreVMSnapshotsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_snapshots"
+}
+
+// Schema defines the schema for the data source.
+func (d *hypercoreVMSnapshotsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "" +
+			"Lists VM snapshots matching all of the given filters. Filters that are not set are ignored. <br>" +
+			"Snapshots are sorted by creation time, oldest first. The most recent one is also available as `latest`.",
+		Attributes: map[string]schema.Attribute{
+			"vm_uuid": schema.StringAttribute{
+				MarkdownDescription: "Return only snapshots of this VM.",
+				Optional:            true,
+			},
+			"label": schema.StringAttribute{
+				MarkdownDescription: "Return only snapshots with exactly this label.",
+				Optional:            true,
+			},
+			"label_regex": schema.StringAttribute{
+				MarkdownDescription: "Return only snapshots with label matching this regular expression (Go `regexp` syntax).",
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Return only snapshots of this type. Can be: `USER`, `AUTOMATED`, `SUPPORT`.",
+				Optional:            true,
+				Validators: []validator.String{
+					vmSnapshotTypeValidator(),
+				},
+			},
+			"created_after": schema.StringAttribute{
+				MarkdownDescription: "Return only snapshots created after this RFC3339 timestamp.",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339Validator(),
+				},
+			},
+			"created_before": schema.StringAttribute{
+				MarkdownDescription: "Return only snapshots created before this RFC3339 timestamp.",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339Validator(),
+				},
+			},
+			"snapshots": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching snapshots, oldest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: hypercoreVMSnapshotAttributes(),
+				},
+			},
+			"latest": schema.SingleNestedAttribute{
+				MarkdownDescription: "The most recent matching snapshot. Null if no snapshot matches.",
+				Computed:            true,
+				Attributes:          hypercoreVMSnapshotAttributes(),
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *hypercoreVMSnapshotsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	restClient, ok := req.ProviderData.(*utils.RestClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = restClient
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *hypercoreVMSnapshotsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	var conf hypercoreVMSnapshotsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &conf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := utils.VMSnapshotFilter{
+		VMUUID:        conf.FilterVMUUID.ValueString(),
+		Label:         conf.FilterLabel.ValueString(),
+		LabelRegex:    conf.FilterLabelRegex.ValueString(),
+		Type:          conf.FilterType.ValueString(),
+		CreatedAfter:  conf.FilterCreatedAfter.ValueString(),
+		CreatedBefore: conf.FilterCreatedBefore.ValueString(),
+	}
+	diagFilter := utils.ValidateVMSnapshotFilter(&filter)
+	if diagFilter != nil {
+		resp.Diagnostics.AddError(diagFilter.Summary(), diagFilter.Detail())
+		return
+	}
+
+	query := map[string]any{}
+	if filter.VMUUID != "" {
+		query = map[string]any{"domainUUID": filter.VMUUID}
+	}
+	hc3_snapshots := d.client.ListRecords(
+		"/rest/v1/VirDomainSnapshot",
+		query,
+		-1.0,
+		false,
+	)
+	hc3_snapshots = utils.FilterVMSnapshots(hc3_snapshots, filter)
+	tflog.Debug(ctx, fmt.Sprintf("TTRT: filter=%v snapshot_count=%d\n", filter, len(hc3_snapshots)))
+
+	state := conf
+	state.Snapshots = []hypercoreVMSnapshotModel{}
+	for _, snapshot := range hc3_snapshots {
+		state.Snapshots = append(state.Snapshots, buildHypercoreVMSnapshotModel(snapshot))
+	}
+	state.Latest = nil
+	if len(state.Snapshots) > 0 {
+		latest := state.Snapshots[len(state.Snapshots)-1]
+		state.Latest = &latest
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
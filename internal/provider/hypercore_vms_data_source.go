@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -36,10 +37,17 @@ type hypercoreVMsDataSource struct {
 	client *utils.RestClient
 }
 
-// coffeesDataSourceModel maps the data source schema data.
+// hypercoreVMsDataSourceModel maps the data source schema data.
 type hypercoreVMsDataSourceModel struct {
-	FilterName types.String       `tfsdk:"name"`
-	Vms        []HypercoreVMModel `tfsdk:"vms"`
+	FilterName                types.String       `tfsdk:"name"`
+	FilterNamePrefix          types.String       `tfsdk:"name_prefix"`
+	FilterNameRegex           types.String       `tfsdk:"name_regex"`
+	FilterDescriptionContains types.String       `tfsdk:"description_contains"`
+	FilterTagsAny             types.List         `tfsdk:"tags_any"`
+	FilterTagsAll             types.List         `tfsdk:"tags_all"`
+	FilterPowerState          types.String       `tfsdk:"power_state"`
+	FilterNodeUUID            types.String       `tfsdk:"node_uuid"`
+	Vms                       []HypercoreVMModel `tfsdk:"vms"`
 }
 
 // HypercoreVMModel maps VM schema data.
@@ -48,20 +56,41 @@ type HypercoreVMModel struct {
 	Name                 types.String          `tfsdk:"name"`
 	Description          types.String          `tfsdk:"description"`
 	PowerState           types.String          `tfsdk:"power_state"`
+	DesiredDisposition   types.String          `tfsdk:"desired_disposition"`
 	VCPU                 types.Int32           `tfsdk:"vcpu"`
 	Memory               types.Int64           `tfsdk:"memory"`
+	MachineType          types.String          `tfsdk:"machine_type"`
+	OSType               types.String          `tfsdk:"os_type"`
+	NodeUUID             types.String          `tfsdk:"node_uuid"`
+	GuestAgentState      types.String          `tfsdk:"guest_agent_state"`
+	BootDevices          []types.String        `tfsdk:"boot_devices"`
 	SnapshotScheduleUUID types.String          `tfsdk:"snapshot_schedule_uuid"`
+	SnapshotUUIDs        []types.String        `tfsdk:"snapshot_uuids"`
+	ReplicationUUIDs     []types.String        `tfsdk:"replication_uuids"`
+	SourceVMUUID         types.String          `tfsdk:"source_vm_uuid"`
 	Tags                 []types.String        `tfsdk:"tags"`
+	Console              HypercoreConsoleModel `tfsdk:"console"`
 	Disks                []HypercoreDiskModel  `tfsdk:"disks"`
 	Nics                 []HypercoreNicModel   `tfsdk:"nics"`
 	AffinityStrategy     AffinityStrategyModel `tfsdk:"affinity_strategy"`
 }
 
+type HypercoreConsoleModel struct {
+	Type   types.String `tfsdk:"type"`
+	IP     types.String `tfsdk:"ip"`
+	Port   types.Int64  `tfsdk:"port"`
+	Keymap types.String `tfsdk:"keymap"`
+}
+
 type HypercoreDiskModel struct {
-	UUID types.String  `tfsdk:"uuid"`
-	Type types.String  `tfsdk:"type"`
-	Slot types.Int64   `tfsdk:"slot"`
-	Size types.Float64 `tfsdk:"size"`
+	UUID          types.String  `tfsdk:"uuid"`
+	Type          types.String  `tfsdk:"type"`
+	Slot          types.Int64   `tfsdk:"slot"`
+	Size          types.Float64 `tfsdk:"size"`
+	SizeBytes     types.Int64   `tfsdk:"size_bytes"`
+	SizeGiB       types.Int64   `tfsdk:"size_gib"`
+	FlashPriority types.Int64   `tfsdk:"flash_priority"`
+	IsoPath       types.String  `tfsdk:"iso_path"`
 }
 
 type HypercoreNicModel struct {
@@ -69,6 +98,7 @@ type HypercoreNicModel struct {
 	Vlan         types.Int64    `tfsdk:"vlan"`
 	Type         types.String   `tfsdk:"type"`
 	MacAddress   types.String   `tfsdk:"mac_address"`
+	Connected    types.Bool     `tfsdk:"connected"`
 	Ipv4Adresses []types.String `tfsdk:"ipv4_addresses"`
 }
 
@@ -80,105 +110,214 @@ func (d *hypercoreVMsDataSource) Metadata(_ context.Context, req datasource.Meta
 // Schema defines the schema for the data source.
 func (d *hypercoreVMsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "" +
+			"Lists VMs matching all of the given filters. Filters that are not set are ignored. <br>" +
+			"Zero, one or more VMs can be returned.",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
+				MarkdownDescription: "Return only VMs with exactly this name.",
+				Optional:            true,
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Return only VMs with name starting with this prefix.",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Return only VMs with name matching this regular expression (Go `regexp` syntax).",
+				Optional:            true,
+			},
+			"description_contains": schema.StringAttribute{
+				MarkdownDescription: "Return only VMs with description containing this substring.",
+				Optional:            true,
+			},
+			"tags_any": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Return only VMs with at least one of these tags.",
+				Optional:            true,
+			},
+			"tags_all": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Return only VMs with all of these tags.",
+				Optional:            true,
+			},
+			"power_state": schema.StringAttribute{
+				MarkdownDescription: "" +
+					"Return only VMs in this power state. " +
+					"Can be: `RUNNING`, `BLOCKED`, `PAUSED`, `SHUTDOWN`, `SHUTOFF`, `CRASHED`.",
 				Optional: true,
+				Validators: []validator.String{
+					observedPowerStateValidator(),
+				},
+			},
+			"node_uuid": schema.StringAttribute{
+				MarkdownDescription: "Return only VMs currently running on the node with this UUID.",
+				Optional:            true,
 			},
 			"vms": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"uuid": schema.StringAttribute{
-							Computed: true,
-						},
-						"name": schema.StringAttribute{
-							Computed: true,
-						},
-						"vcpu": schema.Int32Attribute{
-							MarkdownDescription: "Number of CPUs",
-							Optional:            true,
-						},
-						"memory": schema.Int64Attribute{
-							MarkdownDescription: "Memory (RAM) size in MiB",
-							Optional:            true,
-						},
-						"snapshot_schedule_uuid": schema.StringAttribute{
-							MarkdownDescription: "UUID of the applied snapshot schedule for creating automated snapshots",
-							Computed:            true,
-						},
-						"description": schema.StringAttribute{
-							Computed: true,
-						},
-						"power_state": schema.StringAttribute{
-							Computed: true,
-						},
-						"tags": schema.ListAttribute{
-							ElementType: types.StringType,
-							Optional:    true,
-						},
-						"affinity_strategy": schema.ObjectAttribute{
-							MarkdownDescription: "VM node affinity.",
-							Computed:            true,
-							AttributeTypes: map[string]attr.Type{
-								"strict_affinity":     types.BoolType,
-								"preferred_node_uuid": types.StringType,
-								"backup_node_uuid":    types.StringType,
-							},
-						},
+					Attributes: hypercoreVMDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// hypercoreVMDataSourceAttributes returns VM attributes shared by hypercore_vms and hypercore_vm data sources.
+func hypercoreVMDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"uuid": schema.StringAttribute{
+			Computed: true,
+		},
+		"name": schema.StringAttribute{
+			Computed: true,
+		},
+		"vcpu": schema.Int32Attribute{
+			MarkdownDescription: "Number of CPUs",
+			Optional:            true,
+		},
+		"memory": schema.Int64Attribute{
+			MarkdownDescription: "Memory (RAM) size in MiB",
+			Optional:            true,
+		},
+		"snapshot_schedule_uuid": schema.StringAttribute{
+			MarkdownDescription: "UUID of the applied snapshot schedule for creating automated snapshots",
+			Computed:            true,
+		},
+		"description": schema.StringAttribute{
+			Computed: true,
+		},
+		"power_state": schema.StringAttribute{
+			MarkdownDescription: "Current power state. One of `RUNNING`, `BLOCKED`, `PAUSED`, `SHUTDOWN`, `SHUTOFF`, `CRASHED`.",
+			Computed:            true,
+		},
+		"desired_disposition": schema.StringAttribute{
+			MarkdownDescription: "Power state the VM is transitioning to, as requested by the last power action.",
+			Computed:            true,
+		},
+		"machine_type": schema.StringAttribute{
+			MarkdownDescription: "Hypervisor machine type, for example `scale-7.2` or `scale-uefi-tpm-9.3`.",
+			Computed:            true,
+		},
+		"os_type": schema.StringAttribute{
+			MarkdownDescription: "Guest operating system type, for example `os_other` or `os_windows_server_2012`.",
+			Computed:            true,
+		},
+		"node_uuid": schema.StringAttribute{
+			MarkdownDescription: "UUID of the node the VM is currently running on. Empty if the VM is not running.",
+			Computed:            true,
+		},
+		"guest_agent_state": schema.StringAttribute{
+			MarkdownDescription: "Guest agent (qemu-guest-agent) state as reported by the hypervisor.",
+			Computed:            true,
+		},
+		"boot_devices": schema.ListAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "List of UUIDs of disks and NICs, in the order that they will boot",
+			Computed:            true,
+		},
+		"snapshot_uuids": schema.ListAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "UUIDs of VM snapshots",
+			Computed:            true,
+		},
+		"replication_uuids": schema.ListAttribute{
+			ElementType:         types.StringType,
+			MarkdownDescription: "UUIDs of VM replications",
+			Computed:            true,
+		},
+		"source_vm_uuid": schema.StringAttribute{
+			MarkdownDescription: "UUID of the source VM, if this VM is a replica or a clone. Empty otherwise.",
+			Computed:            true,
+		},
+		"tags": schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+		"console": schema.ObjectAttribute{
+			MarkdownDescription: "VM console access details.",
+			Computed:            true,
+			AttributeTypes:      getVMConsoleAttributeTypes(),
+		},
+		"affinity_strategy": schema.ObjectAttribute{
+			MarkdownDescription: "VM node affinity.",
+			Computed:            true,
+			AttributeTypes: map[string]attr.Type{
+				"strict_affinity":     types.BoolType,
+				"preferred_node_uuid": types.StringType,
+				"backup_node_uuid":    types.StringType,
+			},
+		},
 
-						"disks": schema.ListNestedAttribute{
-							MarkdownDescription: "List of disks",
-							Computed:            true,
-							NestedObject: schema.NestedAttributeObject{
-								Attributes: map[string]schema.Attribute{
-									"uuid": schema.StringAttribute{
-										MarkdownDescription: "UUID",
-										Computed:            true,
-									},
-									"type": schema.StringAttribute{
-										MarkdownDescription: "type",
-										Computed:            true,
-									},
-									"slot": schema.Int64Attribute{
-										MarkdownDescription: "slot",
-										Computed:            true,
-									},
-									"size": schema.Float64Attribute{
-										MarkdownDescription: "size",
-										Computed:            true,
-									},
-								},
-							},
-						},
-						"nics": schema.ListNestedAttribute{
-							MarkdownDescription: "List of NICs",
-							Computed:            true,
-							NestedObject: schema.NestedAttributeObject{
-								Attributes: map[string]schema.Attribute{
-									"uuid": schema.StringAttribute{
-										MarkdownDescription: "UUID",
-										Computed:            true,
-									},
-									"type": schema.StringAttribute{
-										MarkdownDescription: "type",
-										Computed:            true,
-									},
-									"vlan": schema.Int64Attribute{
-										MarkdownDescription: "vlan",
-										Computed:            true,
-									},
-									"mac_address": schema.StringAttribute{
-										MarkdownDescription: "MAC address",
-										Computed:            true,
-									},
-									"ipv4_addresses": schema.ListAttribute{
-										ElementType:         types.StringType,
-										MarkdownDescription: "IPv4 addresses",
-										Computed:            true,
-									},
-								},
-							},
-						},
+		"disks": schema.ListNestedAttribute{
+			MarkdownDescription: "List of disks",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"uuid": schema.StringAttribute{
+						MarkdownDescription: "UUID",
+						Computed:            true,
+					},
+					"type": schema.StringAttribute{
+						MarkdownDescription: "type",
+						Computed:            true,
+					},
+					"slot": schema.Int64Attribute{
+						MarkdownDescription: "slot",
+						Computed:            true,
+					},
+					"size": schema.Float64Attribute{
+						MarkdownDescription: "Disk size in `GB` (1 GB = 1000^3 bytes)",
+						Computed:            true,
+					},
+					"size_bytes": schema.Int64Attribute{
+						MarkdownDescription: "Disk size in bytes",
+						Computed:            true,
+					},
+					"size_gib": schema.Int64Attribute{
+						MarkdownDescription: "Disk size in `GiB` (1 GiB = 1024^3 bytes). `null` if the size is not a whole number of GiB.",
+						Computed:            true,
+					},
+					"flash_priority": schema.Int64Attribute{
+						MarkdownDescription: "SSD tiering priority factor, between (including) `0` and `11`",
+						Computed:            true,
+					},
+					"iso_path": schema.StringAttribute{
+						MarkdownDescription: "Path of the ISO inserted into an `IDE_CDROM` disk. Empty for other disk types or if no ISO is inserted.",
+						Computed:            true,
+					},
+				},
+			},
+		},
+		"nics": schema.ListNestedAttribute{
+			MarkdownDescription: "List of NICs",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"uuid": schema.StringAttribute{
+						MarkdownDescription: "UUID",
+						Computed:            true,
+					},
+					"type": schema.StringAttribute{
+						MarkdownDescription: "type",
+						Computed:            true,
+					},
+					"vlan": schema.Int64Attribute{
+						MarkdownDescription: "vlan",
+						Computed:            true,
+					},
+					"mac_address": schema.StringAttribute{
+						MarkdownDescription: "MAC address",
+						Computed:            true,
+					},
+					"connected": schema.BoolAttribute{
+						MarkdownDescription: "NIC link state",
+						Computed:            true,
+					},
+					"ipv4_addresses": schema.ListAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "IPv4 addresses",
+						Computed:            true,
 					},
 				},
 			},
@@ -212,12 +351,33 @@ func (d *hypercoreVMsDataSource) Read(ctx context.Context, req datasource.ReadRe
 	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
 
 	var conf hypercoreVMsDataSourceModel
-	req.Config.Get(ctx, &conf)
-	filter_name := conf.FilterName.ValueString()
+	resp.Diagnostics.Append(req.Config.Get(ctx, &conf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := utils.VMFilter{
+		Name:                conf.FilterName.ValueString(),
+		NamePrefix:          conf.FilterNamePrefix.ValueString(),
+		NameRegex:           conf.FilterNameRegex.ValueString(),
+		DescriptionContains: conf.FilterDescriptionContains.ValueString(),
+		PowerState:          conf.FilterPowerState.ValueString(),
+		NodeUUID:            conf.FilterNodeUUID.ValueString(),
+	}
+	resp.Diagnostics.Append(conf.FilterTagsAny.ElementsAs(ctx, &filter.TagsAny, false)...)
+	resp.Diagnostics.Append(conf.FilterTagsAll.ElementsAs(ctx, &filter.TagsAll, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diagFilter := utils.ValidateVMFilter(&filter)
+	if diagFilter != nil {
+		resp.Diagnostics.AddError(diagFilter.Summary(), diagFilter.Detail())
+		return
+	}
 
 	query := map[string]any{}
-	if filter_name != "" {
-		query = map[string]any{"name": filter_name}
+	if filter.Name != "" {
+		query = map[string]any{"name": filter.Name}
 	}
 	hc3_vms := d.client.ListRecords(
 		"/rest/v1/VirDomain",
@@ -225,25 +385,15 @@ func (d *hypercoreVMsDataSource) Read(ctx context.Context, req datasource.ReadRe
 		-1.0,
 		false,
 	)
-	tflog.Debug(ctx, fmt.Sprintf("TTRT: filter_name=%s vm_count=%d\n", filter_name, len(hc3_vms)))
-	if filter_name != "" {
-		if len(hc3_vms) == 0 {
-			resp.Diagnostics.AddError("VM not found", fmt.Sprintf("No VM with name %s found.", filter_name))
-			return
-		}
-		if len(hc3_vms) > 1 {
-			resp.Diagnostics.AddError("Multiple VMs found", fmt.Sprintf("Multiple VMs with name %s found.", filter_name))
-			return
-		}
-	}
+	hc3_vms = utils.FilterVMs(hc3_vms, filter)
+	tflog.Debug(ctx, fmt.Sprintf("TTRT: filter=%v vm_count=%d\n", filter, len(hc3_vms)))
 
-	var state hypercoreVMsDataSourceModel
-	state.FilterName = types.StringValue(filter_name)
+	state := conf
+	state.Vms = []HypercoreVMModel{}
 	for _, vm := range hc3_vms {
 		hypercoreVMModel := BuildHypercoreVMModelFromAPIData(vm, d.client, ctx)
 		state.Vms = append(state.Vms, hypercoreVMModel)
 	}
-	tflog.Debug(ctx, fmt.Sprintf("TTRT: filter_name=%s name=%s\n", filter_name, state.Vms[0].Name.String()))
 
 	// Set state
 	diags := resp.State.Set(ctx, &state)
@@ -275,13 +425,26 @@ func BuildHypercoreVMModelFromAPIData(vm map[string]any, restClient *utils.RestC
 		uuid := utils.AnyToString(blockDev2["uuid"])
 		disk_type := utils.AnyToString(blockDev2["type"])
 		slot := utils.AnyToInteger64(blockDev2["slot"])
-		size_B := float64(utils.AnyToInteger64(blockDev2["capacity"]))
-		size_GB := types.Float64Value(size_B / 1000 / 1000 / 1000)
+		size_B := utils.AnyToInteger64(blockDev2["capacity"])
+		size_GB := types.Float64Value(utils.DiskSizeBytesToGB(size_B))
+		size_GiB := types.Int64Null()
+		if gib, ok := utils.DiskSizeBytesToGiB(size_B); ok {
+			size_GiB = types.Int64Value(gib)
+		}
+		flash_priority := utils.TO_HUMAN_PRIORITY_FACTOR[utils.AnyToInteger64(blockDev2["tieringPriorityFactor"])]
+		iso_path := ""
+		if disk_type == "IDE_CDROM" {
+			iso_path = utils.AnyToStringOrEmpty(blockDev2["path"])
+		}
 		disk := HypercoreDiskModel{
-			UUID: types.StringValue(uuid),
-			Type: types.StringValue(disk_type),
-			Slot: types.Int64Value(slot),
-			Size: size_GB,
+			UUID:          types.StringValue(uuid),
+			Type:          types.StringValue(disk_type),
+			Slot:          types.Int64Value(slot),
+			Size:          size_GB,
+			SizeBytes:     types.Int64Value(size_B),
+			SizeGiB:       size_GiB,
+			FlashPriority: types.Int64Value(flash_priority),
+			IsoPath:       types.StringValue(iso_path),
 		}
 		disks = append(disks, disk)
 	}
@@ -305,11 +468,13 @@ func BuildHypercoreVMModelFromAPIData(vm map[string]any, restClient *utils.RestC
 		for _, addr := range ipv4_addresses {
 			ipv4_addresses_string_value = append(ipv4_addresses_string_value, types.StringValue(addr))
 		}
+		connected := utils.GetNicConnected(nicDev2)
 		nic := HypercoreNicModel{
 			UUID:         types.StringValue(uuid),
 			Type:         types.StringValue(nic_type),
 			Vlan:         types.Int64Value(vlan),
 			MacAddress:   types.StringValue(mac),
+			Connected:    types.BoolValue(connected),
 			Ipv4Adresses: ipv4_addresses_string_value,
 		}
 		nics = append(nics, nic)
@@ -320,6 +485,17 @@ func BuildHypercoreVMModelFromAPIData(vm map[string]any, restClient *utils.RestC
 	affinityStrategy.PreferredNodeUUID = types.StringValue(utils.AnyToString(hc3affinityStrategy["preferredNodeUUID"]))
 	affinityStrategy.BackupNodeUUID = types.StringValue(utils.AnyToString(hc3affinityStrategy["backupNodeUUID"]))
 
+	// console
+	var console HypercoreConsoleModel
+	hc3Console, _ := vm["console"].(map[string]any)
+	console.Type = types.StringValue(utils.AnyToStringOrEmpty(hc3Console["type"]))
+	console.IP = types.StringValue(utils.AnyToStringOrEmpty(hc3Console["ip"]))
+	console.Keymap = types.StringValue(utils.AnyToStringOrEmpty(hc3Console["keymap"]))
+	console.Port = types.Int64Value(0)
+	if hc3Console["port"] != nil {
+		console.Port = types.Int64Value(utils.AnyToInteger64(hc3Console["port"]))
+	}
+
 	// VM
 	memory_B := utils.AnyToInteger64(vm["mem"])
 	memory_MiB := memory_B / 1024 / 1024
@@ -328,10 +504,20 @@ func BuildHypercoreVMModelFromAPIData(vm map[string]any, restClient *utils.RestC
 		Name:                 types.StringValue(utils.AnyToString(vm["name"])),
 		VCPU:                 types.Int32Value(int32(utils.AnyToInteger64(vm["numVCPU"]))),
 		Memory:               types.Int64Value(memory_MiB),
+		MachineType:          types.StringValue(utils.AnyToStringOrEmpty(vm["machineType"])),
+		OSType:               types.StringValue(utils.AnyToStringOrEmpty(vm["operatingSystem"])),
+		NodeUUID:             types.StringValue(utils.AnyToStringOrEmpty(vm["nodeUUID"])),
+		GuestAgentState:      types.StringValue(utils.AnyToStringOrEmpty(vm["guestAgentState"])),
+		BootDevices:          stringsToStringValues(utils.AnyToListOfStringsOrEmpty(vm["bootDevices"])),
 		SnapshotScheduleUUID: types.StringValue(utils.AnyToString(vm["snapshotScheduleUUID"])),
+		SnapshotUUIDs:        stringsToStringValues(utils.AnyToListOfStringsOrEmpty(vm["snapUUIDs"])),
+		ReplicationUUIDs:     stringsToStringValues(utils.AnyToListOfStringsOrEmpty(vm["replicationUUIDs"])),
+		SourceVMUUID:         types.StringValue(utils.AnyToStringOrEmpty(vm["sourceVirDomainUUID"])),
 		Description:          types.StringValue(utils.AnyToString(vm["description"])),
-		PowerState:           types.StringValue(utils.AnyToString(vm["state"])), // TODO convert (stopped vs SHUTOFF)
+		PowerState:           types.StringValue(utils.PowerStateFromHypercore(vm["state"])),
+		DesiredDisposition:   types.StringValue(utils.PowerStateFromHypercore(vm["desiredDisposition"])),
 		Tags:                 tags_String,
+		Console:              console,
 		AffinityStrategy:     affinityStrategy,
 		Disks:                disks,
 		Nics:                 nics,
@@ -339,3 +525,11 @@ func BuildHypercoreVMModelFromAPIData(vm map[string]any, restClient *utils.RestC
 
 	return hypercoreVMState
 }
+
+func stringsToStringValues(values []string) []types.String {
+	stringValues := make([]types.String, 0, len(values))
+	for _, value := range values {
+		stringValues = append(stringValues, types.StringValue(value))
+	}
+	return stringValues
+}
@@ -0,0 +1,447 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &HypercoreVMsPowerStateResource{}
+var _ resource.ResourceWithModifyPlan = &HypercoreVMsPowerStateResource{}
+var _ resource.ResourceWithValidateConfig = &HypercoreVMsPowerStateResource{}
+
+func NewHypercoreVMsPowerStateResource() resource.Resource {
+	return &HypercoreVMsPowerStateResource{}
+}
+
+// HypercoreVMsPowerStateResource defines the resource implementation.
+type HypercoreVMsPowerStateResource struct {
+	client *utils.RestClient
+}
+
+// HypercoreVMsPowerStateResourceModel describes the resource data model.
+type HypercoreVMsPowerStateResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	VmUUIDs         types.Set    `tfsdk:"vm_uuids"`
+	Tags            types.List   `tfsdk:"tags"`
+	State           types.String `tfsdk:"state"`
+	ForceSutoff     types.Bool   `tfsdk:"force_shutoff"`
+	Ordering        types.List   `tfsdk:"ordering"`
+	BatchSize       types.Int64  `tfsdk:"batch_size"`
+	StaggerDelay    types.Int64  `tfsdk:"stagger_delay"`
+	SelectedVmUUIDs types.List   `tfsdk:"selected_vm_uuids"`
+	VmStates        types.Map    `tfsdk:"vm_states"`
+}
+
+func (r *HypercoreVMsPowerStateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vms_power_state"
+}
+
+func (r *HypercoreVMsPowerStateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "" +
+			"Hypercore VMs power state resource to manage power state of many VMs at once.<br>" +
+			"VMs are selected by `vm_uuids` or by `tags`. Exactly one of them must be set. " +
+			"Power actions for VMs in the same batch are sent to HC3 as one request, and are awaited together.<br>" +
+			"Removing the resource does not change power state of the VMs.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "VMs power state identifier",
+			},
+			"vm_uuids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "UUIDs of VMs of which we want to set the power state.",
+				Optional:            true,
+			},
+			"tags": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Select all VMs which have all of these tags.",
+				Optional:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "Desired power state of the VMs. Can be: `SHUTOFF`, `RUNNING`, `PAUSED`",
+				Required:            true,
+				Validators: []validator.String{
+					powerStateValidator(),
+				},
+			},
+			"force_shutoff": schema.BoolAttribute{
+				MarkdownDescription: "" +
+					"Set to `true` if you want to put the VMs into the `SHUTOFF` state by force. " +
+					"This option will only be taken into account when `state` is set to `SHUTOFF`. Default is `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"ordering": schema.ListAttribute{
+				ElementType: types.ListType{ElemType: types.StringType},
+				MarkdownDescription: "" +
+					"Tiers of VM UUIDs, processed in the given order. " +
+					"A tier is processed only after all VMs of the previous tier reached the desired `state`. " +
+					"Remaining selected VMs form the last tier. VMs within a tier are ordered by name.<br>" +
+					"Use it with `batch_size` and `stagger_delay` for ordered start-up or shutdown of multi-tier applications.",
+				Optional: true,
+			},
+			"batch_size": schema.Int64Attribute{
+				MarkdownDescription: "Number of VMs of a tier to process at once. If not set, the whole tier is processed in one batch.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64AtLeastValidator{min: 1},
+				},
+			},
+			"stagger_delay": schema.Int64Attribute{
+				MarkdownDescription: "Seconds to wait between batches, and between tiers. Default is `0`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64AtLeastValidator{min: 0},
+				},
+			},
+			"selected_vm_uuids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "UUIDs of the selected VMs, in processing order.",
+				Computed:            true,
+			},
+			"vm_states": schema.MapAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "" +
+					"Observed power state of each selected VM, by VM UUID. " +
+					"If any VM is not in the desired `state`, Terraform plans to fix it.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *HypercoreVMsPowerStateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config HypercoreVMsPowerStateResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.VmUUIDs.IsNull() == config.Tags.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid VM selection",
+			"Exactly one of 'vm_uuids' or 'tags' must be set.",
+		)
+	}
+}
+
+// ModifyPlan plans the ID, so it follows changes of vm_uuids and tags.
+// If any VM drifted from the desired state, vm_states is planned unknown, so the drift is fixed on apply.
+func (r *HypercoreVMsPowerStateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var data HypercoreVMsPowerStateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !req.State.Raw.IsNull() && !data.State.IsUnknown() {
+		var vmStates map[string]string
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("vm_states"), &vmStates)...)
+		for vmUUID, vmState := range vmStates {
+			if vmState != data.State.ValueString() {
+				tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreVMsPowerStateResource: vm_uuid=%s, state=%s", vmUUID, vmState))
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("vm_states"), types.MapUnknown(types.StringType))...)
+				break
+			}
+		}
+	}
+	isUnknown := func(value attr.Value) bool { return value.IsUnknown() }
+	if data.VmUUIDs.IsUnknown() || data.Tags.IsUnknown() ||
+		slices.ContainsFunc(data.VmUUIDs.Elements(), isUnknown) || slices.ContainsFunc(data.Tags.Elements(), isUnknown) {
+		// ID is known after apply
+		return
+	}
+
+	id, diags := vmsPowerStateID(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func (r *HypercoreVMsPowerStateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	tflog.Info(ctx, "TTRT HypercoreVMsPowerStateResource CONFIGURE")
+	// Prevent padisk if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	restClient, ok := req.ProviderData.(*utils.RestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = restClient
+}
+
+// vmsPowerStateID returns the resource ID.
+// There is no HC3 object behind this resource, so ID is derived from the VM selection.
+func vmsPowerStateID(ctx context.Context, data HypercoreVMsPowerStateResourceModel) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var selection []string
+	diags.Append(data.VmUUIDs.ElementsAs(ctx, &selection, false)...)
+	slices.Sort(selection)
+	if !data.Tags.IsNull() {
+		diags.Append(data.Tags.ElementsAs(ctx, &selection, false)...)
+		selection = slices.Insert(selection, 0, "tags")
+	}
+	return types.StringValue(strings.Join(selection, ":")), diags
+}
+
+// selectVMs returns selected VMs split into tiers, in processing order.
+// If mustExist is set, every VM listed in vm_uuids must exist.
+func (r *HypercoreVMsPowerStateResource) selectVMs(ctx context.Context, data HypercoreVMsPowerStateResourceModel, mustExist bool) ([][]map[string]any, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var vmUUIDs, tags []string
+	var ordering [][]string
+	diags.Append(data.VmUUIDs.ElementsAs(ctx, &vmUUIDs, false)...)
+	diags.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+	diags.Append(data.Ordering.ElementsAs(ctx, &ordering, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	hc3VMs := utils.GetVM(map[string]any{}, *r.client)
+	selectedVMs := []map[string]any{}
+	if !data.VmUUIDs.IsNull() {
+		for _, vm := range hc3VMs {
+			if slices.Contains(vmUUIDs, utils.AnyToString(vm["uuid"])) {
+				selectedVMs = append(selectedVMs, vm)
+			}
+		}
+		if mustExist && len(selectedVMs) != len(vmUUIDs) {
+			missingUUIDs := []string{}
+			for _, vmUUID := range vmUUIDs {
+				if !slices.ContainsFunc(selectedVMs, func(vm map[string]any) bool { return vm["uuid"] == vmUUID }) {
+					missingUUIDs = append(missingUUIDs, vmUUID)
+				}
+			}
+			diags.AddError("VM not found", fmt.Sprintf("VMs not found - vmUUIDs=%s", strings.Join(missingUUIDs, ", ")))
+			return nil, diags
+		}
+	} else {
+		selectedVMs = utils.FilterVMs(hc3VMs, utils.VMFilter{TagsAll: tags})
+	}
+
+	return utils.TierVMs(selectedVMs, ordering), diags
+}
+
+// applyPowerState moves selected VMs into the desired state, and verifies they got there.
+func (r *HypercoreVMsPowerStateResource) applyPowerState(ctx context.Context, data *HypercoreVMsPowerStateResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	selectedTiers, selectDiags := r.selectVMs(ctx, *data, true)
+	diags.Append(selectDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	tiers := [][]string{}
+	vmUUIDs := []string{}
+	for _, tierVMs := range selectedTiers {
+		tier := []string{}
+		for _, vm := range tierVMs {
+			tier = append(tier, utils.AnyToString(vm["uuid"]))
+		}
+		tiers = append(tiers, tier)
+		vmUUIDs = append(vmUUIDs, tier...)
+	}
+	desiredState := data.State.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("TTRT HypercoreVMsPowerStateResource: tiers=%v, state=%s", tiers, desiredState))
+
+	d := utils.ModifyVMsPowerState(
+		*r.client,
+		tiers,
+		desiredState,
+		data.ForceSutoff.ValueBool(),
+		int(data.BatchSize.ValueInt64()),
+		int(data.StaggerDelay.ValueInt64()),
+		ctx,
+	)
+	if d != nil && d.Severity() == diag.SeverityError {
+		diags.AddError(d.Summary(), d.Detail())
+		return diags
+	}
+	if d != nil {
+		diags.AddWarning(d.Summary(), d.Detail())
+	}
+
+	vmStates := map[string]string{}
+	for _, vmUUID := range vmUUIDs {
+		hc3PowerState, d := utils.GetVMPowerState(vmUUID, *r.client)
+		if d != nil {
+			diags.AddError(d.Summary(), d.Detail())
+			return diags
+		}
+		vmStates[vmUUID] = hc3PowerState
+		if hc3PowerState != desiredState {
+			var hintMsg string
+			if desiredState == utils.POWER_STATE_SHUTOFF {
+				hintMsg = "Use 'force_shutoff' attribute to force the VMs to transition into this state."
+			}
+			diags.AddError(
+				fmt.Sprintf("Couldn't transition into the '%s' state.", desiredState),
+				fmt.Sprintf(
+					"VM %s couldn't be transitioned from '%s' state into the '%s' state. %s",
+					vmUUID, hc3PowerState, desiredState, hintMsg,
+				),
+			)
+		}
+	}
+
+	selectedVmUUIDs, listDiags := types.ListValueFrom(ctx, types.StringType, vmUUIDs)
+	diags.Append(listDiags...)
+	data.SelectedVmUUIDs = selectedVmUUIDs
+	vmStatesMap, mapDiags := types.MapValueFrom(ctx, types.StringType, vmStates)
+	diags.Append(mapDiags...)
+	data.VmStates = vmStatesMap
+	return diags
+}
+
+func (r *HypercoreVMsPowerStateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMsPowerStateResource CREATE")
+	var data HypercoreVMsPowerStateResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HTTP Client",
+			"Expected configured HTTP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.applyPowerState(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, diags := vmsPowerStateID(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	data.Id = id
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreVMsPowerStateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMsPowerStateResource READ")
+	var data HypercoreVMsPowerStateResourceModel
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	selectedTiers, diags := r.selectVMs(ctx, data, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	selectedVMs := slices.Concat(selectedTiers...)
+
+	// Observed states are kept apart from the desired state, ModifyPlan plans to fix VMs which drifted.
+	vmUUIDs := []string{}
+	vmStates := map[string]string{}
+	for _, vm := range selectedVMs {
+		vmUUID := utils.AnyToString(vm["uuid"])
+		vmUUIDs = append(vmUUIDs, vmUUID)
+		vmStates[vmUUID] = utils.PowerStateFromHypercore(vm["state"])
+	}
+	vmStatesMap, diags := types.MapValueFrom(ctx, types.StringType, vmStates)
+	resp.Diagnostics.Append(diags...)
+	data.VmStates = vmStatesMap
+	if !data.VmUUIDs.IsNull() {
+		// Missing VMs are removed from vm_uuids, so Terraform plans to fail on them.
+		selectedVmUUIDs, diags := types.SetValueFrom(ctx, types.StringType, vmUUIDs)
+		resp.Diagnostics.Append(diags...)
+		data.VmUUIDs = selectedVmUUIDs
+	}
+	selectedVmUUIDs, diags := types.ListValueFrom(ctx, types.StringType, vmUUIDs)
+	resp.Diagnostics.Append(diags...)
+	data.SelectedVmUUIDs = selectedVmUUIDs
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreVMsPowerStateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMsPowerStateResource UPDATE")
+	var data HypercoreVMsPowerStateResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.applyPowerState(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, diags := vmsPowerStateID(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	data.Id = id
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HypercoreVMsPowerStateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer utils.RecoverDiagnostics(ctx, &resp.Diagnostics)
+
+	tflog.Info(ctx, "TTRT HypercoreVMsPowerStateResource DELETE")
+	var data HypercoreVMsPowerStateResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Extra implementation not needed.
+	// VMs are left in their current power state.
+}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+var _ basetypes.StringTypable = MacAddressType{}
+var _ basetypes.StringValuableWithSemanticEquals = MacAddressValue{}
+
+// MacAddressType is a NIC MAC address. Two MAC addresses are semantically equal
+// if they differ only in letter case or separator, so HC3 formatting does not cause diffs.
+type MacAddressType struct {
+	basetypes.StringType
+}
+
+func (t MacAddressType) Equal(o attr.Type) bool {
+	other, ok := o.(MacAddressType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t MacAddressType) String() string {
+	return "MacAddressType"
+}
+
+func (t MacAddressType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return MacAddressValue{StringValue: in}, nil
+}
+
+func (t MacAddressType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return MacAddressValue{StringValue: stringValue}, nil
+}
+
+func (t MacAddressType) ValueType(_ context.Context) attr.Value {
+	return MacAddressValue{}
+}
+
+type MacAddressValue struct {
+	basetypes.StringValue
+}
+
+func NewMacAddressValue(macAddress string) MacAddressValue {
+	return MacAddressValue{StringValue: basetypes.NewStringValue(macAddress)}
+}
+
+func (v MacAddressValue) Equal(o attr.Value) bool {
+	other, ok := o.(MacAddressValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v MacAddressValue) Type(_ context.Context) attr.Type {
+	return MacAddressType{}
+}
+
+func (v MacAddressValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(MacAddressValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+	return utils.MacAddressesEqual(v.ValueString(), newValue.ValueString()), diags
+}
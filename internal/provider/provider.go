@@ -170,21 +170,33 @@ func (p *HypercoreProvider) Resources(ctx context.Context) []func() resource.Res
 		NewHypercoreVMResource,
 		NewHypercoreNicResource,
 		NewHypercoreDiskResource,
+		NewHypercoreVMCdromResource,
 		NewHypercoreVirtualDiskResource,
 		NewHypercoreISOResource,
+		NewHypercoreImageDownloadResource,
 		NewHypercoreVMPowerStateResource,
+		NewHypercoreVMsPowerStateResource,
 		NewHypercoreVMBootOrderResource,
 		NewHypercoreVMSnapshotResource,
 		NewHypercoreVMSnapshotScheduleResource,
+		NewHypercoreVMSnapshotScheduleAssignmentResource,
 		NewHypercoreVMReplicationResource,
+		NewHypercoreRemoteClusterConnectionResource,
+		NewHypercoreVMFailoverResource,
 	}
 }
 
 func (p *HypercoreProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewHypercoreVMsDataSource,
+		NewHypercoreVMDataSource,
 		NewHypercoreNodesDataSource,
+		NewHypercoreISOsDataSource,
+		NewHypercoreVirtualDisksDataSource,
+		NewHypercoreVMSnapshotsDataSource,
+		NewHypercoreVMSnapshotSchedulesDataSource,
 		NewHypercoreRemoteClusterConnectionsDataSource,
+		NewHypercoreClusterDataSource,
 	}
 }
 
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHypercoreClusterDatasource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHypercoreClusterDatasourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.hypercore_cluster.test", "uuid"),
+					resource.TestCheckResourceAttrSet("data.hypercore_cluster.test", "name"),
+					resource.TestMatchResourceAttr("data.hypercore_cluster.test", "version", regexp.MustCompile(`^\d+(\.\d+)+$`)),
+					resource.TestMatchResourceAttr("data.hypercore_cluster.test", "node_count", regexp.MustCompile(`^[1-9]\d*$`)),
+					resource.TestCheckResourceAttrSet("data.hypercore_cluster.test", "storage_raw_total_bytes"),
+					resource.TestCheckResourceAttrSet("data.hypercore_cluster.test", "storage_raw_free_bytes"),
+					resource.TestCheckResourceAttrSet("data.hypercore_cluster.test", "memory_total_bytes"),
+					resource.TestCheckResourceAttrSet("data.hypercore_cluster.test", "memory_free_bytes"),
+				),
+			},
+			{
+				Config:      testAccHypercoreClusterDatasourceMinVersionConfig,
+				ExpectError: regexp.MustCompile("HyperCore version too old"),
+			},
+		},
+	})
+}
+
+const testAccHypercoreClusterDatasourceConfig = `
+data "hypercore_cluster" "test" {
+  min_version = "9.0"
+}
+`
+
+const testAccHypercoreClusterDatasourceMinVersionConfig = `
+data "hypercore_cluster" "test" {
+  min_version = "999.0"
+}
+`
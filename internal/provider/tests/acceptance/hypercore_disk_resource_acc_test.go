@@ -19,8 +19,27 @@ func TestAccHypercoreDiskResource(t *testing.T) {
 				Config: testAccHypercoreDiskResourceConfig(),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("hypercore_disk.test", "size", "3"),
+					resource.TestCheckResourceAttr("hypercore_disk.test", "size_bytes", "3000000000"),
 					resource.TestCheckResourceAttr("hypercore_disk.test", "type", "IDE_DISK"),
 					resource.TestCheckResourceAttr("hypercore_disk.test", "flash_priority", "4"), // should default to 4 if not specified in resource config
+					resource.TestCheckResourceAttr("hypercore_disk.test", "read_only", "false"),
+					resource.TestCheckResourceAttr("hypercore_disk.test", "disable_snapshotting", "false"),
+					resource.TestCheckResourceAttrSet("hypercore_disk.test", "cache_mode"),
+				),
+			},
+			{
+				Config: testAccHypercoreDiskResourceAdvancedConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_disk.test", "cache_mode", "WRITEBACK"),
+					resource.TestCheckResourceAttr("hypercore_disk.test", "disable_snapshotting", "true"),
+				),
+			},
+			{
+				Config: testAccHypercoreDiskResourceSizeGiBConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_disk.test", "size_gib", "4"),
+					resource.TestCheckResourceAttr("hypercore_disk.test", "size_bytes", "4294967296"),
+					resource.TestCheckResourceAttr("hypercore_disk.test", "size", "4.294967296"),
 				),
 			},
 		},
@@ -44,3 +63,35 @@ output "vm_id" {
 }
 `, source_vm_name)
 }
+
+func testAccHypercoreDiskResourceAdvancedConfig() string {
+	return fmt.Sprintf(`
+data "hypercore_vms" "diskvm" {
+  name = %[1]q
+}
+
+resource "hypercore_disk" "test" {
+  vm_uuid              = data.hypercore_vms.diskvm.vms.0.uuid
+  type                 = "IDE_DISK"
+  size                 = 3
+  cache_mode           = "WRITEBACK"
+  disable_snapshotting = true
+}
+`, source_vm_name)
+}
+
+func testAccHypercoreDiskResourceSizeGiBConfig() string {
+	return fmt.Sprintf(`
+data "hypercore_vms" "diskvm" {
+  name = %[1]q
+}
+
+resource "hypercore_disk" "test" {
+  vm_uuid              = data.hypercore_vms.diskvm.vms.0.uuid
+  type                 = "IDE_DISK"
+  size_gib             = 4
+  cache_mode           = "WRITEBACK"
+  disable_snapshotting = true
+}
+`, source_vm_name)
+}
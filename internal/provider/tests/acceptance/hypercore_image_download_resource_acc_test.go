@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHypercoreImageDownloadResource(t *testing.T) {
+	localPath := t.TempDir() + "/testtf-vd-download.img"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHypercoreImageDownloadResourceConfig(localPath),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_image_download.test", "source_uuid", existing_vdisk_uuid),
+					resource.TestCheckResourceAttr("hypercore_image_download.test", "path", localPath),
+					resource.TestCheckResourceAttrSet("hypercore_image_download.test", "sha256"),
+					resource.TestCheckResourceAttrSet("hypercore_image_download.test", "size_bytes"),
+				),
+			},
+			{
+				// Unchanged file is not downloaded again
+				Config:   testAccHypercoreImageDownloadResourceConfig(localPath),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccHypercoreImageDownloadResourceConfig(localPath string) string {
+	return fmt.Sprintf(`
+resource "hypercore_image_download" "test" {
+  source_type = "virtual_disk"
+  source_uuid = %[1]q
+  path        = %[2]q
+}
+`, existing_vdisk_uuid, localPath)
+}
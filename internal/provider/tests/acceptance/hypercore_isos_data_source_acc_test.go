@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHypercoreISOsDatasource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHypercoreISOsDatasourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hypercore_isos.test", "isos.#", "1"),
+					resource.TestCheckResourceAttr("data.hypercore_isos.test", "isos.0.name", "testtf-isos-ds.iso"),
+					resource.TestCheckResourceAttr("data.hypercore_isos.test", "isos.0.ready_for_insert", "true"),
+					resource.TestCheckResourceAttr("data.hypercore_isos.test", "isos.0.vm_uuids.#", "1"),
+					resource.TestCheckResourceAttr("data.hypercore_isos.test", "isos.0.vm_uuids.0", source_vm_uuid),
+					resource.TestCheckResourceAttrSet("data.hypercore_isos.test", "isos.0.uuid"),
+					resource.TestCheckResourceAttrSet("data.hypercore_isos.test", "isos.0.path"),
+					resource.TestCheckResourceAttrSet("data.hypercore_isos.test", "isos.0.size_bytes"),
+				),
+			},
+		},
+	})
+}
+
+func testAccHypercoreISOsDatasourceConfig() string {
+	return fmt.Sprintf(`
+resource "hypercore_iso" "test" {
+  name       = "testtf-isos-ds.iso"
+  source_url = "https://dl-cdn.alpinelinux.org/alpine/v3.21/releases/aarch64/alpine-virt-3.21.3-aarch64.iso"
+}
+
+resource "hypercore_vm_cdrom" "test" {
+  vm_uuid  = %[1]q
+  iso_name = hypercore_iso.test.name
+}
+
+data "hypercore_isos" "test" {
+  name_regex = "^testtf-isos-ds\\.iso$"
+  depends_on = [hypercore_vm_cdrom.test]
+}
+`, source_vm_uuid)
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccHypercoreNicResource(t *testing.T) {
@@ -61,6 +62,13 @@ func TestAccHypercoreNicResource_Mac(t *testing.T) {
 					resource.TestCheckResourceAttr("hypercore_nic.test_mac", "mac_address", "52:54:00:11:22:44"),
 				),
 			},
+			{
+				ResourceName:            "hypercore_nic.test_mac",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccHypercoreNicImportStateIdFunc("hypercore_nic.test_mac", "mac_address"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ipv4_addresses"},
+			},
 		},
 	})
 }
@@ -79,3 +87,78 @@ resource "hypercore_nic" "test_mac" {
 }
 `, source_vm_name, vlan, nicType, macAddress)
 }
+
+func TestAccHypercoreNicResource_Connected(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHypercoreSourceVMRConfig_Connected("52-54-00-11-22-aa", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_nic.test_connected", "connected", "false"),
+					// Configured MAC format is kept, HC3 returns 52:54:00:11:22:AA
+					resource.TestCheckResourceAttr("hypercore_nic.test_connected", "mac_address", "52-54-00-11-22-aa"),
+					resource.TestCheckResourceAttrSet("hypercore_nic.test_connected", "ipv4_addresses.#"),
+				),
+			},
+			{
+				Config: testAccHypercoreSourceVMRConfig_Connected("52:54:00:11:22:AA", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_nic.test_connected", "connected", "true"),
+				),
+			},
+			{
+				ResourceName:            "hypercore_nic.test_connected",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccHypercoreNicImportStateIdFunc("hypercore_nic.test_connected", ""),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ipv4_addresses"},
+			},
+		},
+	})
+}
+
+func testAccHypercoreSourceVMRConfig_Connected(macAddress string, connected bool) string {
+	return fmt.Sprintf(`
+data "hypercore_vms" "nicvm" {
+  name = %[1]q
+}
+
+resource "hypercore_nic" "test_connected" {
+  vm_uuid     = data.hypercore_vms.nicvm.vms.0.uuid
+  vlan        = 14
+  type        = "VIRTIO"
+  mac_address = %[2]q
+  connected   = %[3]t
+}
+`, source_vm_name, macAddress, connected)
+}
+
+// testAccHypercoreNicImportStateIdFunc builds 'vm_uuid:mac_address' import ID,
+// or 'vm_uuid:nic_index' if selectorAttr is empty.
+func testAccHypercoreNicImportStateIdFunc(resourceName string, selectorAttr string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+		vmUUID := rs.Primary.Attributes["vm_uuid"]
+		if selectorAttr != "" {
+			return fmt.Sprintf("%s:%s", vmUUID, rs.Primary.Attributes[selectorAttr]), nil
+		}
+		vm := s.RootModule().Resources["data.hypercore_vms.nicvm"]
+		if vm == nil {
+			return "", fmt.Errorf("resource not found: data.hypercore_vms.nicvm")
+		}
+		for i := 0; ; i++ {
+			nicUUID, ok := vm.Primary.Attributes[fmt.Sprintf("vms.0.nics.%d.uuid", i)]
+			if !ok {
+				return "", fmt.Errorf("NIC %s not found in VM %s", rs.Primary.ID, vmUUID)
+			}
+			if nicUUID == rs.Primary.ID {
+				return fmt.Sprintf("%s:%d", vmUUID, i), nil
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHypercoreNodesDatasource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHypercoreNodesDatasourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.hypercore_nodes.test", "nodes.#", regexp.MustCompile(`^[1-9]\d*$`)),
+					resource.TestCheckResourceAttr("data.hypercore_nodes.test", "nodes.0.online", "true"),
+					resource.TestCheckResourceAttr("data.hypercore_nodes.test", "nodes.0.schedulable", "true"),
+					resource.TestCheckResourceAttr("data.hypercore_nodes.test", "nodes.0.maintenance", "false"),
+					resource.TestCheckResourceAttrSet("data.hypercore_nodes.test", "nodes.0.uuid"),
+					resource.TestCheckResourceAttrSet("data.hypercore_nodes.test", "nodes.0.cpu_threads"),
+					resource.TestCheckResourceAttrSet("data.hypercore_nodes.test", "nodes.0.memory_free_bytes"),
+					resource.TestCheckResourceAttrSet("data.hypercore_nodes.test", "nodes.0.vm_count"),
+				),
+			},
+		},
+	})
+}
+
+const testAccHypercoreNodesDatasourceConfig = `
+data "hypercore_nodes" "test" {
+  schedulable = true
+}
+`
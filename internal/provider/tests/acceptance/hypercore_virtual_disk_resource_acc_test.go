@@ -42,3 +42,29 @@ resource "hypercore_disk" "attach_vd_test" {
 
 `, source_vm_name, existing_vdisk_uuid)
 }
+
+func TestAccHypercoreVirtualDiskResourceFromDisk(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHypercoreVirtualDiskResourceFromDiskConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_virtual_disk.from_disk_test", "name", "testtf-vd-from-disk.img"),
+					resource.TestCheckResourceAttr("hypercore_virtual_disk.from_disk_test", "source_disk_uuid", source_disk_uuid),
+					resource.TestCheckResourceAttrSet("hypercore_virtual_disk.from_disk_test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccHypercoreVirtualDiskResourceFromDiskConfig() string {
+	return fmt.Sprintf(`
+resource "hypercore_virtual_disk" "from_disk_test" {
+  name             = "testtf-vd-from-disk.img"
+  source_disk_uuid = %[1]q
+}
+`, source_disk_uuid)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHypercoreVMCdromResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHypercoreVMCdromResourceConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_vm_cdrom.test", "vm_uuid", source_vm_uuid),
+					resource.TestCheckResourceAttr("hypercore_vm_cdrom.test", "iso_name", "testtf-cdrom.iso"),
+					resource.TestCheckResourceAttrSet("hypercore_vm_cdrom.test", "slot"),
+					resource.TestCheckResourceAttrSet("hypercore_vm_cdrom.test", "iso_path"),
+				),
+			},
+			{
+				Config: testAccHypercoreVMCdromResourceConfig(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("hypercore_vm_cdrom.test", "iso_name"),
+					resource.TestCheckResourceAttr("hypercore_vm_cdrom.test", "iso_path", ""),
+					// Ejecting must not delete the ISO
+					resource.TestCheckResourceAttr("hypercore_iso.test", "name", "testtf-cdrom.iso"),
+				),
+			},
+		},
+	})
+}
+
+func testAccHypercoreVMCdromResourceConfig(insert bool) string {
+	isoName := "null"
+	if insert {
+		isoName = "hypercore_iso.test.name"
+	}
+	return fmt.Sprintf(`
+resource "hypercore_iso" "test" {
+  name       = "testtf-cdrom.iso"
+  source_url = "https://dl-cdn.alpinelinux.org/alpine/v3.21/releases/aarch64/alpine-virt-3.21.3-aarch64.iso"
+}
+
+resource "hypercore_vm_cdrom" "test" {
+  vm_uuid  = %[1]q
+  iso_name = %[2]s
+}
+`, source_vm_uuid, isoName)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHypercoreVMDatasource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHypercoreVMDatasourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hypercore_vm.by_name", "uuid", source_vm_uuid),
+					resource.TestCheckResourceAttr("data.hypercore_vm.by_name", "vm.name", source_vm_name),
+					resource.TestCheckResourceAttr("data.hypercore_vm.by_uuid", "name", source_vm_name),
+					resource.TestCheckResourceAttr("data.hypercore_vm.by_uuid", "vm.uuid", source_vm_uuid),
+					resource.TestCheckResourceAttr("data.hypercore_vms.by_regex", "vms.0.name", source_vm_name),
+				),
+			},
+		},
+	})
+}
+
+func testAccHypercoreVMDatasourceConfig() string {
+	return fmt.Sprintf(`
+data "hypercore_vm" "by_name" {
+  name = %[1]q
+}
+
+data "hypercore_vm" "by_uuid" {
+  uuid = %[2]q
+}
+
+data "hypercore_vms" "by_regex" {
+  name_regex = "^%[1]s$"
+}
+`, source_vm_name, source_vm_uuid)
+}
@@ -16,16 +16,25 @@ func TestAccHypercorePowerStateResource(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccHypercorePowerStateResourceConfig(),
+				Config: testAccHypercorePowerStateResourceConfig("1"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("hypercore_vm_power_state.power_state_test", "state", "RUNNING"),
+					resource.TestCheckResourceAttr("hypercore_vm_power_state.power_state_test", "restart_method", "RESET"),
+				),
+			},
+			// Changing restart_trigger restarts the VM, it stays RUNNING
+			{
+				Config: testAccHypercorePowerStateResourceConfig("2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_vm_power_state.power_state_test", "state", "RUNNING"),
+					resource.TestCheckResourceAttr("hypercore_vm_power_state.power_state_test", "restart_trigger", "2"),
 				),
 			},
 		},
 	})
 }
 
-func testAccHypercorePowerStateResourceConfig() string {
+func testAccHypercorePowerStateResourceConfig(restartTrigger string) string {
 	return fmt.Sprintf(`
 data "hypercore_vms" "integrationvm" {
   name = %[1]q
@@ -34,6 +43,9 @@ data "hypercore_vms" "integrationvm" {
 resource "hypercore_vm_power_state" "power_state_test" {
   vm_uuid = data.hypercore_vms.integrationvm.vms.0.uuid
   state   = "RUNNING"
+
+  restart_trigger = %[2]q
+  restart_method  = "RESET"
 }
-`, source_vm_name)
+`, source_vm_name, restartTrigger)
 }
@@ -103,3 +103,65 @@ resource "hypercore_vm" "test" {
 }
 `, vm_name)
 }
+
+// VM with snapshot_schedule_uuid in ignore_changes keeps the schedule
+// assigned by hypercore_vm_snapshot_schedule_assignment, without a diff.
+func TestAccHypercoreVMResourceSnapshotScheduleAssigned(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfig_AssignedSnapshotSchedule("testtf-vm-schedule-assigned"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot_schedule_assignment.test", "assigned_vm_uuids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("hypercore_vm_snapshot_schedule_assignment.test", "assigned_vm_uuids.*", "hypercore_vm.test", "id"),
+				),
+			},
+			{
+				Config:             testConfig_AssignedSnapshotSchedule("testtf-vm-schedule-assigned"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("hypercore_vm.test", "snapshot_schedule_uuid", "hypercore_vm_snapshot_schedule.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testConfig_AssignedSnapshotSchedule(vm_name string) string {
+	return fmt.Sprintf(`
+resource "hypercore_vm" "test" {
+  name = %[1]q
+  tags = ["testtf"]
+  vcpu = 4
+  memory = 4096
+  description = "testtf-vm-description"
+  affinity_strategy = {}
+
+  lifecycle {
+    ignore_changes = [snapshot_schedule_uuid]
+  }
+}
+
+resource "hypercore_vm_snapshot_schedule" "test" {
+  name = %[1]q
+  rules = [
+    {
+      name                    = "testtf-rule"
+      every                   = "1d"
+      local_retention_seconds = 3600
+    }
+  ]
+}
+
+resource "hypercore_vm_snapshot_schedule_assignment" "test" {
+  schedule_uuid = hypercore_vm_snapshot_schedule.test.id
+  vm_uuids      = [hypercore_vm.test.id]
+}
+`, vm_name)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccHypercoreVMSnapshotResource_Retention(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHypercoreVMSnapshotResourceConfig("testtf-snapshot", `local_retain_until = "1d"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot.test", "label", "testtf-snapshot"),
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot.test", "local_retain_until", "1d"),
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot.test", "replicate", "true"),
+					resource.TestCheckResourceAttrSet("hypercore_vm_snapshot.test", "local_retain_until_timestamp"),
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot.test", "remote_retain_until_timestamp", "0"),
+				),
+			},
+			{
+				Config: testAccHypercoreVMSnapshotResourceConfig("testtf-snapshot", `local_retain_until = "2w"
+  remote_retain_until = "4w"
+  replicate           = false`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("hypercore_vm_snapshot.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot.test", "local_retain_until", "2w"),
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot.test", "remote_retain_until", "4w"),
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot.test", "replicate", "false"),
+				),
+			},
+			{
+				Config: testAccHypercoreVMSnapshotResourceConfig("testtf-snapshot-renamed", ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("hypercore_vm_snapshot.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot.test", "label", "testtf-snapshot-renamed"),
+					resource.TestCheckNoResourceAttr("hypercore_vm_snapshot.test", "local_retain_until"),
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot.test", "local_retain_until_timestamp", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccHypercoreVMSnapshotResourceConfig(label string, retention string) string {
+	return fmt.Sprintf(`
+resource "hypercore_vm_snapshot" "test" {
+  vm_uuid = %[1]q
+  label   = %[2]q
+  %[3]s
+}
+`, source_vm_uuid, label, retention)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHypercoreVMSnapshotScheduleAssignmentResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccHypercoreVMSnapshotScheduleAssignmentResourceConfig(`vm_uuids = ["00000000-0000-0000-0000-000000000000"]`),
+				ExpectError: regexp.MustCompile(`VM not found`),
+			},
+			{
+				Config: testAccHypercoreVMSnapshotScheduleAssignmentResourceConfig(fmt.Sprintf(`vm_uuids = [%q]`, source_vm_uuid)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("hypercore_vm_snapshot_schedule_assignment.test", "schedule_uuid", "hypercore_vm_snapshot_schedule.test", "id"),
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot_schedule_assignment.test", "exclusive", "false"),
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot_schedule_assignment.test", "assigned_vm_uuids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("hypercore_vm_snapshot_schedule_assignment.test", "assigned_vm_uuids.*", source_vm_uuid),
+				),
+			},
+			{
+				ResourceName:            "hypercore_vm_snapshot_schedule_assignment.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"vm_uuids"},
+			},
+		},
+	})
+}
+
+func testAccHypercoreVMSnapshotScheduleAssignmentResourceConfig(selector string) string {
+	return `
+resource "hypercore_vm_snapshot_schedule" "test" {
+  name = "testtf-schedule-assignment"
+  rules = [
+    {
+      name                    = "testtf-rule"
+      every                   = "1d"
+      local_retention_seconds = 3600
+    }
+  ]
+}
+
+resource "hypercore_vm_snapshot_schedule_assignment" "test" {
+  schedule_uuid = hypercore_vm_snapshot_schedule.test.id
+  ` + selector + `
+}
+`
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHypercoreVMSnapshotScheduleResource_Structured(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccHypercoreVMSnapshotScheduleResourceConfig(`frequency = "FREQ=MINUTLY;INTERVAL=5"`),
+				ExpectError: regexp.MustCompile(`not a valid RFC-2445 RRULE`),
+			},
+			{
+				Config: testAccHypercoreVMSnapshotScheduleResourceConfig(`every = "1d"
+      at       = "02:30"
+      weekdays = ["MO", "FR"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot_schedule.test", "rules.0.frequency", "FREQ=DAILY;INTERVAL=1;BYDAY=MO,FR"),
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot_schedule.test", "rules.0.start_timestamp", "2010-01-01 02:30:00"),
+				),
+			},
+			{
+				Config: testAccHypercoreVMSnapshotScheduleResourceConfig(`frequency       = "FREQ=HOURLY;INTERVAL=6"
+      start_timestamp = "2010-01-01T00:00:00Z"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot_schedule.test", "rules.0.frequency", "FREQ=HOURLY;INTERVAL=6"),
+					resource.TestCheckResourceAttr("hypercore_vm_snapshot_schedule.test", "rules.0.start_timestamp", "2010-01-01T00:00:00Z"),
+					resource.TestCheckNoResourceAttr("hypercore_vm_snapshot_schedule.test", "rules.0.every"),
+				),
+			},
+		},
+	})
+}
+
+func testAccHypercoreVMSnapshotScheduleResourceConfig(rule string) string {
+	return `
+resource "hypercore_vm_snapshot_schedule" "test" {
+  name = "testtf-schedule-structured"
+  rules = [
+    {
+      name                    = "testtf-rule"
+      local_retention_seconds = 3600
+      ` + rule + `
+    }
+  ]
+}
+`
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHypercoreVMSnapshotSchedulesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHypercoreVMSnapshotSchedulesDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hypercore_vm_snapshot_schedules.test", "schedules.#", "1"),
+					resource.TestCheckResourceAttrPair("data.hypercore_vm_snapshot_schedules.test", "schedules.0.uuid", "hypercore_vm_snapshot_schedule.test", "id"),
+					resource.TestCheckResourceAttr("data.hypercore_vm_snapshot_schedules.test", "schedules.0.rules.#", "1"),
+					resource.TestCheckResourceAttr("data.hypercore_vm_snapshot_schedules.test", "schedules.0.rules.0.frequency", "FREQ=DAILY;INTERVAL=1"),
+					resource.TestCheckResourceAttr("data.hypercore_vm_snapshot_schedules.test", "schedules.0.vm_uuids.#", "1"),
+					resource.TestCheckResourceAttr("data.hypercore_vm_snapshot_schedules.test", "schedules.0.vm_uuids.0", source_vm_uuid),
+				),
+			},
+			{
+				ResourceName:      "hypercore_vm_snapshot_schedule.test",
+				ImportState:       true,
+				ImportStateId:     "testtf-schedules-ds",
+				ImportStateVerify: true,
+				// Import by name reads rules in the HC3 form.
+				ImportStateVerifyIgnore: []string{"rules"},
+			},
+		},
+	})
+}
+
+func testAccHypercoreVMSnapshotSchedulesDataSourceConfig() string {
+	return fmt.Sprintf(`
+resource "hypercore_vm_snapshot_schedule" "test" {
+  name = "testtf-schedules-ds"
+  rules = [
+    {
+      name                    = "testtf-rule"
+      every                   = "1d"
+      local_retention_seconds = 3600
+    }
+  ]
+}
+
+resource "hypercore_vm_snapshot_schedule_assignment" "test" {
+  schedule_uuid = hypercore_vm_snapshot_schedule.test.id
+  vm_uuids      = [%q]
+}
+
+data "hypercore_vm_snapshot_schedules" "test" {
+  name       = hypercore_vm_snapshot_schedule.test.name
+  depends_on = [hypercore_vm_snapshot_schedule_assignment.test]
+}
+`, source_vm_uuid)
+}
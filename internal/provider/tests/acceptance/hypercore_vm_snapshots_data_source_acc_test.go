@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHypercoreVMSnapshotsDatasource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHypercoreVMSnapshotsDatasourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hypercore_vm_snapshots.test", "snapshots.#", "2"),
+					resource.TestCheckResourceAttr("data.hypercore_vm_snapshots.test", "snapshots.0.label", "testtf-snapshots-ds-1"),
+					resource.TestCheckResourceAttr("data.hypercore_vm_snapshots.test", "snapshots.0.type", "USER"),
+					resource.TestCheckResourceAttr("data.hypercore_vm_snapshots.test", "snapshots.0.vm_uuid", source_vm_uuid),
+					resource.TestCheckResourceAttrPair("data.hypercore_vm_snapshots.test", "latest.uuid", "hypercore_vm_snapshot.second", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccHypercoreVMSnapshotsDatasourceConfig() string {
+	return fmt.Sprintf(`
+resource "hypercore_vm_snapshot" "first" {
+  vm_uuid = %[1]q
+  label   = "testtf-snapshots-ds-1"
+}
+
+resource "hypercore_vm_snapshot" "second" {
+  vm_uuid    = %[1]q
+  label      = "testtf-snapshots-ds-2"
+  depends_on = [hypercore_vm_snapshot.first]
+}
+
+data "hypercore_vm_snapshots" "test" {
+  vm_uuid     = %[1]q
+  type        = "USER"
+  label_regex = "^testtf-snapshots-ds-"
+  depends_on  = [hypercore_vm_snapshot.second]
+}
+`, source_vm_uuid)
+}
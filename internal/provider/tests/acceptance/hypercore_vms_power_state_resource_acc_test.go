@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHypercoreVMsPowerStateResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHypercoreVMsPowerStateResourceConfig("RUNNING"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_vms_power_state.test", "state", "RUNNING"),
+					resource.TestCheckResourceAttr("hypercore_vms_power_state.test", "selected_vm_uuids.#", "1"),
+					resource.TestCheckResourceAttr("hypercore_vms_power_state.test", "selected_vm_uuids.0", source_vm_uuid),
+				),
+			},
+			{
+				Config: testAccHypercoreVMsPowerStateResourceConfig("SHUTOFF"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hypercore_vms_power_state.test", "state", "SHUTOFF"),
+				),
+			},
+		},
+	})
+}
+
+func testAccHypercoreVMsPowerStateResourceConfig(state string) string {
+	return fmt.Sprintf(`
+resource "hypercore_vms_power_state" "test" {
+  vm_uuids      = [%[1]q]
+  state         = %[2]q
+  force_shutoff = true
+}
+`, source_vm_uuid, state)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetClusterCapacity(t *testing.T) {
+	nodes := []map[string]any{
+		{
+			"memSize":            float64(64000),
+			"totalMemUsageBytes": float64(16000),
+			"drives": []any{
+				map[string]any{"capacityBytes": float64(1000), "usedBytes": float64(300)},
+				map[string]any{"capacityBytes": float64(2000), "usedBytes": float64(500)},
+			},
+		},
+		{
+			// node without usage reported
+			"memSize": float64(32000),
+			"drives": []any{
+				map[string]any{"capacityBytes": float64(1000)},
+			},
+		},
+	}
+
+	capacity := utils.GetClusterCapacity(nodes)
+	assert.Equal(t, int64(4000), capacity.StorageRawTotalBytes)
+	assert.Equal(t, int64(800), capacity.StorageRawUsedBytes)
+	assert.Equal(t, int64(3200), capacity.StorageRawFreeBytes())
+	assert.Equal(t, int64(96000), capacity.MemoryTotalBytes)
+	assert.Equal(t, int64(16000), capacity.MemoryUsedBytes)
+	assert.Equal(t, int64(80000), capacity.MemoryFreeBytes())
+
+	empty := utils.GetClusterCapacity([]map[string]any{})
+	assert.Equal(t, utils.ClusterCapacity{}, empty)
+	assert.Equal(t, int64(0), empty.StorageRawFreeBytes())
+}
+
+func TestCompareHyperCoreVersion(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"9.4", "9.4.0", 0},
+		{"9.4.30.217736", "9.4.30.217736", 0},
+		{"9.4.30", "9.4.4", 1},
+		{"9.3.99", "9.4", -1},
+		{"10.0", "9.4.30", 1},
+		{"9.4", "9.4.0.1", -1},
+	}
+	for _, tt := range tests {
+		cmp, err := utils.CompareHyperCoreVersion(tt.a, tt.b)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, cmp, "%s vs %s", tt.a, tt.b)
+	}
+
+	for _, invalid := range []string{"", "9.x", "9..4", "v9.4", "9.-1"} {
+		_, err := utils.CompareHyperCoreVersion(invalid, "9.4")
+		assert.Error(t, err, invalid)
+		assert.NotNil(t, utils.ValidateHyperCoreVersion(invalid), invalid)
+	}
+	assert.Nil(t, utils.ValidateHyperCoreVersion("9.4.30.217736"))
+}
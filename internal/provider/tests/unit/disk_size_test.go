@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/provider"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskSizeConversions(t *testing.T) {
+	assert.Equal(t, int64(47200000000), utils.DiskSizeGBToBytes(47.2))
+	assert.Equal(t, int64(10737418240), utils.DiskSizeGiBToBytes(10))
+	assert.Equal(t, 10.73741824, utils.DiskSizeBytesToGB(10737418240))
+
+	gib, ok := utils.DiskSizeBytesToGiB(10737418240)
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), gib)
+
+	_, ok = utils.DiskSizeBytesToGiB(3000000000)
+	assert.False(t, ok)
+
+	// GiB size round-trips through GB without losing bytes
+	assert.Equal(t, int64(10737418240), utils.DiskSizeGBToBytes(utils.DiskSizeBytesToGB(10737418240)))
+}
+
+func TestValidateDiskSizeBytes(t *testing.T) {
+	assert.Nil(t, utils.ValidateDiskSizeBytes("disk-uuid", 3000000000, 3000000000))
+	assert.Nil(t, utils.ValidateDiskSizeBytes("disk-uuid", 3000000000, 4294967296))
+	assert.NotNil(t, utils.ValidateDiskSizeBytes("disk-uuid", 4294967296, 3000000000))
+}
+
+func TestDiskSizeGBSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+
+	equal, diags := provider.NewDiskSizeGBValue(47.2).Float64SemanticEquals(ctx, provider.NewDiskSizeGBValue(math.Nextafter(47.2, 48)))
+	assert.False(t, diags.HasError())
+	assert.True(t, equal)
+
+	equal, _ = provider.NewDiskSizeGBValue(10.73741824).Float64SemanticEquals(ctx, provider.NewDiskSizeGBValue(utils.DiskSizeBytesToGB(10737418240)))
+	assert.True(t, equal)
+
+	equal, _ = provider.NewDiskSizeGBValue(10.7).Float64SemanticEquals(ctx, provider.NewDiskSizeGBValue(10.8))
+	assert.False(t, equal)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadToFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/v1/ISO/iso-uuid/data", r.URL.Path)
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+	restClient := utils.RestClient{HttpClient: server.Client(), Host: server.URL, AuthHeader: map[string]string{}}
+
+	localPath := filepath.Join(t.TempDir(), "a.iso")
+	size, checksum, d := utils.DownloadToFile(restClient, utils.GetDownloadEndpoint(utils.DOWNLOAD_SOURCE_ISO, "iso-uuid"), localPath, context.Background())
+
+	assert.Nil(t, d)
+	assert.Equal(t, int64(5), size)
+	// sha256 of "hello"
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", checksum)
+	content, err := os.ReadFile(localPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	localChecksum, err := utils.FileSHA256(localPath)
+	assert.NoError(t, err)
+	assert.Equal(t, checksum, localChecksum)
+
+	_, err = os.Stat(localPath + ".part")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestDownloadToFileError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+	restClient := utils.RestClient{HttpClient: server.Client(), Host: server.URL, AuthHeader: map[string]string{}}
+
+	localPath := filepath.Join(t.TempDir(), "a.img")
+	_, _, d := utils.DownloadToFile(restClient, utils.GetDownloadEndpoint(utils.DOWNLOAD_SOURCE_VIRTUAL_DISK, "vd-uuid"), localPath, context.Background())
+
+	assert.NotNil(t, d)
+	_, err := os.Stat(localPath)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(localPath + ".part")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFileSHA256Missing(t *testing.T) {
+	checksum, err := utils.FileSHA256(filepath.Join(t.TempDir(), "missing"))
+	assert.NoError(t, err)
+	assert.Equal(t, "", checksum)
+}
+
+func TestValidateDownloadSourceType(t *testing.T) {
+	assert.Nil(t, utils.ValidateDownloadSourceType("iso"))
+	assert.Nil(t, utils.ValidateDownloadSourceType("virtual_disk"))
+	assert.NotNil(t, utils.ValidateDownloadSourceType("ISO"))
+}
+
+func TestCheckLocalFile(t *testing.T) {
+	localPath := filepath.Join(t.TempDir(), "a.iso")
+	assert.NoError(t, os.WriteFile(localPath, []byte("hello"), 0o600))
+	checksum := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	modifiedTime, err := utils.FileModifiedTime(localPath)
+	assert.NoError(t, err)
+
+	unchanged, currentModifiedTime, err := utils.CheckLocalFile(localPath, 5, modifiedTime, checksum)
+	assert.NoError(t, err)
+	assert.True(t, unchanged)
+	assert.Equal(t, modifiedTime, currentModifiedTime)
+
+	// Same size and modification time, the file is not hashed.
+	unchanged, _, _ = utils.CheckLocalFile(localPath, 5, modifiedTime, "other-checksum")
+	assert.True(t, unchanged)
+
+	// Modification time changed, the file is hashed.
+	unchanged, currentModifiedTime, _ = utils.CheckLocalFile(localPath, 5, "2000-01-01T00:00:00Z", checksum)
+	assert.True(t, unchanged)
+	assert.Equal(t, modifiedTime, currentModifiedTime)
+	unchanged, _, _ = utils.CheckLocalFile(localPath, 5, "2000-01-01T00:00:00Z", "other-checksum")
+	assert.False(t, unchanged)
+
+	unchanged, _, _ = utils.CheckLocalFile(localPath, 6, modifiedTime, checksum)
+	assert.False(t, unchanged)
+	unchanged, _, err = utils.CheckLocalFile(filepath.Join(t.TempDir(), "missing"), 5, modifiedTime, checksum)
+	assert.NoError(t, err)
+	assert.False(t, unchanged)
+}
+
+func TestGetDownloadSourceVersion(t *testing.T) {
+	assert.Equal(t, "checksum:abc", utils.GetDownloadSourceVersion(utils.DOWNLOAD_SOURCE_ISO, map[string]any{"size": 5, "checksum": "abc", "modified": 1000}))
+	assert.Equal(t, "modified:1000", utils.GetDownloadSourceVersion(utils.DOWNLOAD_SOURCE_VIRTUAL_DISK, map[string]any{"capacityBytes": 5, "modified": 1000}))
+	assert.Equal(t, "size:5", utils.GetDownloadSourceVersion(utils.DOWNLOAD_SOURCE_VIRTUAL_DISK, map[string]any{"capacityBytes": 5}))
+}
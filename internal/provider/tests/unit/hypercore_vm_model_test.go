@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildHypercoreVMModelFromAPIData(t *testing.T) {
+	ctx := context.Background()
+	hc3VM := map[string]any{
+		"uuid":                 "vm-uuid",
+		"name":                 "vm-name",
+		"description":          "vm-description",
+		"state":                "RUNNING",
+		"desiredDisposition":   "RUNNING",
+		"numVCPU":              2.0,
+		"mem":                  4294967296.0,
+		"machineType":          "scale-7.2",
+		"operatingSystem":      "os_other",
+		"nodeUUID":             "node-uuid",
+		"guestAgentState":      "AVAILABLE",
+		"bootDevices":          []any{"disk-uuid", "nic-uuid"},
+		"snapUUIDs":            []any{"snap-uuid"},
+		"replicationUUIDs":     []any{},
+		"sourceVirDomainUUID":  "",
+		"snapshotScheduleUUID": "",
+		"tags":                 "a,b",
+		"console": map[string]any{
+			"type":   "VNC",
+			"ip":     "10.0.0.1",
+			"port":   5900.0,
+			"keymap": "en-us",
+		},
+		"affinityStrategy": map[string]any{
+			"strictAffinity":    false,
+			"preferredNodeUUID": "",
+			"backupNodeUUID":    "",
+		},
+		"blockDevs": []any{
+			map[string]any{
+				"uuid":                  "disk-uuid",
+				"type":                  "VIRTIO_DISK",
+				"slot":                  0.0,
+				"capacity":              10000000000.0,
+				"tieringPriorityFactor": 8.0,
+				"path":                  "scribe/disk-uuid",
+			},
+			map[string]any{
+				"uuid":                  "cdrom-uuid",
+				"type":                  "IDE_CDROM",
+				"slot":                  0.0,
+				"capacity":              0.0,
+				"tieringPriorityFactor": 8.0,
+				"path":                  "scribe/iso-uuid",
+			},
+		},
+		"netDevs": []any{
+			map[string]any{
+				"uuid":          "nic-uuid",
+				"type":          "VIRTIO",
+				"vlan":          0.0,
+				"macAddress":    "7C:4C:58:00:00:01",
+				"connected":     false,
+				"ipv4Addresses": []any{"10.0.0.2"},
+			},
+		},
+	}
+
+	vmModel := provider.BuildHypercoreVMModelFromAPIData(hc3VM, nil, ctx)
+
+	assert.Equal(t, "scale-7.2", vmModel.MachineType.ValueString())
+	assert.Equal(t, "os_other", vmModel.OSType.ValueString())
+	assert.Equal(t, "node-uuid", vmModel.NodeUUID.ValueString())
+	assert.Equal(t, "AVAILABLE", vmModel.GuestAgentState.ValueString())
+	assert.Equal(t, "RUNNING", vmModel.DesiredDisposition.ValueString())
+	assert.Equal(t, []types.String{types.StringValue("disk-uuid"), types.StringValue("nic-uuid")}, vmModel.BootDevices)
+	assert.Equal(t, []types.String{types.StringValue("snap-uuid")}, vmModel.SnapshotUUIDs)
+	assert.Equal(t, []types.String{}, vmModel.ReplicationUUIDs)
+	assert.Equal(t, "", vmModel.SourceVMUUID.ValueString())
+	assert.Equal(t, "VNC", vmModel.Console.Type.ValueString())
+	assert.Equal(t, int64(5900), vmModel.Console.Port.ValueInt64())
+	assert.Equal(t, int64(10000000000), vmModel.Disks[0].SizeBytes.ValueInt64())
+	assert.True(t, vmModel.Disks[0].SizeGiB.IsNull())
+	assert.Equal(t, int64(4), vmModel.Disks[0].FlashPriority.ValueInt64())
+	assert.Equal(t, "", vmModel.Disks[0].IsoPath.ValueString())
+	assert.Equal(t, "scribe/iso-uuid", vmModel.Disks[1].IsoPath.ValueString())
+	assert.False(t, vmModel.Nics[0].Connected.ValueBool())
+
+	_, diag := provider.ConvertVMModelToObject(ctx, vmModel)
+	assert.Nil(t, diag)
+}
+
+func TestBuildHypercoreVMModelFromAPIDataMissingFields(t *testing.T) {
+	ctx := context.Background()
+	hc3VM := map[string]any{
+		"uuid":                 "vm-uuid",
+		"name":                 "vm-name",
+		"description":          "",
+		"state":                "SHUTOFF",
+		"numVCPU":              1.0,
+		"mem":                  1073741824.0,
+		"tags":                 "",
+		"snapshotScheduleUUID": "",
+		"affinityStrategy": map[string]any{
+			"strictAffinity":    false,
+			"preferredNodeUUID": "",
+			"backupNodeUUID":    "",
+		},
+		"blockDevs": []any{},
+		"netDevs":   []any{},
+	}
+
+	vmModel := provider.BuildHypercoreVMModelFromAPIData(hc3VM, nil, ctx)
+
+	assert.Equal(t, "", vmModel.MachineType.ValueString())
+	assert.Equal(t, "", vmModel.Console.Type.ValueString())
+	assert.Equal(t, int64(0), vmModel.Console.Port.ValueInt64())
+	assert.Equal(t, []types.String{}, vmModel.BootDevices)
+
+	_, diag := provider.ConvertVMModelToObject(ctx, vmModel)
+	assert.Nil(t, diag)
+}
+
+func TestVMSchemaMatchesBetweenDataSourceAndPowerState(t *testing.T) {
+	ctx := context.Background()
+
+	dsResp := &datasource.SchemaResponse{}
+	provider.NewHypercoreVMsDataSource().Schema(ctx, datasource.SchemaRequest{}, dsResp)
+	vmsType := dsResp.Schema.Attributes["vms"].GetType()
+
+	rsResp := &resource.SchemaResponse{}
+	provider.NewHypercoreVMPowerStateResource().Schema(ctx, resource.SchemaRequest{}, rsResp)
+	vmType := rsResp.Schema.Attributes["vm"].GetType()
+
+	assert.Equal(t, vmsType.(types.ListType).ElemType, vmType)
+
+	vmDsResp := &datasource.SchemaResponse{}
+	provider.NewHypercoreVMDataSource().Schema(ctx, datasource.SchemaRequest{}, vmDsResp)
+	dsAttributes := vmDsResp.Schema.Attributes["vm"].(dsschema.SingleNestedAttribute).Attributes
+	rsAttributes := rsResp.Schema.Attributes["vm"].(rsschema.SingleNestedAttribute).Attributes
+	assert.Len(t, rsAttributes, len(dsAttributes))
+	for name, dsAttribute := range dsAttributes {
+		rsAttribute := rsAttributes[name]
+		assert.Equal(t, dsAttribute.GetMarkdownDescription(), rsAttribute.GetMarkdownDescription(), name)
+		assert.Equal(t, dsAttribute.IsComputed(), rsAttribute.IsComputed(), name)
+		assert.Equal(t, dsAttribute.IsOptional(), rsAttribute.IsOptional(), name)
+	}
+}
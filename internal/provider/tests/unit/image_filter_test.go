@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterImages(t *testing.T) {
+	images := []map[string]any{
+		{"name": "ubuntu-24.04.iso"},
+		{"name": "alpine-3.20.iso"},
+		{"name": "ubuntu-22.04.iso"},
+		{"name": "virtio-win.iso"},
+	}
+
+	names := func(filtered []map[string]any) []string {
+		result := []string{}
+		for _, image := range filtered {
+			result = append(result, image["name"].(string))
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"alpine-3.20.iso", "ubuntu-22.04.iso", "ubuntu-24.04.iso", "virtio-win.iso"}, names(utils.FilterImages(images, utils.ImageFilter{})))
+	assert.Equal(t, []string{"virtio-win.iso"}, names(utils.FilterImages(images, utils.ImageFilter{Name: "virtio-win.iso"})))
+	assert.Equal(t, []string{"ubuntu-22.04.iso", "ubuntu-24.04.iso"}, names(utils.FilterImages(images, utils.ImageFilter{NamePrefix: "ubuntu-"})))
+	assert.Equal(t, []string{"ubuntu-24.04.iso"}, names(utils.FilterImages(images, utils.ImageFilter{NamePrefix: "ubuntu-", NameRegex: "24\\.04"})))
+	assert.Empty(t, utils.FilterImages(images, utils.ImageFilter{Name: "missing.iso"}))
+}
+
+func TestValidateImageFilter(t *testing.T) {
+	assert.Nil(t, utils.ValidateImageFilter(&utils.ImageFilter{NameRegex: "^ubuntu-.*\\.iso$"}))
+	assert.NotNil(t, utils.ValidateImageFilter(&utils.ImageFilter{NameRegex: "ubuntu-[0-9"}))
+}
+
+func TestGetImageUsage(t *testing.T) {
+	vms := []map[string]any{
+		{"uuid": "vm-b", "blockDevs": []any{
+			map[string]any{"type": "IDE_CDROM", "path": "scribe/iso-1"},
+		}},
+		{"uuid": "vm-a", "blockDevs": []any{
+			map[string]any{"type": "VIRTIO_DISK", "path": "scribe/disk-1"},
+			map[string]any{"type": "IDE_CDROM", "path": "scribe/iso-1"},
+			map[string]any{"type": "IDE_CDROM", "path": "scribe/iso-1"},
+		}},
+		{"uuid": "vm-c", "blockDevs": []any{
+			map[string]any{"type": "IDE_CDROM", "path": ""},
+		}},
+	}
+
+	assert.Equal(t, []string{"vm-a", "vm-b"}, utils.GetImageUsage(vms, "scribe/iso-1"))
+	assert.Equal(t, []string{}, utils.GetImageUsage(vms, "scribe/iso-2"))
+	assert.Equal(t, []string{}, utils.GetImageUsage(vms, ""))
+}
@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectImageFormatQcow2(t *testing.T) {
+	data := make([]byte, 512)
+	copy(data, []byte{'Q', 'F', 'I', 0xfb})
+	binary.BigEndian.PutUint32(data[4:8], 3)
+	binary.BigEndian.PutUint64(data[24:32], 10737418240)
+
+	info, d := utils.DetectImageFormat(data)
+	assert.Nil(t, d)
+	assert.Equal(t, utils.ImageInfo{Format: "qcow2", VirtualSize: 10737418240}, info)
+
+	binary.BigEndian.PutUint32(data[4:8], 1)
+	_, d = utils.DetectImageFormat(data)
+	assert.NotNil(t, d)
+
+	_, d = utils.DetectImageFormat(data[:16])
+	assert.NotNil(t, d)
+}
+
+func TestDetectImageFormatVMDK(t *testing.T) {
+	data := make([]byte, 512)
+	copy(data, "KDMV")
+	binary.LittleEndian.PutUint64(data[12:20], 2048)
+
+	info, d := utils.DetectImageFormat(data)
+	assert.Nil(t, d)
+	assert.Equal(t, utils.ImageInfo{Format: "vmdk", VirtualSize: 2048 * 512}, info)
+
+	_, d = utils.DetectImageFormat([]byte("# Disk DescriptorFile\nversion=1\n"))
+	assert.NotNil(t, d)
+}
+
+func TestDetectImageFormatVHD(t *testing.T) {
+	footer := make([]byte, 512)
+	copy(footer, "conectix")
+	binary.BigEndian.PutUint64(footer[48:56], 1073741824)
+
+	// Fixed VHD - raw data followed by footer
+	fixed := append(make([]byte, 4096), footer...)
+	info, d := utils.DetectImageFormat(fixed)
+	assert.Nil(t, d)
+	assert.Equal(t, utils.ImageInfo{Format: "vhd", VirtualSize: 1073741824}, info)
+
+	// Dynamic VHD - footer copy at the start
+	dynamic := append(append([]byte{}, footer...), make([]byte, 4096)...)
+	info, d = utils.DetectImageFormat(dynamic)
+	assert.Nil(t, d)
+	assert.Equal(t, "vhd", info.Format)
+
+	info, d = utils.DetectImageFormat(append([]byte("vhdxfile"), make([]byte, 504)...))
+	assert.Nil(t, d)
+	assert.Equal(t, utils.ImageInfo{Format: "vhdx", VirtualSize: -1}, info)
+}
+
+func TestDetectImageFormatRawAndUnsupported(t *testing.T) {
+	info, d := utils.DetectImageFormat(make([]byte, 4096))
+	assert.Nil(t, d)
+	assert.Equal(t, utils.ImageInfo{Format: "raw", VirtualSize: 4096}, info)
+
+	_, d = utils.DetectImageFormat([]byte{})
+	assert.NotNil(t, d)
+
+	_, d = utils.DetectImageFormat([]byte{0x1f, 0x8b, 0x08, 0x00})
+	assert.NotNil(t, d)
+
+	iso := make([]byte, 0x9000)
+	copy(iso[0x8001:], "CD001")
+	_, d = utils.DetectImageFormat(iso)
+	assert.NotNil(t, d)
+}
+
+func TestValidateImageExtension(t *testing.T) {
+	assert.Nil(t, utils.ValidateImageExtension("disk.qcow2", "qcow2"))
+	assert.Nil(t, utils.ValidateImageExtension("jammy-server-cloudimg-amd64.img", "qcow2"))
+	assert.Nil(t, utils.ValidateImageExtension("disk.IMG", "raw"))
+	assert.Nil(t, utils.ValidateImageExtension("disk-without-extension", "vmdk"))
+	assert.NotNil(t, utils.ValidateImageExtension("disk.vmdk", "qcow2"))
+	assert.NotNil(t, utils.ValidateImageExtension("disk.vhd", "vhdx"))
+}
+
+func TestReadImageHeader(t *testing.T) {
+	footer := make([]byte, 512)
+	copy(footer, "conectix")
+	binary.BigEndian.PutUint64(footer[48:56], 1073741824)
+	// Fixed VHD larger than the header
+	image := append(make([]byte, 2*utils.IMAGE_HEADER_SIZE), footer...)
+
+	var bytesSent atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/no-range" {
+			r.Header.Del("Range")
+		}
+		counter := &countingWriter{ResponseWriter: w, sent: &bytesSent}
+		http.ServeContent(counter, r, "disk.vhd", time.Time{}, bytes.NewReader(image))
+	}))
+	defer server.Close()
+
+	header, imageFooter, size, ok, err := utils.ReadImageHeader(server.URL + "/disk.vhd")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(len(image)), size)
+	assert.Len(t, header, utils.IMAGE_HEADER_SIZE)
+	assert.Less(t, bytesSent.Load(), int64(len(image)))
+	info, d := utils.DetectImageFormatFromHeader(header, imageFooter, size)
+	assert.Nil(t, d)
+	assert.Equal(t, utils.ImageInfo{Format: "vhd", VirtualSize: 1073741824}, info)
+
+	_, _, _, ok, err = utils.ReadImageHeader(server.URL + "/no-range")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	localPath := filepath.Join(t.TempDir(), "disk.vhd")
+	assert.NoError(t, os.WriteFile(localPath, image, 0o600))
+	header, imageFooter, size, ok, err = utils.ReadImageHeader("file:///" + localPath)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	info, d = utils.DetectImageFormatFromHeader(header, imageFooter, size)
+	assert.Nil(t, d)
+	assert.Equal(t, "vhd", info.Format)
+
+	// Small raw image
+	assert.NoError(t, os.WriteFile(localPath, []byte("raw"), 0o600))
+	header, imageFooter, size, ok, err = utils.ReadImageHeader("file:///" + localPath)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	info, d = utils.DetectImageFormatFromHeader(header, imageFooter, size)
+	assert.Nil(t, d)
+	assert.Equal(t, utils.ImageInfo{Format: "raw", VirtualSize: 3}, info)
+}
+
+type countingWriter struct {
+	http.ResponseWriter
+	sent *atomic.Int64
+}
+
+func (w *countingWriter) Write(data []byte) (int, error) {
+	w.sent.Add(int64(len(data)))
+	return w.ResponseWriter.Write(data)
+}
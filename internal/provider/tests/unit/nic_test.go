@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNicType(t *testing.T) {
+	assert.Nil(t, utils.ValidateNicType("VIRTIO"))
+	assert.Nil(t, utils.ValidateNicType("INTEL_E1000"))
+	assert.Nil(t, utils.ValidateNicType("RTL8139"))
+	assert.NotNil(t, utils.ValidateNicType("virtio"))
+	assert.NotNil(t, utils.ValidateNicType("E1000"))
+}
+
+func TestValidateMacAddress(t *testing.T) {
+	assert.Nil(t, utils.ValidateMacAddress("52:54:00:AB:CD:EF"))
+	assert.Nil(t, utils.ValidateMacAddress("52:54:00:ab:cd:ef"))
+	assert.Nil(t, utils.ValidateMacAddress("52-54-00-ab-cd-ef"))
+	assert.NotNil(t, utils.ValidateMacAddress(""))
+	assert.NotNil(t, utils.ValidateMacAddress("52:54:00:AB:CD"))
+	assert.NotNil(t, utils.ValidateMacAddress("52:54:00:AB:CD:EG"))
+	assert.NotNil(t, utils.ValidateMacAddress("5254.00ab.cdef"))
+	assert.NotNil(t, utils.ValidateMacAddress("00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01"))
+	// multicast
+	assert.NotNil(t, utils.ValidateMacAddress("01:00:5E:00:00:01"))
+}
+
+func TestNormalizeMacAddress(t *testing.T) {
+	assert.Equal(t, "52:54:00:AB:CD:EF", utils.NormalizeMacAddress("52:54:00:ab:cd:ef"))
+	assert.Equal(t, "52:54:00:AB:CD:EF", utils.NormalizeMacAddress("52-54-00-AB-cd-EF"))
+	assert.Equal(t, "not-a-mac", utils.NormalizeMacAddress("not-a-mac"))
+	assert.True(t, utils.MacAddressesEqual("52-54-00-ab-cd-ef", "52:54:00:AB:CD:EF"))
+	assert.False(t, utils.MacAddressesEqual("52:54:00:AB:CD:EF", "52:54:00:AB:CD:EE"))
+}
+
+func TestFindNicForImport(t *testing.T) {
+	nics := []map[string]any{
+		{"uuid": "nic-1", "slot": 1.0, "type": "INTEL_E1000", "vlan": 10.0, "macAddress": "52:54:00:00:00:02"},
+		{"uuid": "nic-0", "slot": 0.0, "type": "VIRTIO", "vlan": 0.0, "macAddress": "52:54:00:00:00:01"},
+	}
+
+	nic, d := utils.FindNicForImport(nics, "52-54-00-00-00-02")
+	assert.Nil(t, d)
+	assert.Equal(t, "nic-1", nic["uuid"])
+
+	nic, d = utils.FindNicForImport(nics, "0")
+	assert.Nil(t, d)
+	assert.Equal(t, "nic-0", nic["uuid"])
+	nic, d = utils.FindNicForImport(nics, "1")
+	assert.Nil(t, d)
+	assert.Equal(t, "nic-1", nic["uuid"])
+
+	nic, d = utils.FindNicForImport(nics, "INTEL_E1000:10")
+	assert.Nil(t, d)
+	assert.Equal(t, "nic-1", nic["uuid"])
+
+	_, d = utils.FindNicForImport(nics, "52:54:00:00:00:03")
+	assert.NotNil(t, d)
+	_, d = utils.FindNicForImport(nics, "2")
+	assert.NotNil(t, d)
+	_, d = utils.FindNicForImport(nics, "-1")
+	assert.NotNil(t, d)
+	_, d = utils.FindNicForImport(nics, "VIRTIO:10")
+	assert.NotNil(t, d)
+	_, d = utils.FindNicForImport(nics, "first")
+	assert.NotNil(t, d)
+}
+
+func TestGetNicConnected(t *testing.T) {
+	assert.True(t, utils.GetNicConnected(map[string]any{}))
+	assert.True(t, utils.GetNicConnected(map[string]any{"connected": true}))
+	assert.False(t, utils.GetNicConnected(map[string]any{"connected": false}))
+	assert.Equal(t, []string{}, utils.GetNicIpv4Addresses(map[string]any{}))
+	assert.Equal(t, []string{"10.0.0.5"}, utils.GetNicIpv4Addresses(map[string]any{"ipv4Addresses": []any{"10.0.0.5"}}))
+}
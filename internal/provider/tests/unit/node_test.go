@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterNodes(t *testing.T) {
+	nodes := []map[string]any{
+		{"uuid": "node-a", "networkStatus": "ONLINE", "virtualizationOnline": true, "allowRunningVMs": true, "currentDisposition": "IN", "desiredDisposition": "IN"},
+		{"uuid": "node-b", "networkStatus": "ONLINE", "virtualizationOnline": true, "allowRunningVMs": true, "currentDisposition": "IN", "desiredDisposition": "OUT"},
+		{"uuid": "node-c", "networkStatus": "ONLINE", "virtualizationOnline": true, "allowRunningVMs": false},
+		{"uuid": "node-d", "networkStatus": "OFFLINE", "virtualizationOnline": true, "allowRunningVMs": true},
+		{"uuid": "node-e", "networkStatus": "ONLINE", "virtualizationOnline": false},
+		// older HC3 versions do not report all fields
+		{"uuid": "node-f", "networkStatus": "ONLINE"},
+	}
+
+	uuids := func(filtered []map[string]any) []string {
+		result := []string{}
+		for _, node := range filtered {
+			result = append(result, node["uuid"].(string))
+		}
+		return result
+	}
+	yes, no := true, false
+
+	assert.Equal(t, []string{"node-a", "node-b", "node-c", "node-d", "node-e", "node-f"}, uuids(utils.FilterNodes(nodes, utils.NodeFilter{})))
+	assert.Equal(t, []string{"node-a", "node-b", "node-c", "node-f"}, uuids(utils.FilterNodes(nodes, utils.NodeFilter{Online: &yes})))
+	assert.Equal(t, []string{"node-d", "node-e"}, uuids(utils.FilterNodes(nodes, utils.NodeFilter{Online: &no})))
+	assert.Equal(t, []string{"node-a", "node-f"}, uuids(utils.FilterNodes(nodes, utils.NodeFilter{Schedulable: &yes})))
+	assert.Equal(t, []string{"node-b", "node-c"}, uuids(utils.FilterNodes(nodes, utils.NodeFilter{Online: &yes, Schedulable: &no})))
+
+	assert.True(t, utils.IsNodeInMaintenance(nodes[1]))
+	assert.False(t, utils.IsNodeInMaintenance(nodes[5]))
+}
+
+func TestGetNodeCPU(t *testing.T) {
+	node := map[string]any{
+		"numSockets":        float64(2),
+		"numCores":          float64(16),
+		"numThreads":        float64(32),
+		"CPUhz":             float64(2400000000),
+		"supportedCPUTypes": []any{"clusterBaseline", "Skylake-Server"},
+	}
+	assert.Equal(t, utils.NodeCPU{Sockets: 2, Cores: 16, Threads: 32, Hz: 2400000000, VMTypes: []string{"clusterBaseline", "Skylake-Server"}}, utils.GetNodeCPU(node))
+	assert.Equal(t, utils.NodeCPU{VMTypes: []string{}}, utils.GetNodeCPU(map[string]any{}))
+}
+
+func TestCountVMsByNode(t *testing.T) {
+	vms := []map[string]any{
+		{"uuid": "vm-1", "nodeUUID": "node-a"},
+		{"uuid": "vm-2", "nodeUUID": "node-a"},
+		{"uuid": "vm-3", "nodeUUID": "node-b"},
+		{"uuid": "vm-4", "nodeUUID": ""},
+		{"uuid": "vm-5"},
+	}
+	assert.Equal(t, map[string]int64{"node-a": 2, "node-b": 1}, utils.CountVMsByNode(vms))
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRemoteClusterConnectionHealthy(t *testing.T) {
+	assert.True(t, utils.IsRemoteClusterConnectionHealthy(map[string]any{"connectionStatus": "CONNECTED", "replicationOK": true}))
+	assert.False(t, utils.IsRemoteClusterConnectionHealthy(map[string]any{"connectionStatus": "CONNECTED", "replicationOK": false}))
+	assert.False(t, utils.IsRemoteClusterConnectionHealthy(map[string]any{"connectionStatus": "DISCONNECTED"}))
+}
+
+func TestWaitRemoteClusterConnectionHealthy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/v1/RemoteClusterConnection/conn-healthy":
+			_, _ = w.Write([]byte(`[{"uuid": "conn-healthy", "connectionStatus": "CONNECTED", "replicationOK": true}]`))
+		case "/rest/v1/RemoteClusterConnection/conn-broken":
+			_, _ = w.Write([]byte(`[{"uuid": "conn-broken", "connectionStatus": "DISCONNECTED", "replicationOK": false}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	restClient := utils.RestClient{HttpClient: server.Client(), Host: server.URL, AuthHeader: map[string]string{}}
+
+	assert.Nil(t, utils.WaitRemoteClusterConnectionHealthy(restClient, "conn-healthy", 0, context.Background()))
+
+	d := utils.WaitRemoteClusterConnectionHealthy(restClient, "conn-broken", 0, context.Background())
+	assert.NotNil(t, d)
+	assert.Equal(t, diag.SeverityError, d.Severity())
+	assert.Contains(t, d.Detail(), "DISCONNECTED")
+}
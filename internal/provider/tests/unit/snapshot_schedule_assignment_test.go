@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectScheduleAssignmentVMs(t *testing.T) {
+	vms := []map[string]any{
+		{"uuid": "vm-c", "name": "web-1", "tags": "web,prod"},
+		{"uuid": "vm-a", "name": "db-1", "tags": "db,prod"},
+		{"uuid": "vm-b", "name": "test-1", "tags": ""},
+	}
+
+	selected, d := utils.SelectScheduleAssignmentVMs(vms, utils.ScheduleAssignmentSelector{VMUUIDs: []string{"vm-b"}})
+	assert.Nil(t, d)
+	assert.Equal(t, []string{"vm-b"}, selected)
+
+	selected, d = utils.SelectScheduleAssignmentVMs(vms, utils.ScheduleAssignmentSelector{TagsAny: []string{"prod"}})
+	assert.Nil(t, d)
+	assert.Equal(t, []string{"vm-a", "vm-c"}, selected)
+
+	selected, d = utils.SelectScheduleAssignmentVMs(vms, utils.ScheduleAssignmentSelector{VMUUIDs: []string{"vm-b"}, TagsAll: []string{"db", "prod"}})
+	assert.Nil(t, d)
+	assert.Equal(t, []string{"vm-a", "vm-b"}, selected)
+
+	_, d = utils.SelectScheduleAssignmentVMs(vms, utils.ScheduleAssignmentSelector{VMUUIDs: []string{"vm-missing"}})
+	assert.NotNil(t, d)
+}
+
+func TestGetScheduleAssignedVMs(t *testing.T) {
+	vms := []map[string]any{
+		{"uuid": "vm-b", "snapshotScheduleUUID": "sched-1"},
+		{"uuid": "vm-a", "snapshotScheduleUUID": "sched-1"},
+		{"uuid": "vm-c", "snapshotScheduleUUID": ""},
+		{"uuid": "vm-d"},
+	}
+	assert.Equal(t, []string{"vm-a", "vm-b"}, utils.GetScheduleAssignedVMs(vms, "sched-1"))
+	assert.Empty(t, utils.GetScheduleAssignedVMs(vms, "sched-2"))
+}
+
+func TestPlanScheduleAssignment(t *testing.T) {
+	desired := []string{"vm-a", "vm-b"}
+	actual := []string{"vm-b", "vm-c", "vm-d"}
+	managed := []string{"vm-c"}
+
+	toAssign, toRemove := utils.PlanScheduleAssignment(desired, actual, managed, false)
+	assert.Equal(t, []string{"vm-a"}, toAssign)
+	assert.Equal(t, []string{"vm-c"}, toRemove)
+
+	toAssign, toRemove = utils.PlanScheduleAssignment(desired, actual, managed, true)
+	assert.Equal(t, []string{"vm-a"}, toAssign)
+	assert.Equal(t, []string{"vm-c", "vm-d"}, toRemove)
+
+	toAssign, toRemove = utils.PlanScheduleAssignment(desired, desired, desired, true)
+	assert.Empty(t, toAssign)
+	assert.Empty(t, toRemove)
+}
+
+func TestTrackedScheduleAssignedVMs(t *testing.T) {
+	// vm-x was assigned outside of Terraform
+	actual := []string{"vm-a", "vm-b", "vm-x"}
+	tracked := []string{"vm-a", "vm-b", "vm-c"}
+
+	assert.Equal(t, []string{"vm-a", "vm-b"}, utils.TrackedScheduleAssignedVMs(actual, tracked, false))
+	assert.Equal(t, []string{"vm-a", "vm-b", "vm-x"}, utils.TrackedScheduleAssignedVMs(actual, tracked, true))
+	assert.Empty(t, utils.TrackedScheduleAssignedVMs(actual, []string{}, false))
+}
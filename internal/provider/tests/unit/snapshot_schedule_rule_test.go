@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRRule(t *testing.T) {
+	assert.Nil(t, utils.ValidateRRule("FREQ=MINUTELY;INTERVAL=5"))
+	assert.Nil(t, utils.ValidateRRule("FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,WE,FR"))
+	assert.Nil(t, utils.ValidateRRule("FREQ=MONTHLY;BYDAY=-1FR;BYHOUR=2;BYMINUTE=30"))
+	assert.Nil(t, utils.ValidateRRule("FREQ=DAILY;UNTIL=20301231T000000Z"))
+	assert.Nil(t, utils.ValidateRRule("RRULE:freq=daily;count=10"))
+
+	assert.NotNil(t, utils.ValidateRRule(""))
+	assert.NotNil(t, utils.ValidateRRule("INTERVAL=5"))
+	assert.NotNil(t, utils.ValidateRRule("FREQ=MINUTLY;INTERVAL=5"))
+	assert.NotNil(t, utils.ValidateRRule("FREQ=SECONDLY"))
+	assert.NotNil(t, utils.ValidateRRule("FREQ=DAILY;INTERVAL=0"))
+	assert.NotNil(t, utils.ValidateRRule("FREQ=DAILY;INTERVAL=1;INTERVAL=2"))
+	assert.NotNil(t, utils.ValidateRRule("FREQ=DAILY;BYHOUR=24"))
+	assert.NotNil(t, utils.ValidateRRule("FREQ=WEEKLY;BYDAY=MON"))
+	assert.NotNil(t, utils.ValidateRRule("FREQ=DAILY;COUNT=3;UNTIL=20301231"))
+	assert.NotNil(t, utils.ValidateRRule("FREQ=DAILY;BYEASTER=1"))
+	assert.NotNil(t, utils.ValidateRRule("FREQ=DAILY;INTERVAL"))
+}
+
+func TestRRulesEqual(t *testing.T) {
+	assert.True(t, utils.RRulesEqual("FREQ=DAILY;INTERVAL=1", "FREQ=DAILY"))
+	assert.True(t, utils.RRulesEqual("FREQ=WEEKLY;BYDAY=MO;INTERVAL=2", "interval=2;freq=weekly;byday=MO"))
+	assert.False(t, utils.RRulesEqual("FREQ=DAILY;INTERVAL=1", "FREQ=DAILY;INTERVAL=2"))
+	assert.False(t, utils.RRulesEqual("FREQ=DAILY", "FREQ=HOURLY"))
+}
+
+func TestCompileScheduleRRule(t *testing.T) {
+	testCases := []struct {
+		every    string
+		weekdays []string
+		expected string
+	}{
+		{"15m", nil, "FREQ=MINUTELY;INTERVAL=15"},
+		{"90m", nil, "FREQ=MINUTELY;INTERVAL=90"},
+		{"1h", nil, "FREQ=HOURLY;INTERVAL=1"},
+		{"6h", nil, "FREQ=HOURLY;INTERVAL=6"},
+		{"24h", nil, "FREQ=DAILY;INTERVAL=1"},
+		{"1d", nil, "FREQ=DAILY;INTERVAL=1"},
+		{"14d", nil, "FREQ=WEEKLY;INTERVAL=2"},
+		{"1w", nil, "FREQ=WEEKLY;INTERVAL=1"},
+		{"1d", []string{"MO", "TU", "WE", "TH", "FR"}, "FREQ=DAILY;INTERVAL=1;BYDAY=MO,TU,WE,TH,FR"},
+		{"", []string{"SA"}, "FREQ=WEEKLY;INTERVAL=1;BYDAY=SA"},
+	}
+	for _, tc := range testCases {
+		rrule, d := utils.CompileScheduleRRule(tc.every, tc.weekdays)
+		assert.Nil(t, d, tc.every)
+		assert.Equal(t, tc.expected, rrule, tc.every)
+		assert.Nil(t, utils.ValidateRRule(rrule))
+	}
+
+	for _, every := range []string{"", "30s", "0h", "-1h", "1.5d", "daily"} {
+		_, d := utils.CompileScheduleRRule(every, nil)
+		assert.NotNil(t, d, every)
+	}
+	_, d := utils.CompileScheduleRRule("1d", []string{"MON"})
+	assert.NotNil(t, d)
+}
+
+func TestCompileScheduleStart(t *testing.T) {
+	start, d := utils.CompileScheduleStart("02:30")
+	assert.Nil(t, d)
+	assert.Equal(t, "2010-01-01 02:30:00", start)
+
+	start, d = utils.CompileScheduleStart("")
+	assert.Nil(t, d)
+	assert.Equal(t, "2010-01-01 00:00:00", start)
+
+	for _, at := range []string{"24:00", "2:30pm", "02:30:00", "noon"} {
+		_, d = utils.CompileScheduleStart(at)
+		assert.NotNil(t, d, at)
+	}
+}
+
+func TestScheduleStartToClusterLocal(t *testing.T) {
+	assert.Nil(t, utils.ValidateScheduleStartTimestamp("2010-01-01 00:00:00"))
+	assert.Nil(t, utils.ValidateScheduleStartTimestamp("2010-01-01T00:00:00Z"))
+	assert.NotNil(t, utils.ValidateScheduleStartTimestamp("2010-01-01"))
+	assert.NotNil(t, utils.ValidateScheduleStartTimestamp("2010-01-01T00:00:00"))
+
+	assert.True(t, utils.IsScheduleStartInClusterTimezone("2010-01-01 00:00:00"))
+	assert.False(t, utils.IsScheduleStartInClusterTimezone("2010-01-01T00:00:00+02:00"))
+
+	location, err := time.LoadLocation("Europe/Ljubljana")
+	assert.Nil(t, err)
+	assert.Equal(t, "2010-01-01 01:00:00", utils.ScheduleStartToClusterLocal("2010-01-01T00:00:00Z", location))
+	assert.Equal(t, "2010-07-01 02:00:00", utils.ScheduleStartToClusterLocal("2010-07-01T00:00:00Z", location))
+	assert.Equal(t, "2010-01-01 00:00:00", utils.ScheduleStartToClusterLocal("2010-01-01 00:00:00", location))
+}
+
+func TestGetClusterLocation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/v1/TimeZone", r.URL.Path)
+		_, _ = w.Write([]byte(`[{"uuid": "timezone_guid", "timeZone": "America/New_York"}]`))
+	}))
+	defer server.Close()
+	restClient := utils.RestClient{HttpClient: server.Client(), Host: server.URL, AuthHeader: map[string]string{}}
+
+	location, d := utils.GetClusterLocation(restClient)
+	assert.Nil(t, d)
+	assert.Equal(t, "America/New_York", location.String())
+	assert.Equal(t, "2009-12-31 19:00:00", utils.ScheduleStartToClusterLocal("2010-01-01T00:00:00Z", location))
+}
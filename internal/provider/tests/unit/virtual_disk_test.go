@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSnapshotHasDisk(t *testing.T) {
+	snapshot := map[string]any{
+		"uuid": "snap-uuid",
+		"domain": map[string]any{
+			"blockDevs": []any{
+				map[string]any{"uuid": "disk-1"},
+				map[string]any{"uuid": "disk-2"},
+			},
+		},
+	}
+
+	assert.Nil(t, utils.ValidateSnapshotHasDisk(snapshot, "disk-2"))
+	assert.NotNil(t, utils.ValidateSnapshotHasDisk(snapshot, "disk-3"))
+	assert.NotNil(t, utils.ValidateSnapshotHasDisk(map[string]any{"uuid": "snap-uuid"}, "disk-1"))
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetVMCdrom(t *testing.T) {
+	vm := map[string]any{
+		"blockDevs": []any{
+			map[string]any{"uuid": "disk-uuid", "type": "VIRTIO_DISK", "slot": 0.0},
+			map[string]any{"uuid": "cdrom-2", "type": "IDE_CDROM", "slot": 2.0},
+			map[string]any{"uuid": "cdrom-1", "type": "IDE_CDROM", "slot": 1.0},
+		},
+	}
+
+	assert.Equal(t, "cdrom-1", utils.GetVMCdrom(vm, nil)["uuid"])
+
+	slot := int64(2)
+	assert.Equal(t, "cdrom-2", utils.GetVMCdrom(vm, &slot)["uuid"])
+
+	slot = 0
+	assert.Nil(t, utils.GetVMCdrom(vm, &slot))
+
+	assert.Nil(t, utils.GetVMCdrom(map[string]any{"blockDevs": []any{}}, nil))
+}
+
+func TestValidateISOReadyForInsert(t *testing.T) {
+	assert.Nil(t, utils.ValidateISOReadyForInsert(map[string]any{"uuid": "iso-uuid", "name": "a.iso", "readyForInsert": true}))
+	assert.NotNil(t, utils.ValidateISOReadyForInsert(map[string]any{"uuid": "iso-uuid", "name": "a.iso", "readyForInsert": false}))
+}
@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDiskCacheMode(t *testing.T) {
+	assert.Nil(t, utils.ValidateDiskCacheMode("WRITETHROUGH"))
+	assert.Nil(t, utils.ValidateDiskCacheMode("WRITEBACK"))
+	assert.Nil(t, utils.ValidateDiskCacheMode("NONE"))
+	assert.NotNil(t, utils.ValidateDiskCacheMode("writeback"))
+	assert.NotNil(t, utils.ValidateDiskCacheMode(""))
+}
+
+func TestValidateDiskTypeUnchanged(t *testing.T) {
+	assert.Nil(t, utils.ValidateDiskTypeUnchanged("disk-uuid", "VIRTIO_DISK", "VIRTIO_DISK"))
+	assert.NotNil(t, utils.ValidateDiskTypeUnchanged("disk-uuid", "VIRTIO_DISK", "IDE_DISK"))
+}
+
+func TestValidateOnIncompatibleChange(t *testing.T) {
+	assert.Nil(t, utils.ValidateOnIncompatibleChange("fail"))
+	assert.Nil(t, utils.ValidateOnIncompatibleChange("replace"))
+	assert.NotNil(t, utils.ValidateOnIncompatibleChange("REPLACE"))
+	assert.NotNil(t, utils.ValidateOnIncompatibleChange(""))
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFailoverSnapshot(t *testing.T) {
+	snapshots := []map[string]any{
+		{"uuid": "snap-2", "domainUUID": "replica-1", "timestamp": 2000, "label": "", "type": "AUTOMATED"},
+		{"uuid": "snap-1", "domainUUID": "replica-1", "timestamp": 1000, "label": "", "type": "AUTOMATED"},
+		{"uuid": "snap-3", "domainUUID": "replica-2", "timestamp": 3000, "label": "", "type": "AUTOMATED"},
+	}
+
+	snapshot, d := utils.GetFailoverSnapshot(snapshots, "replica-1", "")
+	assert.Nil(t, d)
+	assert.Equal(t, "snap-2", snapshot["uuid"])
+
+	snapshot, d = utils.GetFailoverSnapshot(snapshots, "replica-1", "snap-1")
+	assert.Nil(t, d)
+	assert.Equal(t, "snap-1", snapshot["uuid"])
+
+	_, d = utils.GetFailoverSnapshot(snapshots, "replica-1", "snap-3")
+	assert.NotNil(t, d)
+	_, d = utils.GetFailoverSnapshot(snapshots, "replica-3", "")
+	assert.NotNil(t, d)
+}
+
+func TestValidateNicVlanMap(t *testing.T) {
+	assert.Nil(t, utils.ValidateNicVlanMap(map[string]int64{}))
+	assert.Nil(t, utils.ValidateNicVlanMap(map[string]int64{"0": 100, "10": 110}))
+	assert.NotNil(t, utils.ValidateNicVlanMap(map[string]int64{"ten": 110}))
+	assert.NotNil(t, utils.ValidateNicVlanMap(map[string]int64{"10": 5000}))
+	assert.NotNil(t, utils.ValidateNicVlanMap(map[string]int64{"-1": 10}))
+}
+
+func TestPlanNicVlanRemap(t *testing.T) {
+	nics := []map[string]any{
+		{"uuid": "nic-1", "vlan": 10},
+		{"uuid": "nic-2", "vlan": 20},
+		{"uuid": "nic-3", "vlan": 0},
+		{"uuid": "nic-4", "vlan": 30},
+	}
+	assert.Equal(t,
+		map[string]int64{"nic-1": 110, "nic-3": 100},
+		utils.PlanNicVlanRemap(nics, map[string]int64{"10": 110, "0": 100, "30": 30}),
+	)
+	assert.Empty(t, utils.PlanNicVlanRemap(nics, map[string]int64{}))
+}
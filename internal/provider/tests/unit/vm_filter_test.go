@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterVMs(t *testing.T) {
+	vms := []map[string]any{
+		{"name": "app-1", "description": "web frontend", "tags": "web,prod", "state": "RUNNING", "nodeUUID": "node-a"},
+		{"name": "app-2", "description": "database", "tags": "db,prod", "state": "SHUTOFF", "nodeUUID": "node-b"},
+		{"name": "template-ubuntu", "description": "", "tags": "", "state": "SHUTOFF", "nodeUUID": ""},
+	}
+
+	names := func(filtered []map[string]any) []string {
+		result := []string{}
+		for _, vm := range filtered {
+			result = append(result, vm["name"].(string))
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"app-1", "app-2", "template-ubuntu"}, names(utils.FilterVMs(vms, utils.VMFilter{})))
+	assert.Equal(t, []string{"app-2"}, names(utils.FilterVMs(vms, utils.VMFilter{Name: "app-2"})))
+	assert.Equal(t, []string{"app-1", "app-2"}, names(utils.FilterVMs(vms, utils.VMFilter{NamePrefix: "app-"})))
+	assert.Equal(t, []string{"template-ubuntu"}, names(utils.FilterVMs(vms, utils.VMFilter{NameRegex: "^template-"})))
+	assert.Equal(t, []string{"app-1"}, names(utils.FilterVMs(vms, utils.VMFilter{DescriptionContains: "web"})))
+	assert.Equal(t, []string{"app-1", "app-2"}, names(utils.FilterVMs(vms, utils.VMFilter{TagsAny: []string{"web", "db"}})))
+	assert.Equal(t, []string{"app-2"}, names(utils.FilterVMs(vms, utils.VMFilter{TagsAll: []string{"db", "prod"}})))
+	assert.Equal(t, []string{"app-2", "template-ubuntu"}, names(utils.FilterVMs(vms, utils.VMFilter{PowerState: "SHUTOFF"})))
+	assert.Equal(t, []string{"app-2", "template-ubuntu"}, names(utils.FilterVMs(vms, utils.VMFilter{PowerState: "stopped"})))
+	assert.Equal(t, []string{"app-1"}, names(utils.FilterVMs(vms, utils.VMFilter{NodeUUID: "node-a"})))
+	assert.Empty(t, utils.FilterVMs(vms, utils.VMFilter{TagsAll: []string{"web", "db"}}))
+}
+
+func TestValidateVMFilter(t *testing.T) {
+	assert.Nil(t, utils.ValidateVMFilter(&utils.VMFilter{NameRegex: "^app-[0-9]+$"}))
+	assert.NotNil(t, utils.ValidateVMFilter(&utils.VMFilter{NameRegex: "app-[0-9"}))
+	assert.NotNil(t, utils.ValidateVMFilter(&utils.VMFilter{PowerState: "HIBERNATED"}))
+}
+
+func TestTierVMs(t *testing.T) {
+	vms := []map[string]any{
+		{"uuid": "uuid-c", "name": "web"},
+		{"uuid": "uuid-a", "name": "app"},
+		{"uuid": "uuid-b", "name": "db"},
+	}
+
+	uuids := func(tiers [][]map[string]any) [][]string {
+		result := [][]string{}
+		for _, tier := range tiers {
+			tierUUIDs := []string{}
+			for _, vm := range tier {
+				tierUUIDs = append(tierUUIDs, vm["uuid"].(string))
+			}
+			result = append(result, tierUUIDs)
+		}
+		return result
+	}
+
+	assert.Equal(t, [][]string{{"uuid-a", "uuid-b", "uuid-c"}}, uuids(utils.TierVMs(vms, nil)))
+	assert.Equal(t, [][]string{{"uuid-b"}, {"uuid-a", "uuid-c"}}, uuids(utils.TierVMs(vms, [][]string{{"uuid-b"}})))
+	assert.Equal(t, [][]string{{"uuid-b", "uuid-c"}, {"uuid-a"}}, uuids(utils.TierVMs(vms, [][]string{{"uuid-c", "uuid-b"}, {"uuid-a"}})))
+	assert.Equal(t, [][]string{{"uuid-c"}, {"uuid-a", "uuid-b"}}, uuids(utils.TierVMs(vms, [][]string{{"missing"}, {"uuid-c"}, {"uuid-c", "uuid-a", "uuid-b"}})))
+	assert.Equal(t, "uuid-c", vms[0]["uuid"])
+}
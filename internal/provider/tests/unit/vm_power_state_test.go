@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePowerState(t *testing.T) {
+	for _, state := range utils.ALL_POWER_STATES {
+		normalized, ok := utils.NormalizePowerState(state)
+		assert.True(t, ok)
+		assert.Equal(t, state, normalized)
+	}
+
+	cases := map[string]string{
+		"running": "RUNNING",
+		"started": "RUNNING",
+		"stopped": "SHUTOFF",
+		"stop":    "SHUTOFF",
+		// legacy shutdown is the desired state, not the transitional SHUTDOWN state
+		"shutdown": "SHUTOFF",
+		"SHUTDOWN": "SHUTDOWN",
+		"crashed":  "CRASHED",
+		"Blocked":  "BLOCKED",
+	}
+	for state, expected := range cases {
+		normalized, ok := utils.NormalizePowerState(state)
+		assert.True(t, ok, state)
+		assert.Equal(t, expected, normalized, state)
+	}
+
+	_, ok := utils.NormalizePowerState("HIBERNATED")
+	assert.False(t, ok)
+}
+
+func TestPowerStateFromHypercore(t *testing.T) {
+	assert.Equal(t, "SHUTOFF", utils.PowerStateFromHypercore("SHUTOFF"))
+	assert.Equal(t, "CRASHED", utils.PowerStateFromHypercore("CRASHED"))
+	assert.Equal(t, "", utils.PowerStateFromHypercore(nil))
+	assert.Equal(t, "SOMETHING_NEW", utils.PowerStateFromHypercore("SOMETHING_NEW"))
+}
+
+func TestValidatePowerState(t *testing.T) {
+	assert.Nil(t, utils.ValidatePowerState("RUNNING"))
+	assert.Nil(t, utils.ValidatePowerState("PAUSED"))
+	assert.Nil(t, utils.ValidatePowerState("SHUTOFF"))
+	assert.NotNil(t, utils.ValidatePowerState("CRASHED"))
+
+	d := utils.ValidatePowerState("stopped")
+	assert.NotNil(t, d)
+	assert.Contains(t, d.Detail(), "Use 'SHUTOFF' instead.")
+
+	assert.Nil(t, utils.ValidateObservedPowerState("BLOCKED"))
+	assert.Nil(t, utils.ValidateObservedPowerState("stopped"))
+	assert.NotNil(t, utils.ValidateObservedPowerState("HIBERNATED"))
+}
+
+func TestGetNeededActionsForTransition(t *testing.T) {
+	assert.Equal(t, []string{}, utils.GetNeededActionsForTransition("RUNNING", "RUNNING", false))
+	assert.Equal(t, []string{"START"}, utils.GetNeededActionsForTransition("SHUTOFF", "RUNNING", false))
+	assert.Equal(t, []string{"START"}, utils.GetNeededActionsForTransition("PAUSED", "RUNNING", false))
+	assert.Equal(t, []string{"START"}, utils.GetNeededActionsForTransition("CRASHED", "RUNNING", false))
+	assert.Equal(t, []string{"SHUTDOWN"}, utils.GetNeededActionsForTransition("RUNNING", "SHUTOFF", false))
+	assert.Equal(t, []string{"STOP"}, utils.GetNeededActionsForTransition("RUNNING", "SHUTOFF", true))
+	assert.Equal(t, []string{"START", "SHUTDOWN"}, utils.GetNeededActionsForTransition("PAUSED", "SHUTOFF", false))
+	assert.Equal(t, []string{"STOP"}, utils.GetNeededActionsForTransition("CRASHED", "SHUTOFF", false))
+	assert.Equal(t, []string{"PAUSE"}, utils.GetNeededActionsForTransition("RUNNING", "PAUSED", false))
+	assert.Equal(t, []string{"START", "PAUSE"}, utils.GetNeededActionsForTransition("SHUTOFF", "PAUSED", false))
+}
+
+func TestValidateRestartMethod(t *testing.T) {
+	assert.Nil(t, utils.ValidateRestartMethod("REBOOT"))
+	assert.Nil(t, utils.ValidateRestartMethod("RESET"))
+	assert.NotNil(t, utils.ValidateRestartMethod("reboot"))
+}
+
+func TestModifyVMsPowerStateTiers(t *testing.T) {
+	var mu sync.Mutex
+	states := map[string]string{"vm-1": "SHUTOFF", "vm-2": "SHUTOFF", "vm-3": "RUNNING", "vm-4": "SHUTOFF"}
+	requests := [][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == "POST" {
+			assert.Equal(t, "/rest/v1/VirDomain/action", r.URL.Path)
+			var payload []map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			request := []string{}
+			for _, action := range payload {
+				assert.Equal(t, "START", action["actionType"])
+				vmUUID := action["virDomainUUID"].(string)
+				request = append(request, vmUUID)
+				states[vmUUID] = "RUNNING"
+			}
+			requests = append(requests, request)
+			_, _ = w.Write([]byte(`{"taskTag": "", "createdUUID": ""}`))
+			return
+		}
+		vmUUID := strings.TrimPrefix(r.URL.Path, "/rest/v1/VirDomain/")
+		_, _ = fmt.Fprintf(w, `[{"uuid": "%s", "state": "%s"}]`, vmUUID, states[vmUUID])
+	}))
+	defer server.Close()
+	restClient := utils.RestClient{HttpClient: server.Client(), Host: server.URL, AuthHeader: map[string]string{}}
+
+	d := utils.ModifyVMsPowerState(
+		restClient,
+		[][]string{{"vm-1"}, {"vm-2", "vm-3", "vm-4"}},
+		utils.POWER_STATE_RUNNING,
+		false,
+		0,
+		0,
+		context.Background(),
+	)
+	assert.Nil(t, d)
+	// Each tier is one batch, and VMs already in the desired state are skipped.
+	assert.Equal(t, [][]string{{"vm-1"}, {"vm-2", "vm-4"}}, requests)
+
+	requests = [][]string{}
+	for vmUUID := range states {
+		states[vmUUID] = "SHUTOFF"
+	}
+	d = utils.ModifyVMsPowerState(
+		restClient,
+		[][]string{{"vm-1"}, {"vm-2", "vm-3", "vm-4"}},
+		utils.POWER_STATE_RUNNING,
+		false,
+		2,
+		0,
+		context.Background(),
+	)
+	assert.Nil(t, d)
+	assert.Equal(t, [][]string{{"vm-1"}, {"vm-2", "vm-3"}, {"vm-4"}}, requests)
+}
+
+func TestModifyVMsPowerStateRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error": "too many requests"}`))
+			return
+		}
+		vmUUID := strings.TrimPrefix(r.URL.Path, "/rest/v1/VirDomain/")
+		_, _ = fmt.Fprintf(w, `[{"uuid": "%s", "state": "SHUTOFF"}]`, vmUUID)
+	}))
+	defer server.Close()
+	restClient := utils.RestClient{HttpClient: server.Client(), Host: server.URL, AuthHeader: map[string]string{}}
+
+	d := utils.ModifyVMsPowerState(restClient, [][]string{{"vm-1", "vm-2"}}, utils.POWER_STATE_RUNNING, false, 0, 0, context.Background())
+	assert.NotNil(t, d)
+	assert.Equal(t, diag.SeverityError, d.Severity())
+}
+
+func TestRestartVMSendsRestartAction(t *testing.T) {
+	actionTypes := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			var payload []map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			actionTypes = append(actionTypes, payload[0]["actionType"].(string))
+			_, _ = w.Write([]byte(`{"taskTag": "", "createdUUID": ""}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"uuid": "vm-1", "state": "RUNNING"}]`))
+	}))
+	defer server.Close()
+	restClient := utils.RestClient{HttpClient: server.Client(), Host: server.URL, AuthHeader: map[string]string{}}
+
+	assert.Nil(t, utils.RestartVM(restClient, "vm-1", "REBOOT", 10, context.Background()))
+	assert.Nil(t, utils.RestartVM(restClient, "vm-1", "RESET", 10, context.Background()))
+	assert.Equal(t, []string{"REBOOT", "RESET"}, actionTypes)
+}
+
+func TestVMChangedPowerState(t *testing.T) {
+	changedPowerState := func(powerState string, hc3State string) bool {
+		vm := utils.GetVMStruct("vm", "", "", "", false, nil, nil, nil, nil, "", &powerState, false, "", "")
+		_, changedParams := vm.GetChangedParams(context.Background(), map[string]any{"state": hc3State, "snapshotScheduleUUID": "", "affinityStrategy": map[string]any{}})
+		return changedParams["powerState"]
+	}
+
+	assert.False(t, changedPowerState("stopped", "SHUTOFF"))
+	assert.False(t, changedPowerState("SHUTOFF", "SHUTOFF"))
+	assert.True(t, changedPowerState("start", "SHUTOFF"))
+	assert.False(t, changedPowerState("started", "RUNNING"))
+	assert.False(t, changedPowerState("shutdown", "SHUTOFF"))
+	assert.True(t, changedPowerState("reboot", "RUNNING"))
+	assert.True(t, changedPowerState("RESET", "RUNNING"))
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuestReadiness(t *testing.T) {
+	vlan10 := int64(10)
+	vm := map[string]any{
+		"guestAgentState": "AVAILABLE",
+		"netDevs": []any{
+			map[string]any{"uuid": "nic-0", "macAddress": "52:54:00:00:00:01", "vlan": 0.0, "ipv4Addresses": []any{"192.168.1.5"}},
+			map[string]any{"uuid": "nic-1", "macAddress": "52:54:00:00:00:02", "vlan": 10.0, "ipv4Addresses": []any{"10.0.0.7", "10.0.1.7"}},
+			map[string]any{"uuid": "nic-2", "macAddress": "52:54:00:00:00:03", "vlan": 10.0, "ipv4Addresses": []any{"10.0.2.7"}},
+		},
+	}
+
+	assert.Equal(t, []string{"192.168.1.5", "10.0.0.7", "10.0.1.7", "10.0.2.7"}, utils.GetVMIpv4Addresses(vm, utils.GuestNic{}))
+	assert.Equal(t, []string{"10.0.0.7", "10.0.1.7", "10.0.2.7"}, utils.GetVMIpv4Addresses(vm, utils.GuestNic{Vlan: &vlan10}))
+	assert.Equal(t, []string{"10.0.2.7"}, utils.GetVMIpv4Addresses(vm, utils.GuestNic{UUID: "nic-2"}))
+	assert.Equal(t, []string{"10.0.0.7", "10.0.1.7"}, utils.GetVMIpv4Addresses(vm, utils.GuestNic{MacAddress: "52-54-00-00-00-02"}))
+	assert.Empty(t, utils.GetVMIpv4Addresses(vm, utils.GuestNic{UUID: "nic-2", Vlan: new(int64)}))
+	assert.Equal(t, "192.168.1.5", utils.GetVMPrimaryIpv4(vm, utils.GuestReadiness{}))
+	assert.Equal(t, "10.0.1.7", utils.GetVMPrimaryIpv4(vm, utils.GuestReadiness{Ipv4CIDR: "10.0.1.0/24"}))
+
+	ready, _ := utils.GuestReadiness{GuestAgent: true, MinIpv4Addresses: 4}.IsReady(vm)
+	assert.True(t, ready)
+	ready, _ = utils.GuestReadiness{MinIpv4Addresses: 5}.IsReady(vm)
+	assert.False(t, ready)
+	ready, _ = utils.GuestReadiness{Nic: utils.GuestNic{Vlan: &vlan10}, MinIpv4Addresses: 3}.IsReady(vm)
+	assert.True(t, ready)
+	ready, _ = utils.GuestReadiness{Nic: utils.GuestNic{UUID: "nic-2"}, MinIpv4Addresses: 2}.IsReady(vm)
+	assert.False(t, ready)
+	ready, _ = utils.GuestReadiness{Nic: utils.GuestNic{MacAddress: "52:54:00:00:00:03"}}.IsReady(vm)
+	assert.True(t, ready)
+	ready, _ = utils.GuestReadiness{Ipv4CIDR: "172.16.0.0/12"}.IsReady(vm)
+	assert.False(t, ready)
+
+	vm["guestAgentState"] = "UNAVAILABLE"
+	ready, reason := utils.GuestReadiness{GuestAgent: true}.IsReady(vm)
+	assert.False(t, ready)
+	assert.Contains(t, reason, "UNAVAILABLE")
+}
+
+func TestValidateGuestReadiness(t *testing.T) {
+	assert.Nil(t, utils.ValidateGuestReadiness(utils.GuestReadiness{Ipv4CIDR: "10.0.0.0/24"}))
+	assert.NotNil(t, utils.ValidateGuestReadiness(utils.GuestReadiness{Ipv4CIDR: "10.0.0.0/33"}))
+	assert.NotNil(t, utils.ValidateGuestReadiness(utils.GuestReadiness{Ipv4CIDR: "fd00::/64"}))
+	assert.NotNil(t, utils.ValidateGuestReadiness(utils.GuestReadiness{MinIpv4Addresses: -1}))
+	assert.NotNil(t, utils.ValidateGuestReadiness(utils.GuestReadiness{Nic: utils.GuestNic{MacAddress: "not-a-mac"}}))
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReplicationLastSync(t *testing.T) {
+	snapshots := []map[string]any{
+		{"uuid": "snap-3", "domainUUID": "vm-1", "timestamp": 3000, "replication": true},
+		{"uuid": "snap-2", "domainUUID": "vm-1", "timestamp": 2000, "replication": true},
+		{"uuid": "snap-4", "domainUUID": "vm-1", "timestamp": 4000, "replication": false},
+		{"uuid": "snap-5", "domainUUID": "vm-2", "timestamp": 5000, "replication": true},
+		// taken before the replication was set up
+		{"uuid": "snap-0", "domainUUID": "vm-1", "timestamp": 500, "replication": true},
+	}
+	since := time.Unix(1000, 0)
+
+	replication := map[string]any{"sourceDomainUUID": "vm-1", "enable": true}
+	lastSync, ok := utils.GetReplicationLastSync(replication, snapshots, since)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3000), lastSync.Unix())
+
+	// The newest snapshot is still being transferred
+	replication["progress"] = map[string]any{"percentComplete": 40}
+	lastSync, ok = utils.GetReplicationLastSync(replication, snapshots, since)
+	assert.True(t, ok)
+	assert.Equal(t, int64(2000), lastSync.Unix())
+
+	// Only the first snapshot after since exists, and it is being transferred
+	_, ok = utils.GetReplicationLastSync(replication, snapshots[1:2], since)
+	assert.False(t, ok)
+	_, ok = utils.GetReplicationLastSync(replication, []map[string]any{}, since)
+	assert.False(t, ok)
+
+	// Old snapshots are never counted as replicated
+	delete(replication, "progress")
+	_, ok = utils.GetReplicationLastSync(replication, snapshots[4:], since)
+	assert.False(t, ok)
+	_, ok = utils.GetReplicationLastSync(replication, snapshots, time.Unix(3500, 0))
+	assert.False(t, ok)
+}
+
+func TestGetReplicationState(t *testing.T) {
+	snapshots := []map[string]any{
+		{"uuid": "snap-1", "domainUUID": "vm-1", "timestamp": 1000, "replication": true},
+	}
+	since := time.Unix(1000, 0)
+
+	healthy := &map[string]any{"uuid": "conn-1", "replicationOK": true, "connectionStatus": "CONNECTED"}
+	broken := &map[string]any{"uuid": "conn-1", "replicationOK": false, "connectionStatus": "DISCONNECTED"}
+
+	assert.Equal(t, utils.REPLICATION_STATE_DISABLED, utils.GetReplicationState(map[string]any{"sourceDomainUUID": "vm-1", "enable": false}, healthy, snapshots, since))
+	assert.Equal(t, utils.REPLICATION_STATE_ACTIVE, utils.GetReplicationState(map[string]any{"sourceDomainUUID": "vm-1", "enable": true}, healthy, snapshots, since))
+	assert.Equal(t, utils.REPLICATION_STATE_ACTIVE, utils.GetReplicationState(
+		map[string]any{"sourceDomainUUID": "vm-1", "enable": true, "progress": map[string]any{"percentComplete": 100}}, healthy, snapshots, since,
+	))
+	assert.Equal(t, utils.REPLICATION_STATE_SYNCING, utils.GetReplicationState(
+		map[string]any{"sourceDomainUUID": "vm-1", "enable": true, "progress": map[string]any{"percentComplete": 5}}, healthy, snapshots, since,
+	))
+	assert.Equal(t, utils.REPLICATION_STATE_SYNCING, utils.GetReplicationState(map[string]any{"sourceDomainUUID": "vm-1", "enable": true}, healthy, []map[string]any{}, since))
+	assert.Equal(t, utils.REPLICATION_STATE_SYNCING, utils.GetReplicationState(map[string]any{"sourceDomainUUID": "vm-1", "enable": true}, healthy, snapshots, time.Unix(2000, 0)))
+
+	// Local snapshots do not prove the target got them, if the connection is broken or missing.
+	assert.Equal(t, utils.REPLICATION_STATE_ERROR, utils.GetReplicationState(map[string]any{"sourceDomainUUID": "vm-1", "enable": true}, broken, snapshots, since))
+	assert.Equal(t, utils.REPLICATION_STATE_UNKNOWN, utils.GetReplicationState(map[string]any{"sourceDomainUUID": "vm-1", "enable": true}, nil, snapshots, since))
+	assert.Equal(t, utils.REPLICATION_STATE_DISABLED, utils.GetReplicationState(map[string]any{"sourceDomainUUID": "vm-1", "enable": false}, nil, snapshots, since))
+
+	percent, ok := utils.GetReplicationProgressPercent(map[string]any{"progress": map[string]any{"percentComplete": 5}})
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), percent)
+	_, ok = utils.GetReplicationProgressPercent(map[string]any{})
+	assert.False(t, ok)
+}
+
+func TestGetVMReplicationBySourceVM(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/v1/VirDomainReplication", r.URL.Path)
+		_, _ = w.Write([]byte(`[
+			{"uuid": "repl-1", "sourceDomainUUID": "vm-1", "connectionUUID": "conn-1"},
+			{"uuid": "repl-2", "sourceDomainUUID": "vm-2", "connectionUUID": "conn-1"}
+		]`))
+	}))
+	defer server.Close()
+	restClient := utils.RestClient{HttpClient: server.Client(), Host: server.URL, AuthHeader: map[string]string{}}
+
+	replication := utils.GetVMReplicationBySourceVM(restClient, "vm-2")
+	assert.NotNil(t, replication)
+	assert.Equal(t, "repl-2", (*replication)["uuid"])
+	assert.Nil(t, utils.GetVMReplicationBySourceVM(restClient, "vm-3"))
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterVMSnapshots(t *testing.T) {
+	// 1893456000 = 2030-01-01T00:00:00Z, one day = 86400
+	snapshots := []map[string]any{
+		{"uuid": "snap-3", "domainUUID": "vm-a", "label": "nightly-3", "type": "AUTOMATED", "timestamp": 1893628800.0},
+		{"uuid": "snap-1", "domainUUID": "vm-a", "label": "nightly-1", "type": "AUTOMATED", "timestamp": 1893456000.0},
+		{"uuid": "snap-2", "domainUUID": "vm-a", "label": "before-upgrade", "type": "USER", "timestamp": 1893542400.0},
+		{"uuid": "snap-4", "domainUUID": "vm-b", "label": "nightly-1", "type": "AUTOMATED", "timestamp": 1893456000.0},
+	}
+
+	uuids := func(filtered []map[string]any) []string {
+		result := []string{}
+		for _, snapshot := range filtered {
+			result = append(result, snapshot["uuid"].(string))
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"snap-1", "snap-4", "snap-2", "snap-3"}, uuids(utils.FilterVMSnapshots(snapshots, utils.VMSnapshotFilter{})))
+	assert.Equal(t, []string{"snap-1", "snap-2", "snap-3"}, uuids(utils.FilterVMSnapshots(snapshots, utils.VMSnapshotFilter{VMUUID: "vm-a"})))
+	assert.Equal(t, []string{"snap-1", "snap-4"}, uuids(utils.FilterVMSnapshots(snapshots, utils.VMSnapshotFilter{Label: "nightly-1"})))
+	assert.Equal(t, []string{"snap-1", "snap-3"}, uuids(utils.FilterVMSnapshots(snapshots, utils.VMSnapshotFilter{VMUUID: "vm-a", LabelRegex: "^nightly-"})))
+	assert.Equal(t, []string{"snap-2"}, uuids(utils.FilterVMSnapshots(snapshots, utils.VMSnapshotFilter{Type: "USER"})))
+	assert.Equal(t, []string{"snap-2", "snap-3"}, uuids(utils.FilterVMSnapshots(snapshots, utils.VMSnapshotFilter{CreatedAfter: "2030-01-01T00:00:00Z"})))
+	assert.Equal(t, []string{"snap-1", "snap-4", "snap-2"}, uuids(utils.FilterVMSnapshots(snapshots, utils.VMSnapshotFilter{CreatedBefore: "2030-01-03T00:00:00Z"})))
+	assert.Equal(t, []string{"snap-2"}, uuids(utils.FilterVMSnapshots(snapshots, utils.VMSnapshotFilter{CreatedAfter: "2030-01-01T12:00:00Z", CreatedBefore: "2030-01-02T12:00:00Z"})))
+	assert.Empty(t, utils.FilterVMSnapshots(snapshots, utils.VMSnapshotFilter{VMUUID: "vm-b", Type: "USER"}))
+}
+
+func TestValidateVMSnapshotFilter(t *testing.T) {
+	assert.Nil(t, utils.ValidateVMSnapshotFilter(&utils.VMSnapshotFilter{}))
+	assert.Nil(t, utils.ValidateVMSnapshotFilter(&utils.VMSnapshotFilter{Type: "AUTOMATED", LabelRegex: "^nightly-", CreatedAfter: "2030-01-01T00:00:00+01:00"}))
+	assert.NotNil(t, utils.ValidateVMSnapshotFilter(&utils.VMSnapshotFilter{Type: "MANUAL"}))
+	assert.NotNil(t, utils.ValidateVMSnapshotFilter(&utils.VMSnapshotFilter{LabelRegex: "nightly-[0-9"}))
+	assert.NotNil(t, utils.ValidateVMSnapshotFilter(&utils.VMSnapshotFilter{CreatedBefore: "2030-01-01"}))
+}
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterVMSnapshotSchedules(t *testing.T) {
+	schedules := []map[string]any{
+		{"uuid": "sched-3", "name": "Hourly"},
+		{"uuid": "sched-2", "name": "Daily"},
+		{"uuid": "sched-1", "name": "Daily"},
+	}
+
+	uuids := func(filtered []map[string]any) []string {
+		result := []string{}
+		for _, schedule := range filtered {
+			result = append(result, schedule["uuid"].(string))
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"sched-1", "sched-2", "sched-3"}, uuids(utils.FilterVMSnapshotSchedules(schedules, "")))
+	assert.Equal(t, []string{"sched-3"}, uuids(utils.FilterVMSnapshotSchedules(schedules, "Hourly")))
+	assert.Empty(t, utils.FilterVMSnapshotSchedules(schedules, "hourly"))
+}
+
+func TestFindVMSnapshotScheduleForImport(t *testing.T) {
+	schedules := []map[string]any{
+		{"uuid": "sched-1", "name": "Daily"},
+		{"uuid": "sched-2", "name": "Daily"},
+		{"uuid": "sched-3", "name": "Hourly"},
+		{"uuid": "sched-4", "name": "sched-3"},
+	}
+
+	schedule, d := utils.FindVMSnapshotScheduleForImport(schedules, "sched-1")
+	assert.Nil(t, d)
+	assert.Equal(t, "sched-1", schedule["uuid"])
+
+	schedule, d = utils.FindVMSnapshotScheduleForImport(schedules, "Hourly")
+	assert.Nil(t, d)
+	assert.Equal(t, "sched-3", schedule["uuid"])
+
+	// UUID takes precedence over name
+	schedule, d = utils.FindVMSnapshotScheduleForImport(schedules, "sched-3")
+	assert.Nil(t, d)
+	assert.Equal(t, "Hourly", schedule["name"])
+
+	_, d = utils.FindVMSnapshotScheduleForImport(schedules, "Daily")
+	assert.NotNil(t, d)
+	assert.Contains(t, d.Detail(), "sched-1, sched-2")
+
+	_, d = utils.FindVMSnapshotScheduleForImport(schedules, "Weekly")
+	assert.NotNil(t, d)
+}
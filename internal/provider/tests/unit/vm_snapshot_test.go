@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetentionDuration(t *testing.T) {
+	duration, err := utils.ParseRetentionDuration("30d")
+	assert.Nil(t, err)
+	assert.Equal(t, 30*24*time.Hour, duration)
+
+	duration, err = utils.ParseRetentionDuration("2w")
+	assert.Nil(t, err)
+	assert.Equal(t, 14*24*time.Hour, duration)
+
+	duration, err = utils.ParseRetentionDuration("1h30m")
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Minute, duration)
+
+	_, err = utils.ParseRetentionDuration("1.5d")
+	assert.NotNil(t, err)
+	_, err = utils.ParseRetentionDuration("forever")
+	assert.NotNil(t, err)
+}
+
+func TestParseRetainUntil(t *testing.T) {
+	created := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	timestamp, d := utils.ParseRetainUntil("", created)
+	assert.Nil(t, d)
+	assert.Equal(t, int64(0), timestamp)
+
+	timestamp, d = utils.ParseRetainUntil("2030-02-01T00:00:00Z", created)
+	assert.Nil(t, d)
+	assert.Equal(t, time.Date(2030, 2, 1, 0, 0, 0, 0, time.UTC).Unix(), timestamp)
+
+	timestamp, d = utils.ParseRetainUntil("2030-02-01T02:00:00+02:00", created)
+	assert.Nil(t, d)
+	assert.Equal(t, time.Date(2030, 2, 1, 0, 0, 0, 0, time.UTC).Unix(), timestamp)
+
+	timestamp, d = utils.ParseRetainUntil("7d", created)
+	assert.Nil(t, d)
+	assert.Equal(t, time.Date(2030, 1, 8, 12, 0, 0, 0, time.UTC).Unix(), timestamp)
+
+	_, d = utils.ParseRetainUntil("-1h", created)
+	assert.NotNil(t, d)
+	_, d = utils.ParseRetainUntil("0s", created)
+	assert.NotNil(t, d)
+	_, d = utils.ParseRetainUntil("2030-02-01", created)
+	assert.NotNil(t, d)
+}
+
+func TestValidateRetainUntil(t *testing.T) {
+	assert.Nil(t, utils.ValidateRetainUntil("2030-02-01T00:00:00Z"))
+	assert.Nil(t, utils.ValidateRetainUntil("12h"))
+	assert.NotNil(t, utils.ValidateRetainUntil("next week"))
+	// A timestamp which already passed is still valid config.
+	assert.Nil(t, utils.ValidateRetainUntil("2020-02-01T00:00:00Z"))
+}
+
+func TestValidateRetainUntilNotPast(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Nil(t, utils.ValidateRetainUntilNotPast("", now))
+	assert.Nil(t, utils.ValidateRetainUntilNotPast("12h", now))
+	assert.Nil(t, utils.ValidateRetainUntilNotPast("2030-02-01T00:00:00Z", now))
+	assert.NotNil(t, utils.ValidateRetainUntilNotPast("2030-01-01T00:00:00Z", now))
+	assert.NotNil(t, utils.ValidateRetainUntilNotPast("2020-02-01T00:00:00Z", now))
+}
+
+func TestFormatRetainUntil(t *testing.T) {
+	assert.Equal(t, "", utils.FormatRetainUntil(0))
+	assert.Equal(t, "2030-02-01T00:00:00Z", utils.FormatRetainUntil(time.Date(2030, 2, 1, 0, 0, 0, 0, time.UTC).Unix()))
+}
+
+func TestGetVMSnapshotCreated(t *testing.T) {
+	assert.Equal(t, int64(1893456000), utils.GetVMSnapshotCreated(map[string]any{"timestamp": 1893456000.0}).Unix())
+	assert.Equal(t, int64(0), utils.GetVMSnapshotCreated(map[string]any{}).Unix())
+}
@@ -0,0 +1,231 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-provider-hypercore/internal/utils"
+)
+
+var _ validator.String = utilsStringValidator{}
+
+// utilsStringValidator runs one of the utils.ValidateX functions at plan time,
+// so invalid values are reported before any HC3 API call is made.
+type utilsStringValidator struct {
+	description string
+	validate    func(string) diag.Diagnostic
+}
+
+func (v utilsStringValidator) Description(_ context.Context) string {
+	return v.description
+}
+
+func (v utilsStringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v utilsStringValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if d := v.validate(req.ConfigValue.ValueString()); d != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, d.Summary(), d.Detail())
+	}
+}
+
+var _ validator.Int64 = int64AtLeastValidator{}
+
+// int64AtLeastValidator accepts values greater than or equal to min.
+type int64AtLeastValidator struct {
+	min int64
+}
+
+func (v int64AtLeastValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be at least %d", v.min)
+}
+
+func (v int64AtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v int64AtLeastValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if req.ConfigValue.ValueInt64() < v.min {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid value",
+			fmt.Sprintf("Value %d is invalid, %s.", req.ConfigValue.ValueInt64(), v.Description(ctx)),
+		)
+	}
+}
+
+var _ validator.Int32 = int32AtLeastValidator{}
+
+// int32AtLeastValidator accepts values greater than or equal to min.
+type int32AtLeastValidator struct {
+	min int32
+}
+
+func (v int32AtLeastValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be at least %d", v.min)
+}
+
+func (v int32AtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v int32AtLeastValidator) ValidateInt32(ctx context.Context, req validator.Int32Request, resp *validator.Int32Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if req.ConfigValue.ValueInt32() < v.min {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid value",
+			fmt.Sprintf("Value %d is invalid, %s.", req.ConfigValue.ValueInt32(), v.Description(ctx)),
+		)
+	}
+}
+
+// powerStateValidator accepts power states that can be requested with a power action.
+func powerStateValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be one of: RUNNING, PAUSED, SHUTOFF",
+		validate:    utils.ValidatePowerState,
+	}
+}
+
+// observedPowerStateValidator accepts any power state HC3 can report.
+func observedPowerStateValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be a known VM power state",
+		validate:    utils.ValidateObservedPowerState,
+	}
+}
+
+// restartMethodValidator accepts power actions usable to restart a VM.
+func restartMethodValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be one of: REBOOT, RESET",
+		validate:    utils.ValidateRestartMethod,
+	}
+}
+
+// ipv4CIDRValidator accepts IPv4 CIDR like 10.0.0.0/24.
+func ipv4CIDRValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be an IPv4 CIDR",
+		validate:    utils.ValidateIpv4CIDR,
+	}
+}
+
+// diskCacheModeValidator accepts HC3 disk cache modes.
+func diskCacheModeValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be one of: WRITETHROUGH, WRITEBACK, NONE",
+		validate:    utils.ValidateDiskCacheMode,
+	}
+}
+
+// onIncompatibleChangeValidator accepts fail or replace.
+func onIncompatibleChangeValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be one of: fail, replace",
+		validate:    utils.ValidateOnIncompatibleChange,
+	}
+}
+
+// downloadSourceTypeValidator accepts iso or virtual_disk.
+func downloadSourceTypeValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be one of: iso, virtual_disk",
+		validate:    utils.ValidateDownloadSourceType,
+	}
+}
+
+// nicTypeValidator accepts HC3 NIC types.
+func nicTypeValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be one of: VIRTIO, INTEL_E1000, RTL8139",
+		validate:    utils.ValidateNicType,
+	}
+}
+
+// macAddressValidator accepts unicast MAC addresses like 52:54:00:AB:CD:EF.
+func macAddressValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be a unicast MAC address",
+		validate:    utils.ValidateMacAddress,
+	}
+}
+
+// retainUntilValidator accepts RFC3339 timestamp or positive duration.
+func retainUntilValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be RFC3339 timestamp or positive duration",
+		validate:    utils.ValidateRetainUntil,
+	}
+}
+
+// vmSnapshotTypeValidator accepts HC3 VM snapshot types.
+func vmSnapshotTypeValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be one of: USER, AUTOMATED, SUPPORT",
+		validate:    utils.ValidateVMSnapshotType,
+	}
+}
+
+// rfc3339Validator accepts RFC3339 timestamp like 2030-01-31T00:00:00Z.
+func rfc3339Validator() validator.String {
+	return utilsStringValidator{
+		description: "value must be RFC3339 timestamp",
+		validate:    utils.ValidateRFC3339Timestamp,
+	}
+}
+
+// rruleValidator accepts RFC-2445 RRULE like FREQ=DAILY;INTERVAL=1.
+func rruleValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be RFC-2445 RRULE",
+		validate:    utils.ValidateRRule,
+	}
+}
+
+// scheduleStartTimestampValidator accepts cluster local or RFC3339 timestamp.
+func scheduleStartTimestampValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be '2010-01-01 00:00:00' or RFC3339 timestamp",
+		validate:    utils.ValidateScheduleStartTimestamp,
+	}
+}
+
+// scheduleEveryValidator accepts whole minutes, hours, days or weeks like 1h.
+func scheduleEveryValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be whole minutes, hours, days or weeks",
+		validate:    utils.ValidateScheduleEvery,
+	}
+}
+
+// scheduleAtValidator accepts time of day like 02:30.
+func scheduleAtValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be time of day 'HH:MM'",
+		validate:    utils.ValidateScheduleAt,
+	}
+}
+
+// hyperCoreVersionValidator accepts dotted versions like 9.4 or 9.4.30.217736.
+func hyperCoreVersionValidator() validator.String {
+	return utilsStringValidator{
+		description: "value must be dotted version numbers",
+		validate:    utils.ValidateHyperCoreVersion,
+	}
+}
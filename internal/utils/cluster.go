@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// ClusterCapacity holds storage and memory totals of all cluster nodes, in bytes.
+// Storage is raw drive capacity. HC3 keeps multiple copies of data, so usable capacity is lower.
+type ClusterCapacity struct {
+	StorageRawTotalBytes int64
+	StorageRawUsedBytes  int64
+	MemoryTotalBytes     int64
+	MemoryUsedBytes      int64
+}
+
+func (capacity ClusterCapacity) StorageRawFreeBytes() int64 {
+	return max(capacity.StorageRawTotalBytes-capacity.StorageRawUsedBytes, 0)
+}
+
+func (capacity ClusterCapacity) MemoryFreeBytes() int64 {
+	return max(capacity.MemoryTotalBytes-capacity.MemoryUsedBytes, 0)
+}
+
+// anyToInteger64OrZero is AnyToInteger64, with 0 for fields not reported by HC3.
+func anyToInteger64OrZero(value any) int64 {
+	if value == nil {
+		return 0
+	}
+	return AnyToInteger64(value)
+}
+
+// GetNodeStorageBytes returns total and used raw storage of node drives.
+func GetNodeStorageBytes(node map[string]any) (int64, int64) {
+	total, used := int64(0), int64(0)
+	for _, drive := range AnyToListOfMap(node["drives"]) {
+		total += anyToInteger64OrZero(drive["capacityBytes"])
+		used += anyToInteger64OrZero(drive["usedBytes"])
+	}
+	return total, used
+}
+
+// GetNodeMemoryBytes returns total and used memory of the node.
+func GetNodeMemoryBytes(node map[string]any) (int64, int64) {
+	return anyToInteger64OrZero(node["memSize"]), anyToInteger64OrZero(node["totalMemUsageBytes"])
+}
+
+func GetClusterCapacity(nodes []map[string]any) ClusterCapacity {
+	capacity := ClusterCapacity{}
+	for _, node := range nodes {
+		storageTotal, storageUsed := GetNodeStorageBytes(node)
+		memoryTotal, memoryUsed := GetNodeMemoryBytes(node)
+		capacity.StorageRawTotalBytes += storageTotal
+		capacity.StorageRawUsedBytes += storageUsed
+		capacity.MemoryTotalBytes += memoryTotal
+		capacity.MemoryUsedBytes += memoryUsed
+	}
+	return capacity
+}
+
+func parseHyperCoreVersion(version string) ([]int64, error) {
+	parts := []int64{}
+	for _, part := range strings.Split(strings.TrimSpace(version), ".") {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("'%s' is not a dotted version number", version)
+		}
+		parts = append(parts, n)
+	}
+	return parts, nil
+}
+
+func ValidateHyperCoreVersion(version string) diag.Diagnostic {
+	if _, err := parseHyperCoreVersion(version); err != nil {
+		return diag.NewErrorDiagnostic(
+			"Invalid HyperCore version",
+			fmt.Sprintf("Version '%s' is invalid. It must be dotted numbers, e.g. '9.4' or '9.4.30.217736'", version),
+		)
+	}
+	return nil
+}
+
+// CompareHyperCoreVersion compares dotted versions like 9.4.30.217736. Missing parts are 0, so 9.4 == 9.4.0.
+// Returns -1, 0 or 1.
+func CompareHyperCoreVersion(a string, b string) (int, error) {
+	partsA, err := parseHyperCoreVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	partsB, err := parseHyperCoreVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < max(len(partsA), len(partsB)); i++ {
+		var partA, partB int64
+		if i < len(partsA) {
+			partA = partsA[i]
+		}
+		if i < len(partsB) {
+			partB = partsB[i]
+		}
+		if partA != partB {
+			if partA < partB {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
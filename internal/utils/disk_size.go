@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// HC3 stores disk capacity in bytes. Terraform "size" is in decimal GB,
+// "size_gib" in binary GiB.
+const (
+	BYTES_PER_GB  int64 = 1000 * 1000 * 1000
+	BYTES_PER_GIB int64 = 1024 * 1024 * 1024
+)
+
+// DiskSizeGBToBytes converts GB to bytes, rounded to a whole byte.
+func DiskSizeGBToBytes(sizeGB float64) int64 {
+	return int64(math.Round(sizeGB * float64(BYTES_PER_GB)))
+}
+
+func DiskSizeBytesToGB(sizeBytes int64) float64 {
+	return float64(sizeBytes) / float64(BYTES_PER_GB)
+}
+
+func DiskSizeGiBToBytes(sizeGiB int64) int64 {
+	return sizeGiB * BYTES_PER_GIB
+}
+
+// DiskSizeBytesToGiB converts bytes to GiB. The second return value is false
+// if the size is not a whole number of GiB.
+func DiskSizeBytesToGiB(sizeBytes int64) (int64, bool) {
+	return sizeBytes / BYTES_PER_GIB, sizeBytes%BYTES_PER_GIB == 0
+}
+
+// DiskSizesGBEqual reports whether two GB sizes are the same number of bytes.
+func DiskSizesGBEqual(a float64, b float64) bool {
+	return DiskSizeGBToBytes(a) == DiskSizeGBToBytes(b)
+}
+
+func ValidateDiskSizeBytes(diskUUID string, oldSizeBytes int64, newSizeBytes int64) diag.Diagnostic {
+	if newSizeBytes < oldSizeBytes {
+		return diag.NewErrorDiagnostic(
+			"Invalid disk size",
+			fmt.Sprintf(
+				" can only be expanded. Use a larger size. %d B > %d B: diskUUID=%s",
+				newSizeBytes, oldSizeBytes, diskUUID,
+			),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	DOWNLOAD_SOURCE_ISO          = "iso"
+	DOWNLOAD_SOURCE_VIRTUAL_DISK = "virtual_disk"
+)
+
+var ALLOWED_DOWNLOAD_SOURCE_TYPES = map[string]bool{
+	DOWNLOAD_SOURCE_ISO:          true,
+	DOWNLOAD_SOURCE_VIRTUAL_DISK: true,
+}
+
+func ValidateDownloadSourceType(sourceType string) diag.Diagnostic {
+	if !ALLOWED_DOWNLOAD_SOURCE_TYPES[sourceType] {
+		return diag.NewErrorDiagnostic(
+			"Invalid download source type",
+			fmt.Sprintf("Source type '%s' not allowed. Allowed source types are: iso, virtual_disk", sourceType),
+		)
+	}
+	return nil
+}
+
+// GetDownloadSource finds an ISO or virtual disk by UUID or, if sourceUUID is empty, by name.
+func GetDownloadSource(restClient RestClient, sourceType string, sourceUUID string, sourceName string) (*map[string]any, diag.Diagnostic) {
+	if sourceType == DOWNLOAD_SOURCE_ISO {
+		return ResolveISO(restClient, sourceUUID, sourceName)
+	}
+
+	if sourceUUID != "" {
+		vd := GetVirtualDiskByUUID(restClient, sourceUUID)
+		if vd == nil {
+			return nil, diag.NewErrorDiagnostic("Virtual disk not found", fmt.Sprintf("Virtual disk with UUID '%s' not found.", sourceUUID))
+		}
+		return vd, nil
+	}
+	vd := GetVirtualDiskByName(restClient, sourceName)
+	if vd == nil {
+		return nil, diag.NewErrorDiagnostic("Virtual disk not found", fmt.Sprintf("Virtual disk with name '%s' not found.", sourceName))
+	}
+	return vd, nil
+}
+
+// GetDownloadSourceSize returns size of the ISO or virtual disk as reported by HC3.
+// It is informational, source changes are detected by GetDownloadSourceVersion.
+func GetDownloadSourceSize(sourceType string, source map[string]any) int64 {
+	if sourceType == DOWNLOAD_SOURCE_ISO {
+		return AnyToInteger64(source["size"])
+	}
+	return AnyToInteger64(source["capacityBytes"])
+}
+
+// GetDownloadSourceVersion returns a value which changes when content of the ISO or virtual disk on HC3 changes.
+// HC3 checksum is used if reported, then the modification timestamp. Size is used only if HC3 reports neither.
+func GetDownloadSourceVersion(sourceType string, source map[string]any) string {
+	if checksum := AnyToStringOrEmpty(source["checksum"]); checksum != "" {
+		return "checksum:" + checksum
+	}
+	if source["modified"] != nil {
+		return fmt.Sprintf("modified:%d", AnyToInteger64(source["modified"]))
+	}
+	return fmt.Sprintf("size:%d", GetDownloadSourceSize(sourceType, source))
+}
+
+func GetDownloadEndpoint(sourceType string, sourceUUID string) string {
+	if sourceType == DOWNLOAD_SOURCE_ISO {
+		return fmt.Sprintf("/rest/v1/ISO/%s/data", sourceUUID)
+	}
+	return fmt.Sprintf("/rest/v1/VirtualDisk/%s/download", sourceUUID)
+}
+
+// DownloadToFile streams endpoint into localPath and returns the file size and SHA256 checksum.
+// Data is written to a temporary file first, so localPath is never left partially written.
+func DownloadToFile(
+	restClient RestClient,
+	endpoint string,
+	localPath string,
+	ctx context.Context,
+) (int64, string, diag.Diagnostic) {
+	partPath := localPath + ".part"
+	file, err := os.Create(partPath)
+	if err != nil {
+		return 0, "", diag.NewErrorDiagnostic("Couldn't create local file", fmt.Sprintf("Couldn't create file '%s': %s", partPath, err.Error()))
+	}
+
+	hash := sha256.New()
+	size, err := restClient.GetBinaryRecord(endpoint, io.MultiWriter(file, hash), 0, ctx)
+	if cerr := file.Close(); cerr != nil && err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(partPath)
+		return 0, "", diag.NewErrorDiagnostic("Download failed", fmt.Sprintf("Couldn't download '%s' to '%s': %s", endpoint, localPath, err.Error()))
+	}
+
+	if err := os.Rename(partPath, localPath); err != nil {
+		_ = os.Remove(partPath)
+		return 0, "", diag.NewErrorDiagnostic("Couldn't create local file", fmt.Sprintf("Couldn't rename '%s' to '%s': %s", partPath, localPath, err.Error()))
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	tflog.Debug(ctx, fmt.Sprintf("TTRT Downloaded: endpoint=%s, path=%s, size=%d, sha256=%s", endpoint, localPath, size, checksum))
+	return size, checksum, nil
+}
+
+// FileSHA256 returns SHA256 checksum of a local file. Empty string is returned if the file does not exist.
+func FileSHA256(localPath string) (string, error) {
+	file, err := os.Open(localPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// FileModifiedTime returns modification time of a local file, in RFC3339 format with nanoseconds.
+func FileModifiedTime(localPath string) (string, error) {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return "", err
+	}
+	return info.ModTime().UTC().Format(time.RFC3339Nano), nil
+}
+
+// CheckLocalFile reports whether the local file still has the expected size and SHA256 checksum.
+// The file is hashed only if its modification time differs from modifiedTime, and the size matches.
+// Current modification time of the file is returned too.
+func CheckLocalFile(localPath string, size int64, modifiedTime string, checksum string) (bool, string, error) {
+	info, err := os.Stat(localPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, "", nil
+	}
+	if err != nil {
+		return false, "", err
+	}
+	currentModifiedTime := info.ModTime().UTC().Format(time.RFC3339Nano)
+	if info.Size() != size {
+		return false, currentModifiedTime, nil
+	}
+	if currentModifiedTime == modifiedTime {
+		return true, currentModifiedTime, nil
+	}
+
+	currentChecksum, err := FileSHA256(localPath)
+	if err != nil {
+		return false, "", err
+	}
+	return currentChecksum == checksum, currentModifiedTime, nil
+}
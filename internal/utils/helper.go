@@ -125,6 +125,15 @@ func AnyToString(str any) string {
 	return stringifiedAny
 }
 
+// AnyToStringOrEmpty is like AnyToString, but returns "" for missing (nil) values.
+// Use it for fields not returned by all HyperCore versions.
+func AnyToStringOrEmpty(str any) string {
+	if str == nil {
+		return ""
+	}
+	return AnyToString(str)
+}
+
 func AnyToBool(value any) bool {
 	switch v := value.(type) {
 	case bool:
@@ -233,6 +242,14 @@ func AnyToListOfStrings(list any) []string {
 	return strList
 }
 
+// AnyToListOfStringsOrEmpty is like AnyToListOfStrings, but returns an empty list for missing (nil) values.
+func AnyToListOfStringsOrEmpty(list any) []string {
+	if list == nil {
+		return []string{}
+	}
+	return AnyToListOfStrings(list)
+}
+
 func ReadLocalFileBinary(filePath string) ([]byte, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// ImageFilter holds optional client side filters for ISO and VirtualDisk records.
+// Empty fields are ignored.
+type ImageFilter struct {
+	Name       string
+	NamePrefix string
+	NameRegex  string
+
+	nameMatcher
+}
+
+// ValidateImageFilter validates the filter, and compiles it for Matches.
+func ValidateImageFilter(filter *ImageFilter) diag.Diagnostic {
+	if err := filter.compile(); err != nil {
+		return diag.NewErrorDiagnostic(
+			"Invalid name regex",
+			fmt.Sprintf("Name regex '%s' is invalid: %s", filter.NameRegex, err.Error()),
+		)
+	}
+	return nil
+}
+
+func (filter *ImageFilter) compile() error {
+	return filter.compileName(filter.NameRegex)
+}
+
+// Matches panics if the filter is invalid, use ValidateImageFilter first.
+func (filter *ImageFilter) Matches(image map[string]any) bool {
+	if err := filter.compile(); err != nil {
+		panic(err)
+	}
+
+	return filter.matchesName(AnyToString(image["name"]), filter.Name, filter.NamePrefix)
+}
+
+// FilterImages returns images matching the filter, sorted by name.
+func FilterImages(images []map[string]any, filter ImageFilter) []map[string]any {
+	filtered := []map[string]any{}
+	for _, image := range images {
+		if filter.Matches(image) {
+			filtered = append(filtered, image)
+		}
+	}
+	slices.SortStableFunc(filtered, func(a, b map[string]any) int {
+		return strings.Compare(AnyToString(a["name"]), AnyToString(b["name"]))
+	})
+	return filtered
+}
+
+// GetImageUsage returns sorted UUIDs of VMs with a block device using the image path.
+// Empty list is returned if path is empty.
+func GetImageUsage(vms []map[string]any, path string) []string {
+	vmUUIDs := []string{}
+	if path == "" {
+		return vmUUIDs
+	}
+	for _, vm := range vms {
+		for _, blockDev := range AnyToListOfMap(vm["blockDevs"]) {
+			if AnyToStringOrEmpty(blockDev["path"]) == path {
+				vmUUIDs = append(vmUUIDs, AnyToString(vm["uuid"]))
+				break
+			}
+		}
+	}
+	slices.Sort(vmUUIDs)
+	return vmUUIDs
+}
@@ -0,0 +1,257 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const (
+	IMAGE_FORMAT_QCOW2 = "qcow2"
+	IMAGE_FORMAT_VMDK  = "vmdk"
+	IMAGE_FORMAT_VHD   = "vhd"
+	IMAGE_FORMAT_VHDX  = "vhdx"
+	IMAGE_FORMAT_RAW   = "raw"
+)
+
+// IMAGE_FORMATS_BY_EXTENSION lists image formats allowed for a virtual disk name extension.
+// Extensions not listed here are not checked. ".img" is commonly used for raw and qcow2 cloud images.
+var IMAGE_FORMATS_BY_EXTENSION = map[string][]string{
+	".qcow2": {IMAGE_FORMAT_QCOW2},
+	".qcow":  {IMAGE_FORMAT_QCOW2},
+	".vmdk":  {IMAGE_FORMAT_VMDK},
+	".vhd":   {IMAGE_FORMAT_VHD},
+	".vhdx":  {IMAGE_FORMAT_VHDX},
+	".raw":   {IMAGE_FORMAT_RAW},
+	".img":   {IMAGE_FORMAT_RAW, IMAGE_FORMAT_QCOW2},
+}
+
+// Magic bytes of files which are not disk images HC3 can import.
+var unsupportedImageMagic = []struct {
+	offset int
+	magic  []byte
+	name   string
+}{
+	{0, []byte{0x1f, 0x8b}, "gzip archive"},
+	{0, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}, "xz archive"},
+	{0, []byte("BZh"), "bzip2 archive"},
+	{0, []byte{'P', 'K', 0x03, 0x04}, "zip archive"},
+	{0, []byte{'7', 'z', 0xbc, 0xaf, 0x27, 0x1c}, "7z archive"},
+	{0x8001, []byte("CD001"), "ISO image (use hypercore_iso)"},
+	{0, []byte("# Disk DescriptorFile"), "VMDK descriptor without data (use a monolithic sparse or streamOptimized VMDK)"},
+}
+
+// IMAGE_HEADER_SIZE is how much of the image start is needed to detect its format.
+// It covers the ISO 9660 volume descriptor at offset 0x8001.
+const IMAGE_HEADER_SIZE = 64 * 1024
+
+// IMAGE_FOOTER_SIZE is how much of the image end is needed to detect its format (fixed VHD footer).
+const IMAGE_FOOTER_SIZE = 512
+
+type ImageInfo struct {
+	Format string
+	// VirtualSize is the disk size seen by the guest, in bytes. -1 if it can not be determined from the header.
+	VirtualSize int64
+}
+
+// DetectImageFormat detects disk image format from magic bytes.
+// Data not matching any known format is treated as raw, unless it is a known unsupported file type.
+func DetectImageFormat(data []byte) (ImageInfo, diag.Diagnostic) {
+	return DetectImageFormatFromHeader(
+		data[:min(len(data), IMAGE_HEADER_SIZE)],
+		data[max(0, len(data)-IMAGE_FOOTER_SIZE):],
+		int64(len(data)),
+	)
+}
+
+// DetectImageFormatFromHeader detects disk image format from the first IMAGE_HEADER_SIZE bytes,
+// the last IMAGE_FOOTER_SIZE bytes and the size of the image, so the whole image does not need to be read.
+func DetectImageFormatFromHeader(data []byte, footer []byte, size int64) (ImageInfo, diag.Diagnostic) {
+	if size == 0 {
+		return ImageInfo{}, diag.NewErrorDiagnostic("Corrupt virtual disk image", "File is empty.")
+	}
+	for _, unsupported := range unsupportedImageMagic {
+		if hasMagic(data, unsupported.offset, unsupported.magic) {
+			return ImageInfo{}, diag.NewErrorDiagnostic(
+				"Unsupported virtual disk image",
+				fmt.Sprintf("File is a %s, not a disk image. Supported formats are: qcow2, vmdk, vhd, vhdx, raw", unsupported.name),
+			)
+		}
+	}
+
+	switch {
+	case hasMagic(data, 0, []byte{'Q', 'F', 'I', 0xfb}):
+		if len(data) < 32 {
+			return ImageInfo{}, truncatedImageDiagnostic(IMAGE_FORMAT_QCOW2)
+		}
+		version := binary.BigEndian.Uint32(data[4:8])
+		if version != 2 && version != 3 {
+			return ImageInfo{}, diag.NewErrorDiagnostic(
+				"Unsupported virtual disk image",
+				fmt.Sprintf("qcow version %d is not supported, only qcow2 (version 2 or 3) is supported.", version),
+			)
+		}
+		return ImageInfo{Format: IMAGE_FORMAT_QCOW2, VirtualSize: int64(binary.BigEndian.Uint64(data[24:32]))}, nil
+	case hasMagic(data, 0, []byte("KDMV")):
+		if len(data) < 20 {
+			return ImageInfo{}, truncatedImageDiagnostic(IMAGE_FORMAT_VMDK)
+		}
+		sectors := binary.LittleEndian.Uint64(data[12:20])
+		return ImageInfo{Format: IMAGE_FORMAT_VMDK, VirtualSize: int64(sectors) * 512}, nil
+	case hasMagic(data, 0, []byte("vhdxfile")):
+		return ImageInfo{Format: IMAGE_FORMAT_VHDX, VirtualSize: -1}, nil
+	case hasMagic(data, 0, []byte("conectix")):
+		// Dynamic and differencing VHD have a copy of the footer at the start.
+		return vhdImageInfo(data[:min(len(data), 512)])
+	case len(footer) >= 512 && hasMagic(footer, len(footer)-512, []byte("conectix")):
+		// Fixed VHD has only the footer, at the end.
+		return vhdImageInfo(footer[len(footer)-512:])
+	}
+
+	return ImageInfo{Format: IMAGE_FORMAT_RAW, VirtualSize: size}, nil
+}
+
+// ReadImageHeader reads the header and the footer of an image from a local file or an HTTP(S) URL,
+// for DetectImageFormatFromHeader. HTTP uses Range requests.
+// ok is false if the HTTP server does not support Range requests, then the whole image must be read instead.
+func ReadImageHeader(sourceURL string) ([]byte, []byte, int64, bool, error) {
+	if strings.HasPrefix(sourceURL, "file:///") {
+		return readLocalImageHeader(strings.TrimPrefix(sourceURL, "file:///"))
+	}
+
+	header, size, ok, err := fetchRange(sourceURL, fmt.Sprintf("bytes=0-%d", IMAGE_HEADER_SIZE-1))
+	if err != nil || !ok {
+		return nil, nil, 0, ok, err
+	}
+	if size <= int64(len(header)) {
+		return header, header[max(0, len(header)-IMAGE_FOOTER_SIZE):], size, true, nil
+	}
+	footer, _, ok, err := fetchRange(sourceURL, fmt.Sprintf("bytes=%d-%d", size-IMAGE_FOOTER_SIZE, size-1))
+	return header, footer, size, ok, err
+}
+
+func readLocalImageHeader(filePath string) ([]byte, []byte, int64, bool, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, nil, 0, false, fmt.Errorf("error opening file '%s': %s", filePath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, nil, 0, false, err
+	}
+	size := info.Size()
+	header := make([]byte, min(size, IMAGE_HEADER_SIZE))
+	if _, err := file.ReadAt(header, 0); err != nil {
+		return nil, nil, 0, false, err
+	}
+	footer := make([]byte, min(size, IMAGE_FOOTER_SIZE))
+	if _, err := file.ReadAt(footer, size-int64(len(footer))); err != nil {
+		return nil, nil, 0, false, err
+	}
+	return header, footer, size, true, nil
+}
+
+// fetchRange reads a byte range of url and returns it with the full size of the resource.
+// ok is false if the server ignored the Range header.
+func fetchRange(url string, byteRange string) ([]byte, int64, bool, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	req.Header.Set("Range", byteRange)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		// The body is not read, it can be the whole image.
+		return nil, 0, false, nil
+	}
+	// Content-Range: bytes 0-65535/1073741824
+	_, total, found := strings.Cut(resp.Header.Get("Content-Range"), "/")
+	size, err := strconv.ParseInt(total, 10, 64)
+	if !found || err != nil {
+		return nil, 0, false, nil
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, IMAGE_HEADER_SIZE))
+	if err != nil {
+		return nil, 0, false, err
+	}
+	return data, size, true, nil
+}
+
+// ValidateImageExtension checks that virtual disk name extension matches the detected image format.
+func ValidateImageExtension(name string, format string) diag.Diagnostic {
+	extension := strings.ToLower(filepath.Ext(name))
+	allowedFormats, ok := IMAGE_FORMATS_BY_EXTENSION[extension]
+	if !ok {
+		return nil
+	}
+	for _, allowedFormat := range allowedFormats {
+		if allowedFormat == format {
+			return nil
+		}
+	}
+	return diag.NewErrorDiagnostic(
+		"Virtual disk name does not match image format",
+		fmt.Sprintf(
+			"Virtual disk '%s' has extension '%s', but the image format is '%s'. Use a name ending with %s.",
+			name, extension, format, strings.Join(extensionsForFormat(format), " or "),
+		),
+	)
+}
+
+func extensionsForFormat(format string) []string {
+	extensions := []string{}
+	for extension, formats := range IMAGE_FORMATS_BY_EXTENSION {
+		for _, f := range formats {
+			if f == format {
+				extensions = append(extensions, "'"+extension+"'")
+			}
+		}
+	}
+	sort.Strings(extensions)
+	return extensions
+}
+
+func vhdImageInfo(footer []byte) (ImageInfo, diag.Diagnostic) {
+	if len(footer) < 512 {
+		return ImageInfo{}, truncatedImageDiagnostic(IMAGE_FORMAT_VHD)
+	}
+	// Current size is at offset 48 of the footer, big-endian.
+	return ImageInfo{Format: IMAGE_FORMAT_VHD, VirtualSize: int64(binary.BigEndian.Uint64(footer[48:56]))}, nil
+}
+
+func hasMagic(data []byte, offset int, magic []byte) bool {
+	if offset < 0 || len(data) < offset+len(magic) {
+		return false
+	}
+	return bytes.Equal(data[offset:offset+len(magic)], magic)
+}
+
+func truncatedImageDiagnostic(format string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Corrupt virtual disk image",
+		fmt.Sprintf("File looks like %s, but its header is truncated.", format),
+	)
+}
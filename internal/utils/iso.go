@@ -149,3 +149,50 @@ func UploadISO(
 	iso := GetISOByUUID(restClient, isoUUID)
 	return iso, nil
 }
+
+func GetISOByName(
+	restClient RestClient,
+	name string,
+) *map[string]any {
+	iso := restClient.GetRecord(
+		"/rest/v1/ISO",
+		map[string]any{
+			"name": name,
+		},
+		false,
+		-1,
+	)
+	return iso
+}
+
+// GetISOByPath returns ISO with the given path, as used in a CD-ROM drive path. nil is returned if not found.
+func GetISOByPath(
+	restClient RestClient,
+	path string,
+) *map[string]any {
+	if path == "" {
+		return nil
+	}
+	isos := restClient.ListRecords(
+		"/rest/v1/ISO",
+		map[string]any{
+			"path": path,
+		},
+		-1,
+		false,
+	)
+	if len(isos) == 0 {
+		return nil
+	}
+	return &isos[0]
+}
+
+func ValidateISOReadyForInsert(iso map[string]any) diag.Diagnostic {
+	if !AnyToBool(iso["readyForInsert"]) {
+		return diag.NewErrorDiagnostic(
+			"ISO not ready for insert",
+			fmt.Sprintf("ISO '%s' (UUID '%s') is not ready for insert, it might still be uploading.", AnyToString(iso["name"]), AnyToString(iso["uuid"])),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// nameMatcher is embedded in client side filters to match a record name (or label)
+// by exact value, prefix and regex. Set by compileName, so the regex is compiled
+// once per filter, not once per record.
+type nameMatcher struct {
+	compiled   bool
+	nameRegexp *regexp.Regexp
+}
+
+func (matcher *nameMatcher) compileName(nameRegex string) error {
+	if matcher.compiled {
+		return nil
+	}
+	if nameRegex != "" {
+		nameRegexp, err := regexp.Compile(nameRegex)
+		if err != nil {
+			return err
+		}
+		matcher.nameRegexp = nameRegexp
+	}
+	matcher.compiled = true
+	return nil
+}
+
+// matchesName expects compileName was called. Empty exact and prefix are ignored.
+func (matcher *nameMatcher) matchesName(name string, exact string, prefix string) bool {
+	if exact != "" && name != exact {
+		return false
+	}
+	if prefix != "" && !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	if matcher.nameRegexp != nil && !matcher.nameRegexp.MatchString(name) {
+		return false
+	}
+	return true
+}
@@ -4,29 +4,37 @@
 package utils
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"net"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+var NIC_TYPES = []string{"VIRTIO", "INTEL_E1000", "RTL8139"}
+
 func CreateNic(
 	restClient RestClient,
 	vmUUID string,
 	nic_type string,
 	vlan int64,
 	macAddress string,
+	connected bool,
 	ctx context.Context,
 ) (string, map[string]any) {
 	payload := map[string]any{
 		"virDomainUUID": vmUUID,
 		"type":          nic_type,
 		"vlan":          vlan,
+		"connected":     connected,
 	}
 	if macAddress != "" {
-		payload["macAddress"] = macAddress
+		payload["macAddress"] = NormalizeMacAddress(macAddress)
 	}
 	taskTag, _, _ := restClient.CreateRecord(
 		"/rest/v1/VirDomainNetDevice",
@@ -91,3 +99,110 @@ func ValidateNICSourceVMUUIDUnchanged(nicUUID string, oldVMUUID string, newVMUUI
 	}
 	return nil
 }
+
+func ValidateNicType(nicType string) diag.Diagnostic {
+	if slices.Contains(NIC_TYPES, nicType) {
+		return nil
+	}
+	return diag.NewErrorDiagnostic(
+		"Invalid NIC type",
+		fmt.Sprintf("NIC type '%s' is invalid. NIC type must be one of: %s", nicType, strings.Join(NIC_TYPES, ", ")),
+	)
+}
+
+// ValidateMacAddress accepts unicast MAC addresses like 52:54:00:AB:CD:EF or 52-54-00-ab-cd-ef.
+func ValidateMacAddress(macAddress string) diag.Diagnostic {
+	hwAddr, err := net.ParseMAC(macAddress)
+	if err != nil || len(hwAddr) != 6 || strings.Contains(macAddress, ".") {
+		return diag.NewErrorDiagnostic(
+			"Invalid NIC MAC address",
+			fmt.Sprintf("MAC address '%s' is invalid. MAC address must be six hex octets separated by ':' or '-', e.g. '52:54:00:AB:CD:EF'", macAddress),
+		)
+	}
+	if hwAddr[0]&0x01 != 0 {
+		return diag.NewErrorDiagnostic(
+			"Invalid NIC MAC address",
+			fmt.Sprintf("MAC address '%s' is a multicast address. NIC MAC address must be unicast", macAddress),
+		)
+	}
+	return nil
+}
+
+// NormalizeMacAddress returns MAC address in the HC3 format, upper case with ':' separators.
+// Invalid MAC address is returned unchanged.
+func NormalizeMacAddress(macAddress string) string {
+	hwAddr, err := net.ParseMAC(macAddress)
+	if err != nil || len(hwAddr) != 6 {
+		return macAddress
+	}
+	return strings.ToUpper(hwAddr.String())
+}
+
+func MacAddressesEqual(a string, b string) bool {
+	return NormalizeMacAddress(a) == NormalizeMacAddress(b)
+}
+
+// FindNicForImport returns the VM NIC selected by import ID part after 'vm_uuid:'.
+// Selector is a MAC address, a 0-based index into VM NICs sorted by slot, or legacy 'nic_type:nic_vlan'.
+// NICs are sorted because HC3 does not guarantee netDevs order.
+func FindNicForImport(nics []map[string]any, selector string) (map[string]any, diag.Diagnostic) {
+	if ValidateMacAddress(selector) == nil {
+		for _, nic := range nics {
+			if MacAddressesEqual(AnyToString(nic["macAddress"]), selector) {
+				return nic, nil
+			}
+		}
+		return nil, diag.NewErrorDiagnostic(
+			"NIC import error, NIC not found",
+			fmt.Sprintf("VM has no NIC with MAC address '%s'.", selector),
+		)
+	}
+
+	if index, err := strconv.Atoi(selector); err == nil {
+		if index < 0 || index >= len(nics) {
+			return nil, diag.NewErrorDiagnostic(
+				"NIC import error, NIC not found",
+				fmt.Sprintf("NIC index %d is out of range, VM has %d NICs.", index, len(nics)),
+			)
+		}
+		sorted := slices.Clone(nics)
+		slices.SortStableFunc(sorted, func(a, b map[string]any) int {
+			return cmp.Compare(AnyToInteger64(a["slot"]), AnyToInteger64(b["slot"]))
+		})
+		return sorted[index], nil
+	}
+
+	selectorParts := strings.Split(selector, ":")
+	if len(selectorParts) == 2 {
+		vlan, err := strconv.ParseInt(selectorParts[1], 10, 64)
+		if err == nil {
+			for _, nic := range nics {
+				if AnyToInteger64(nic["vlan"]) == vlan && AnyToString(nic["type"]) == selectorParts[0] {
+					return nic, nil
+				}
+			}
+			return nil, diag.NewErrorDiagnostic(
+				"NIC import error, NIC not found",
+				fmt.Sprintf("VM has no NIC with type '%s' and VLAN %d.", selectorParts[0], vlan),
+			)
+		}
+	}
+
+	return nil, diag.NewErrorDiagnostic(
+		"NIC import requires a composite ID",
+		fmt.Sprintf("NIC import ID format is 'vm_uuid:mac_address', 'vm_uuid:nic_index' or 'vm_uuid:nic_type:nic_vlan'. NIC selector '%s' is invalid.", selector),
+	)
+}
+
+// GetNicIpv4Addresses returns IPv4 addresses reported by the guest agent, empty list if none.
+func GetNicIpv4Addresses(nic map[string]any) []string {
+	return AnyToListOfStringsOrEmpty(nic["ipv4Addresses"])
+}
+
+// GetNicConnected returns NIC link state. HC3 versions without the field have NICs always connected.
+func GetNicConnected(nic map[string]any) bool {
+	if nic["connected"] == nil {
+		return true
+	}
+	return AnyToBool(nic["connected"])
+}
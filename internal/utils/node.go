@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+const (
+	NODE_NETWORK_STATUS_ONLINE = "ONLINE"
+	NODE_DISPOSITION_IN        = "IN"
+)
+
+// NodeFilter holds optional client side filters for Node records.
+// Nil fields are ignored.
+type NodeFilter struct {
+	Online      *bool
+	Schedulable *bool
+}
+
+// IsNodeOnline reports whether the node is reachable on network and runs virtualization.
+func IsNodeOnline(node map[string]any) bool {
+	return AnyToStringOrEmpty(node["networkStatus"]) == NODE_NETWORK_STATUS_ONLINE && GetNodeVirtualizationOnline(node)
+}
+
+// GetNodeVirtualizationOnline returns HC3 virtualizationOnline, true if not reported.
+func GetNodeVirtualizationOnline(node map[string]any) bool {
+	return node["virtualizationOnline"] == nil || AnyToBool(node["virtualizationOnline"])
+}
+
+// IsNodeInMaintenance reports whether the node is, or is being put, out of the cluster.
+func IsNodeInMaintenance(node map[string]any) bool {
+	for _, key := range []string{"currentDisposition", "desiredDisposition"} {
+		disposition := AnyToStringOrEmpty(node[key])
+		if disposition != "" && disposition != NODE_DISPOSITION_IN {
+			return true
+		}
+	}
+	return false
+}
+
+// GetNodeAllowRunningVMs returns HC3 allowRunningVMs, true if not reported.
+func GetNodeAllowRunningVMs(node map[string]any) bool {
+	return node["allowRunningVMs"] == nil || AnyToBool(node["allowRunningVMs"])
+}
+
+// IsNodeSchedulable reports whether new VMs can be started on the node.
+func IsNodeSchedulable(node map[string]any) bool {
+	return IsNodeOnline(node) && GetNodeAllowRunningVMs(node) && !IsNodeInMaintenance(node)
+}
+
+func (filter NodeFilter) Matches(node map[string]any) bool {
+	if filter.Online != nil && IsNodeOnline(node) != *filter.Online {
+		return false
+	}
+	if filter.Schedulable != nil && IsNodeSchedulable(node) != *filter.Schedulable {
+		return false
+	}
+	return true
+}
+
+func FilterNodes(nodes []map[string]any, filter NodeFilter) []map[string]any {
+	filtered := []map[string]any{}
+	for _, node := range nodes {
+		if filter.Matches(node) {
+			filtered = append(filtered, node)
+		}
+	}
+	return filtered
+}
+
+// CountVMsByNode returns number of VMs placed on each node, keyed by node UUID.
+func CountVMsByNode(vms []map[string]any) map[string]int64 {
+	counts := map[string]int64{}
+	for _, vm := range vms {
+		if nodeUUID := AnyToStringOrEmpty(vm["nodeUUID"]); nodeUUID != "" {
+			counts[nodeUUID]++
+		}
+	}
+	return counts
+}
+
+// NodeCPU holds CPU topology of a node. Fields not reported by HC3 are zero.
+type NodeCPU struct {
+	Sockets int64
+	Cores   int64
+	Threads int64
+	Hz      int64
+	// VMTypes are QEMU CPU types the node can provide to VMs, not the physical CPU model.
+	VMTypes []string
+}
+
+func GetNodeCPU(node map[string]any) NodeCPU {
+	return NodeCPU{
+		Sockets: anyToInteger64OrZero(node["numSockets"]),
+		Cores:   anyToInteger64OrZero(node["numCores"]),
+		Threads: anyToInteger64OrZero(node["numThreads"]),
+		Hz:      anyToInteger64OrZero(node["CPUhz"]),
+		VMTypes: AnyToListOfStringsOrEmpty(node["supportedCPUTypes"]),
+	}
+}
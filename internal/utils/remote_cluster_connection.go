@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func GetRemoteClusterConnectionByUUID(
+	restClient RestClient,
+	connectionUUID string,
+) *map[string]any {
+	connection := restClient.GetRecord(
+		fmt.Sprintf("/rest/v1/RemoteClusterConnection/%s", connectionUUID),
+		nil,
+		false,
+		-1,
+	)
+	return connection
+}
+
+func CreateRemoteClusterConnection(
+	restClient RestClient,
+	payload map[string]any,
+	ctx context.Context,
+) (string, map[string]any, diag.Diagnostic) {
+	taskTag, status, err := restClient.CreateRecord(
+		"/rest/v1/RemoteClusterConnection",
+		payload,
+		-1,
+	)
+	tflog.Debug(ctx, fmt.Sprintf("TTRT RemoteClusterConnection Create Status: %d\n", status))
+
+	if err != nil {
+		// Wrong credentials or unreachable remote host, retry would not help.
+		return "", nil, diag.NewErrorDiagnostic(
+			"Couldn't create a remote cluster connection",
+			fmt.Sprintf("Pairing with remote cluster '%s' failed. Check remote host and credentials. HC3 response message: %v", AnyToString(payload["remoteNodeIP"]), err.Error()),
+		)
+	}
+
+	taskTag.WaitTask(restClient, ctx)
+	connectionUUID := taskTag.CreatedUUID
+	connection := GetRemoteClusterConnectionByUUID(restClient, connectionUUID)
+	if connection == nil {
+		return "", nil, diag.NewErrorDiagnostic(
+			"Remote cluster connection not found",
+			fmt.Sprintf("Remote cluster connection not found after create - connectionUUID=%s", connectionUUID),
+		)
+	}
+
+	return connectionUUID, *connection, nil
+}
+
+func UpdateRemoteClusterConnection(
+	restClient RestClient,
+	connectionUUID string,
+	payload map[string]any,
+	ctx context.Context,
+) diag.Diagnostic {
+	taskTag, err := restClient.UpdateRecord(
+		fmt.Sprintf("/rest/v1/RemoteClusterConnection/%s", connectionUUID),
+		payload,
+		-1,
+		ctx,
+	)
+
+	if err != nil {
+		return diag.NewWarningDiagnostic(
+			"HC3 is receiving too many requests at the same time.",
+			fmt.Sprintf("Please retry apply after Terraform finishes it's current operation. HC3 response message: %v", err.Error()),
+		)
+	}
+
+	taskTag.WaitTask(restClient, ctx)
+
+	return nil
+}
+
+func DeleteRemoteClusterConnection(
+	restClient RestClient,
+	connectionUUID string,
+	ctx context.Context,
+) {
+	taskTag := restClient.DeleteRecord(
+		fmt.Sprintf("/rest/v1/RemoteClusterConnection/%s", connectionUUID),
+		-1,
+		ctx,
+	)
+	taskTag.WaitTask(restClient, ctx)
+}
+
+// IsRemoteClusterConnectionHealthy is true if HC3 reports replication over the connection works.
+func IsRemoteClusterConnectionHealthy(connection map[string]any) bool {
+	return connection["replicationOK"] != nil && AnyToBool(connection["replicationOK"])
+}
+
+// WaitRemoteClusterConnectionHealthy waits up to waitTimeout seconds for the connection to become healthy.
+func WaitRemoteClusterConnectionHealthy(restClient RestClient, connectionUUID string, waitTimeout int32, ctx context.Context) diag.Diagnostic {
+	startTime := time.Now().Unix()
+	for {
+		connection := GetRemoteClusterConnectionByUUID(restClient, connectionUUID)
+		if connection == nil {
+			return diag.NewErrorDiagnostic(
+				"Remote cluster connection not found",
+				fmt.Sprintf("Remote cluster connection not found - connectionUUID=%s", connectionUUID),
+			)
+		}
+		if IsRemoteClusterConnectionHealthy(*connection) {
+			return nil
+		}
+		status := AnyToStringOrEmpty((*connection)["connectionStatus"])
+		tflog.Info(ctx, fmt.Sprintf("TTRT WaitRemoteClusterConnectionHealthy: connection %s not healthy, status=%s", connectionUUID, status))
+
+		duration := time.Now().Unix() - startTime
+		if duration >= int64(waitTimeout) {
+			return diag.NewErrorDiagnostic(
+				"Remote cluster connection is not healthy",
+				fmt.Sprintf("Remote cluster connection %s did not become healthy in %d seconds, connection status is '%s'.", connectionUUID, waitTimeout, status),
+			)
+		}
+		time.Sleep(10 * time.Second)
+	}
+}
@@ -537,6 +537,42 @@ func (rc *RestClient) PutBinaryRecordWithoutTaskTag(endpoint string, binaryData
 	return resp.StatusCode, nil
 }
 
+// GetBinaryRecord streams binary response body of endpoint into writer.
+// Timeout 0 means no timeout, useful for large downloads.
+func (rc *RestClient) GetBinaryRecord(endpoint string, writer io.Writer, timeout float64, ctx context.Context) (int64, error) {
+	useTimeout := timeout
+	if timeout == -1 {
+		useTimeout = rc.Timeout
+	}
+	client := *rc.HttpClient
+	client.Timeout = time.Duration(useTimeout * float64(time.Second))
+
+	req := rc.Request(
+		"GET",
+		endpoint,
+		nil,
+		rc.AuthHeader,
+	)
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/octet-stream")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error making a request: %s", err.Error())
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			panic(fmt.Errorf("couldn't close response body: %s", cerr.Error()))
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected response: %d - %v", resp.StatusCode, rc.ToString(resp))
+	}
+
+	return io.Copy(writer, resp.Body)
+}
+
 func (rc *RestClient) DeleteRecord(endpoint string, timeout float64, ctx context.Context) *TaskTag {
 	useTimeout := timeout
 	if timeout == -1 {
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// ScheduleAssignmentSelector selects VMs for a snapshot schedule: VMs listed in VMUUIDs,
+// and VMs matching the tags. Tags are ignored if both TagsAny and TagsAll are empty.
+type ScheduleAssignmentSelector struct {
+	VMUUIDs []string
+	TagsAny []string
+	TagsAll []string
+}
+
+// SelectScheduleAssignmentVMs returns sorted UUIDs of VMs selected by the selector.
+// Listed VM UUIDs not found on HC3 are returned as an error.
+func SelectScheduleAssignmentVMs(vms []map[string]any, selector ScheduleAssignmentSelector) ([]string, diag.Diagnostic) {
+	tagFilter := VMFilter{TagsAny: selector.TagsAny, TagsAll: selector.TagsAll}
+	useTags := len(selector.TagsAny) > 0 || len(selector.TagsAll) > 0
+
+	selected := []string{}
+	found := []string{}
+	for _, vm := range vms {
+		vmUUID := AnyToString(vm["uuid"])
+		if slices.Contains(selector.VMUUIDs, vmUUID) {
+			found = append(found, vmUUID)
+			selected = append(selected, vmUUID)
+		} else if useTags && tagFilter.Matches(vm) {
+			selected = append(selected, vmUUID)
+		}
+	}
+	for _, vmUUID := range selector.VMUUIDs {
+		if !slices.Contains(found, vmUUID) {
+			return nil, diag.NewErrorDiagnostic(
+				"VM not found",
+				fmt.Sprintf("VM with UUID '%s' can't be assigned to the snapshot schedule, it was not found.", vmUUID),
+			)
+		}
+	}
+	slices.Sort(selected)
+	return selected, nil
+}
+
+// GetScheduleAssignedVMs returns sorted UUIDs of VMs using the snapshot schedule.
+func GetScheduleAssignedVMs(vms []map[string]any, scheduleUUID string) []string {
+	assigned := []string{}
+	for _, vm := range vms {
+		if AnyToStringOrEmpty(vm["snapshotScheduleUUID"]) == scheduleUUID {
+			assigned = append(assigned, AnyToString(vm["uuid"]))
+		}
+	}
+	slices.Sort(assigned)
+	return assigned
+}
+
+// TrackedScheduleAssignedVMs returns actual VMs tracked by an assignment: all of them if exclusive,
+// otherwise only those also in tracked. VMs assigned outside of Terraform are not tracked.
+func TrackedScheduleAssignedVMs(actual []string, tracked []string, exclusive bool) []string {
+	result := []string{}
+	for _, vmUUID := range actual {
+		if exclusive || slices.Contains(tracked, vmUUID) {
+			result = append(result, vmUUID)
+		}
+	}
+	return result
+}
+
+// PlanScheduleAssignment returns VMs to assign to the schedule and VMs to remove it from.
+// actual are VMs currently using the schedule, managed are VMs assigned by Terraform before.
+// If exclusive, the schedule is also removed from VMs assigned outside of Terraform.
+func PlanScheduleAssignment(desired []string, actual []string, managed []string, exclusive bool) ([]string, []string) {
+	toAssign := []string{}
+	for _, vmUUID := range desired {
+		if !slices.Contains(actual, vmUUID) {
+			toAssign = append(toAssign, vmUUID)
+		}
+	}
+	toRemove := []string{}
+	for _, vmUUID := range actual {
+		if slices.Contains(desired, vmUUID) {
+			continue
+		}
+		if exclusive || slices.Contains(managed, vmUUID) {
+			toRemove = append(toRemove, vmUUID)
+		}
+	}
+	return toAssign, toRemove
+}
+
+func AssignVMSnapshotSchedule(
+	restClient RestClient,
+	vmUUID string,
+	scheduleUUID string,
+	ctx context.Context,
+) diag.Diagnostic {
+	payload := map[string]any{
+		"snapshotScheduleUUID": scheduleUUID,
+	}
+
+	taskTag, err := restClient.UpdateRecord(
+		fmt.Sprintf("/rest/v1/VirDomain/%s", vmUUID),
+		payload,
+		-1,
+		ctx,
+	)
+
+	if err != nil {
+		return diag.NewWarningDiagnostic(
+			"HC3 is receiving too many requests at the same time.",
+			fmt.Sprintf("Please retry apply after Terraform finishes it's current operation. HC3 response message: %v", err.Error()),
+		)
+	}
+
+	taskTag.WaitTask(restClient, ctx)
+
+	return nil
+}
@@ -0,0 +1,277 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // cluster timezone must load also where the OS has no tz database
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// SCHEDULE_START_TIMESTAMP_FORMAT is the HC3 dtstart format, in cluster local timezone.
+const SCHEDULE_START_TIMESTAMP_FORMAT = "2006-01-02 15:04:05"
+
+// SCHEDULE_DEFAULT_START_DATE is used as dtstart date for rules given with 'every' and 'at'.
+const SCHEDULE_DEFAULT_START_DATE = "2010-01-01"
+
+var RRULE_FREQUENCIES = []string{"MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+var RRULE_WEEKDAYS = []string{"MO", "TU", "WE", "TH", "FR", "SA", "SU"}
+
+var rruleByDayRegex = regexp.MustCompile(`^([+-]?[1-9][0-9]?)?(MO|TU|WE|TH|FR|SA|SU)$`)
+
+func invalidRRule(rrule string, reason string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Invalid snapshot schedule rule frequency",
+		fmt.Sprintf("Frequency '%s' is not a valid RFC-2445 RRULE: %s", rrule, reason),
+	)
+}
+
+// validateRRuleIntList checks comma separated integers are within [min, max], or [-max, -min] if allowNegative.
+func validateRRuleIntList(value string, min int, max int, allowNegative bool) bool {
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return false
+		}
+		if allowNegative && n < 0 {
+			n = -n
+		}
+		if n < min || n > max {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseRRule parses RRULE like FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,FR into upper case parts.
+func ParseRRule(rrule string) (map[string]string, diag.Diagnostic) {
+	parts := map[string]string{}
+	if strings.TrimSpace(rrule) == "" {
+		return nil, invalidRRule(rrule, "it is empty")
+	}
+	for _, part := range strings.Split(strings.TrimPrefix(rrule, "RRULE:"), ";") {
+		key, value, found := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !found || key == "" || value == "" {
+			return nil, invalidRRule(rrule, fmt.Sprintf("'%s' is not KEY=VALUE", part))
+		}
+		if _, duplicate := parts[key]; duplicate {
+			return nil, invalidRRule(rrule, fmt.Sprintf("%s is given more than once", key))
+		}
+		parts[key] = value
+	}
+
+	for key, value := range parts {
+		valid := true
+		switch key {
+		case "FREQ":
+			valid = slices.Contains(RRULE_FREQUENCIES, value)
+		case "INTERVAL", "COUNT":
+			n, err := strconv.Atoi(value)
+			valid = err == nil && n > 0
+		case "UNTIL":
+			_, err := time.Parse("20060102T150405Z", value)
+			if err != nil {
+				_, err = time.Parse("20060102", value)
+			}
+			valid = err == nil
+		case "BYMINUTE":
+			valid = validateRRuleIntList(value, 0, 59, false)
+		case "BYHOUR":
+			valid = validateRRuleIntList(value, 0, 23, false)
+		case "BYMONTHDAY":
+			valid = validateRRuleIntList(value, 1, 31, true)
+		case "BYMONTH":
+			valid = validateRRuleIntList(value, 1, 12, false)
+		case "BYSETPOS":
+			valid = validateRRuleIntList(value, 1, 366, true)
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				valid = valid && rruleByDayRegex.MatchString(day)
+			}
+		case "WKST":
+			valid = slices.Contains(RRULE_WEEKDAYS, value)
+		default:
+			return nil, invalidRRule(rrule, fmt.Sprintf("%s is not supported", key))
+		}
+		if !valid {
+			return nil, invalidRRule(rrule, fmt.Sprintf("%s=%s is invalid", key, value))
+		}
+	}
+
+	if parts["FREQ"] == "" {
+		return nil, invalidRRule(rrule, "FREQ is required")
+	}
+	if parts["COUNT"] != "" && parts["UNTIL"] != "" {
+		return nil, invalidRRule(rrule, "COUNT and UNTIL can't be both set")
+	}
+	return parts, nil
+}
+
+func ValidateRRule(rrule string) diag.Diagnostic {
+	_, d := ParseRRule(rrule)
+	return d
+}
+
+// RRulesEqual compares RRULEs ignoring part order, letter case and default INTERVAL=1.
+func RRulesEqual(a string, b string) bool {
+	partsA, dA := ParseRRule(a)
+	partsB, dB := ParseRRule(b)
+	if dA != nil || dB != nil {
+		return a == b
+	}
+	for _, parts := range []map[string]string{partsA, partsB} {
+		if parts["INTERVAL"] == "" {
+			parts["INTERVAL"] = "1"
+		}
+	}
+	return maps.Equal(partsA, partsB)
+}
+
+func ValidateScheduleWeekday(weekday string) diag.Diagnostic {
+	if slices.Contains(RRULE_WEEKDAYS, weekday) {
+		return nil
+	}
+	return diag.NewErrorDiagnostic(
+		"Invalid snapshot schedule weekday",
+		fmt.Sprintf("Weekday '%s' is invalid. Weekday must be one of: %s", weekday, strings.Join(RRULE_WEEKDAYS, ", ")),
+	)
+}
+
+// ValidateScheduleEvery accepts whole minutes, hours, days or weeks, like 15m, 1h, 1d or 2w.
+func ValidateScheduleEvery(every string) diag.Diagnostic {
+	_, d := CompileScheduleRRule(every, nil)
+	return d
+}
+
+// CompileScheduleRRule builds RRULE from a structured rule. every is a duration in whole minutes,
+// hours, days or weeks. If every is empty, weekdays are required and the rule runs weekly.
+func CompileScheduleRRule(every string, weekdays []string) (string, diag.Diagnostic) {
+	for _, weekday := range weekdays {
+		if d := ValidateScheduleWeekday(weekday); d != nil {
+			return "", d
+		}
+	}
+
+	frequency := "WEEKLY"
+	interval := int64(1)
+	if every != "" {
+		duration, err := ParseRetentionDuration(every)
+		if err != nil || duration <= 0 || duration%time.Minute != 0 {
+			return "", diag.NewErrorDiagnostic(
+				"Invalid snapshot schedule interval",
+				fmt.Sprintf("Interval '%s' is invalid. It must be positive whole number of minutes, hours, days or weeks, e.g. '15m', '1h', '1d', '2w'", every),
+			)
+		}
+		for _, unit := range []struct {
+			frequency string
+			duration  time.Duration
+		}{
+			{"WEEKLY", 7 * 24 * time.Hour},
+			{"DAILY", 24 * time.Hour},
+			{"HOURLY", time.Hour},
+			{"MINUTELY", time.Minute},
+		} {
+			if duration%unit.duration == 0 {
+				frequency = unit.frequency
+				interval = int64(duration / unit.duration)
+				break
+			}
+		}
+	} else if len(weekdays) == 0 {
+		return "", diag.NewErrorDiagnostic(
+			"Incomplete snapshot schedule rule",
+			"Rule requires 'frequency', 'every' or 'weekdays'.",
+		)
+	}
+
+	rrule := fmt.Sprintf("FREQ=%s;INTERVAL=%d", frequency, interval)
+	if len(weekdays) != 0 {
+		rrule += ";BYDAY=" + strings.Join(weekdays, ",")
+	}
+	return rrule, nil
+}
+
+// CompileScheduleStart builds dtstart from time of day 'HH:MM'. Empty at means midnight.
+func CompileScheduleStart(at string) (string, diag.Diagnostic) {
+	if at == "" {
+		at = "00:00"
+	}
+	timeOfDay, err := time.Parse("15:04", at)
+	if err != nil {
+		return "", diag.NewErrorDiagnostic(
+			"Invalid snapshot schedule time of day",
+			fmt.Sprintf("Time of day '%s' is invalid. It must be 'HH:MM' in 24-hour format, e.g. '02:30'", at),
+		)
+	}
+	return fmt.Sprintf("%s %s", SCHEDULE_DEFAULT_START_DATE, timeOfDay.Format("15:04:05")), nil
+}
+
+func ValidateScheduleAt(at string) diag.Diagnostic {
+	_, d := CompileScheduleStart(at)
+	return d
+}
+
+// ValidateScheduleStartTimestamp accepts cluster local '2010-01-01 00:00:00' or RFC3339 with timezone.
+func ValidateScheduleStartTimestamp(timestamp string) diag.Diagnostic {
+	if _, err := time.Parse(SCHEDULE_START_TIMESTAMP_FORMAT, timestamp); err == nil {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339, timestamp); err == nil {
+		return nil
+	}
+	return diag.NewErrorDiagnostic(
+		"Invalid snapshot schedule start timestamp",
+		fmt.Sprintf("Start timestamp '%s' is invalid. It must be cluster local time '2010-01-01 00:00:00' or RFC3339 with timezone '2010-01-01T00:00:00Z'", timestamp),
+	)
+}
+
+// IsScheduleStartInClusterTimezone is true for start timestamps without explicit timezone.
+func IsScheduleStartInClusterTimezone(timestamp string) bool {
+	_, err := time.Parse(time.RFC3339, timestamp)
+	return err != nil
+}
+
+// ScheduleStartToClusterLocal converts RFC3339 start timestamp to HC3 dtstart in cluster timezone.
+// Timestamps without timezone are already cluster local and are returned unchanged.
+func ScheduleStartToClusterLocal(timestamp string, clusterLocation *time.Location) string {
+	parsed, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return timestamp
+	}
+	return parsed.In(clusterLocation).Format(SCHEDULE_START_TIMESTAMP_FORMAT)
+}
+
+// GetClusterLocation returns cluster timezone, used to interpret snapshot schedule dtstart.
+func GetClusterLocation(restClient RestClient) (*time.Location, diag.Diagnostic) {
+	timezones := restClient.ListRecords(
+		"/rest/v1/TimeZone",
+		map[string]any{},
+		-1,
+		false,
+	)
+	if len(timezones) == 0 {
+		return nil, diag.NewErrorDiagnostic(
+			"Cluster timezone not found",
+			"HC3 did not return cluster timezone, needed to convert snapshot schedule start timestamp. Use cluster local timestamp '2010-01-01 00:00:00' instead.",
+		)
+	}
+	timezone := AnyToString(timezones[0]["timeZone"])
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, diag.NewErrorDiagnostic(
+			"Unknown cluster timezone",
+			fmt.Sprintf("Cluster timezone '%s' is unknown: %s", timezone, err.Error()),
+		)
+	}
+	return location, nil
+}
@@ -59,10 +59,25 @@ func UploadVirtualDisk(
 	name string,
 	sourceURL string,
 	ctx context.Context,
-) (string, *map[string]any, diag.Diagnostic) {
-	var binaryData []byte
-	var err error
+) (string, *map[string]any, *ImageInfo, diag.Diagnostic) {
+	// Reject wrong or corrupt images before fetching them
+	header, footer, size, sniffed, err := ReadImageHeader(sourceURL)
+	if err != nil {
+		return "", nil, nil, diag.NewErrorDiagnostic(
+			"Couldn't fetch virtual disk from source",
+			fmt.Sprintf("Couldn't fetch virtual disk from source '%s': %s", sourceURL, err.Error()),
+		)
+	}
+	var imageInfo ImageInfo
+	if sniffed {
+		var diagFormat diag.Diagnostic
+		imageInfo, diagFormat = detectUploadImageFormat(name, sourceURL, header, footer, size)
+		if diagFormat != nil {
+			return "", nil, nil, diagFormat
+		}
+	}
 
+	var binaryData []byte
 	if strings.Contains(sourceURL, "http") {
 		binaryData, err = FetchFileBinaryFromURL(sourceURL)
 	} else if strings.Contains(sourceURL, "file:///") {
@@ -72,15 +87,30 @@ func UploadVirtualDisk(
 	}
 
 	if err != nil {
-		return "", nil, diag.NewErrorDiagnostic(
+		return "", nil, nil, diag.NewErrorDiagnostic(
 			"Couldn't fetch virtual disk from source",
 			fmt.Sprintf("Couldn't fetch virtual disk from source '%s': %s", sourceURL, err.Error()),
 		)
 	}
 
+	if !sniffed {
+		// HTTP server does not support Range requests, check the image before uploading it
+		var diagFormat diag.Diagnostic
+		imageInfo, diagFormat = detectUploadImageFormat(
+			name,
+			sourceURL,
+			binaryData[:min(len(binaryData), IMAGE_HEADER_SIZE)],
+			binaryData[max(0, len(binaryData)-IMAGE_FOOTER_SIZE):],
+			int64(len(binaryData)),
+		)
+		if diagFormat != nil {
+			return "", nil, nil, diagFormat
+		}
+	}
+
 	fileSize := len(binaryData)
 
-	tflog.Debug(ctx, fmt.Sprintf("TTRT Virtual Disk Upload: source_url=%s, file_size=%d", sourceURL, fileSize))
+	tflog.Debug(ctx, fmt.Sprintf("TTRT Virtual Disk Upload: source_url=%s, file_size=%d, format=%s, virtual_size=%d", sourceURL, fileSize, imageInfo.Format, imageInfo.VirtualSize))
 
 	taskTag, err := restClient.PutBinaryRecord(
 		fmt.Sprintf("/rest/v1/VirtualDisk/upload?filename=%s&filesize=%d", name, fileSize),
@@ -91,19 +121,31 @@ func UploadVirtualDisk(
 	)
 
 	if err != nil {
-		return "", nil, diag.NewWarningDiagnostic(
+		return "", nil, nil, diag.NewWarningDiagnostic(
 			"HC3 is receiving too many requests at the same time.",
 			fmt.Sprintf("Please retry apply after Terraform finishes it's current operation or consider using the `-parallelism=1` terraform option. HC3 response message: %v", err.Error()),
 		)
 	}
 	if taskTag == nil {
-		return "", nil, diag.NewErrorDiagnostic("Failed to upload virtual disk "+name+" from source "+sourceURL,
+		return "", nil, nil, diag.NewErrorDiagnostic("Failed to upload virtual disk "+name+" from source "+sourceURL,
 			"There was a problem uploading virtual disk "+name+" from source "+sourceURL+", check input parameters")
 	}
 	taskTag.WaitTask(restClient, ctx)
 	vdUUID := taskTag.CreatedUUID
 	vd := GetVirtualDiskByUUID(restClient, vdUUID)
-	return vdUUID, vd, nil
+	return vdUUID, vd, &imageInfo, nil
+}
+
+// detectUploadImageFormat detects the image format, and checks it matches the virtual disk name extension.
+func detectUploadImageFormat(name string, sourceURL string, header []byte, footer []byte, size int64) (ImageInfo, diag.Diagnostic) {
+	imageInfo, diagFormat := DetectImageFormatFromHeader(header, footer, size)
+	if diagFormat != nil {
+		return ImageInfo{}, diag.NewErrorDiagnostic(diagFormat.Summary(), fmt.Sprintf("Source '%s': %s", sourceURL, diagFormat.Detail()))
+	}
+	if diagExtension := ValidateImageExtension(name, imageInfo.Format); diagExtension != nil {
+		return ImageInfo{}, diagExtension
+	}
+	return imageInfo, nil
 }
 
 func AttachVirtualDisk(
@@ -126,3 +168,54 @@ func AttachVirtualDisk(
 	disk := GetDiskByUUID(restClient, diskUUID)
 	return diskUUID, *disk, nil
 }
+
+// CreateVirtualDiskFromBlockDevice converts VM disk blockDeviceUUID into a new virtual disk, on the cluster.
+// If snapshotUUID is not empty, the disk content from that VM snapshot is used.
+func CreateVirtualDiskFromBlockDevice(
+	restClient RestClient,
+	name string,
+	blockDeviceUUID string,
+	snapshotUUID string,
+	ctx context.Context,
+) (string, *map[string]any, diag.Diagnostic) {
+	payload := map[string]any{
+		"template": map[string]any{
+			"name": name,
+		},
+	}
+	if snapshotUUID != "" {
+		payload["snapUUID"] = snapshotUUID
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("TTRT Virtual Disk Convert: name=%s, block_device_uuid=%s, snapshot_uuid=%s", name, blockDeviceUUID, snapshotUUID))
+	taskTag, _, err := restClient.CreateRecord(
+		fmt.Sprintf("/rest/v1/VirDomainBlockDevice/%s/convert", blockDeviceUUID),
+		payload,
+		-1,
+	)
+	if taskTag == nil {
+		return "", nil, diag.NewErrorDiagnostic(
+			"Failed to create virtual disk "+name+" from disk "+blockDeviceUUID,
+			fmt.Sprintf("There was a problem creating virtual disk %s from disk %s, check input parameters. HC3 response message: %v", name, blockDeviceUUID, err),
+		)
+	}
+	taskTag.WaitTask(restClient, ctx)
+	vdUUID := taskTag.CreatedUUID
+	vd := GetVirtualDiskByUUID(restClient, vdUUID)
+	return vdUUID, vd, nil
+}
+
+// ValidateSnapshotHasDisk checks that VM snapshot contains disk blockDeviceUUID.
+func ValidateSnapshotHasDisk(snapshot map[string]any, blockDeviceUUID string) diag.Diagnostic {
+	domain, _ := snapshot["domain"].(map[string]any)
+	blockDevs, _ := domain["blockDevs"].([]any)
+	for _, blockDev := range blockDevs {
+		if device, ok := blockDev.(map[string]any); ok && AnyToStringOrEmpty(device["uuid"]) == blockDeviceUUID {
+			return nil
+		}
+	}
+	return diag.NewErrorDiagnostic(
+		"Disk not found in snapshot",
+		fmt.Sprintf("VM snapshot '%s' does not contain disk '%s'.", AnyToStringOrEmpty(snapshot["uuid"]), blockDeviceUUID),
+	)
+}
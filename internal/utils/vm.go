@@ -8,39 +8,13 @@ import (
 	"encoding/base64"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
-	FromHypercoreToTerraformPowerState = map[string]string{
-		"RUNNING":  "started",
-		"SHUTOFF":  "stopped",
-		"BLOCKED":  "blocked",
-		"PAUSED":   "paused",
-		"SHUTDOWN": "shutdown",
-		"CRASHED":  "crashed",
-	}
-
-	FromTerraformToHypercorePowerAction = map[string]string{
-		"start":    "START",
-		"shutdown": "SHUTDOWN",
-		"stop":     "STOP",
-		"reboot":   "REBOOT",
-		"reset":    "RESET",
-		"started":  "START",
-	}
-
-	FromTerraformPowerActionToTerraformPowerState = map[string]string{
-		"start":    "started",
-		"shutdown": "stopped",
-		"stop":     "stopped",
-		"reboot":   "started",
-		"reset":    "started",
-		"started":  "started",
-	}
-
 	RebootLookup = map[string]bool{
 		"description": false,
 		"tags":        false,
@@ -282,8 +256,13 @@ func (vc *VM) SetVMParams(restClient RestClient, ctx context.Context) (bool, boo
 		}
 	}
 
-	if vc.powerState != nil {
-		if *vc.powerState != "shutdown" && *vc.powerState != "stop" {
+	if restartAction, ok := vc.restartPowerAction(); ok {
+		if powerState, ok := changedParams["powerState"]; ok && powerState {
+			ignoreRepeatedRequest := true
+			vc.UpdatePowerState(*vm, restClient, restartAction, ignoreRepeatedRequest, ctx)
+		}
+	} else if desiredPowerState, ok := vc.desiredPowerState(); ok {
+		if desiredPowerState != POWER_STATE_SHUTOFF {
 			vc.PowerUp(*vm, restClient, ctx)
 		}
 
@@ -292,7 +271,7 @@ func (vc *VM) SetVMParams(restClient RestClient, ctx context.Context) (bool, boo
 			vc.UpdatePowerState(
 				*vm,
 				restClient,
-				*vc.powerState,
+				GetNeededActionForState(desiredPowerState, false),
 				ignoreRepeatedRequest,
 				ctx,
 			)
@@ -314,6 +293,25 @@ func (vc *VM) SetVMParams(restClient RestClient, ctx context.Context) (bool, boo
 	return changed, vc.WasRebooted(), diff
 }
 
+// desiredPowerState returns the canonical power state requested for the VM, see NormalizePowerState.
+func (vc *VM) desiredPowerState() (string, bool) {
+	if vc.powerState == nil {
+		return "", false
+	}
+	return NormalizePowerState(*vc.powerState)
+}
+
+// restartPowerAction returns REBOOT or RESET, if that power action was requested for the VM.
+// Restart changes the VM in any power state, so it is always sent.
+func (vc *VM) restartPowerAction() (string, bool) {
+	if vc.powerState == nil {
+		return "", false
+	}
+	action := strings.ToUpper(*vc.powerState)
+	return action, ALLOWED_RESTART_METHODS[action]
+}
+
+// UpdatePowerState sends HC3 power action requestedPowerAction (START, SHUTDOWN, STOP, REBOOT or RESET).
 func (vc *VM) UpdatePowerState(
 	vm map[string]any,
 	restClient RestClient,
@@ -335,31 +333,31 @@ func (vc *VM) UpdatePowerState(
 	tflog.Debug(ctx, fmt.Sprintf("Requested power action: %s\n", requestedPowerAction))
 
 	switch requestedPowerAction {
-	case "start":
+	case "START":
 		if vc._wasStartTried {
 			panicOrIgnoreRepeatedRequest("VM _wasStartTried already set")
 			return
 		}
 		vc._wasStartTried = true
-	case "shutdown":
+	case "SHUTDOWN":
 		if vc._wasNiceShutdownTried {
 			panicOrIgnoreRepeatedRequest("VM _wasNiceShutdownTried already set")
 			return
 		}
 		vc._wasNiceShutdownTried = true
-	case "stop":
+	case "STOP":
 		if vc._wasForceShutdownTried {
 			panicOrIgnoreRepeatedRequest("VM _wasForceShutdownTried already set")
 			return
 		}
 		vc._wasForceShutdownTried = true
-	case "reboot":
+	case "REBOOT":
 		if vc._wasRebootTried {
 			panicOrIgnoreRepeatedRequest("VM _wasRebootTried already set")
 			return
 		}
 		vc._wasRebootTried = true
-	case "reset":
+	case "RESET":
 		if vc._wasResetTried {
 			panicOrIgnoreRepeatedRequest("VM _wasResetTried already set")
 			return
@@ -372,7 +370,7 @@ func (vc *VM) UpdatePowerState(
 		[]map[string]any{
 			{
 				"virDomainUUID": vm["uuid"],
-				"actionType":    FromTerraformToHypercorePowerAction[requestedPowerAction],
+				"actionType":    requestedPowerAction,
 				"cause":         "INTERNAL",
 			},
 		},
@@ -380,7 +378,7 @@ func (vc *VM) UpdatePowerState(
 	)
 
 	if err != nil {
-		if requestedPowerAction != "reset" {
+		if requestedPowerAction != "RESET" {
 			return
 		}
 		if responseStatus != 500 {
@@ -394,12 +392,12 @@ func (vc *VM) UpdatePowerState(
 
 func (vc *VM) PowerUp(vm map[string]any, restClient RestClient, ctx context.Context) {
 	if vc.WasShutdown() && vm["state"] == "RUNNING" {
-		vc.UpdatePowerState(vm, restClient, "start", false, ctx)
+		vc.UpdatePowerState(vm, restClient, "START", false, ctx)
 		return
 	}
 
-	if vc.powerState != nil && *vc.powerState == "start" {
-		vc.UpdatePowerState(vm, restClient, *vc.powerState, false, ctx)
+	if desiredPowerState, ok := vc.desiredPowerState(); ok && desiredPowerState == POWER_STATE_RUNNING {
+		vc.UpdatePowerState(vm, restClient, "START", false, ctx)
 	}
 }
 
@@ -432,7 +430,7 @@ func (vc *VM) WaitShutdown(vmUUID string, shutdownTimeout int, restClient RestCl
 	}
 
 	if (*vmFreshData)["state"] == "RUNNING" && !vc._wasNiceShutdownTried {
-		vc.UpdatePowerState(*vmFreshData, restClient, "shutdown", false, ctx)
+		vc.UpdatePowerState(*vmFreshData, restClient, "SHUTDOWN", false, ctx)
 		startTime := time.Now().Unix()
 		for {
 			vm := restClient.GetRecord(
@@ -518,7 +516,7 @@ func (vc *VM) ShutdownForced(vmUUID string, restClient RestClient, ctx context.C
 		return true
 	}
 
-	vc.UpdatePowerState(*vmFreshData, restClient, "stop", false, ctx)
+	vc.UpdatePowerState(*vmFreshData, restClient, "STOP", false, ctx)
 	return true
 }
 
@@ -637,14 +635,10 @@ func (vc *VM) GetChangedParams(ctx context.Context, vmFromClient map[string]any)
 	if vc.vcpu != nil {
 		changedParams["vcpu"] = *vc.vcpu != vmFromClient["numVCPU"]
 	}
-	if vc.powerState != nil {
-		requestedPowerAction := *vc.powerState
-		if requestedPowerAction == "reset" || requestedPowerAction == "reboot" {
-			changedParams["powerState"] = true
-		} else {
-			desiredPowerState := FromTerraformPowerActionToTerraformPowerState[requestedPowerAction]
-			changedParams["powerState"] = desiredPowerState != vmFromClient["state"]
-		}
+	if _, ok := vc.restartPowerAction(); ok {
+		changedParams["powerState"] = true
+	} else if desiredPowerState, ok := vc.desiredPowerState(); ok {
+		changedParams["powerState"] = desiredPowerState != PowerStateFromHypercore(vmFromClient["state"])
 	}
 	changedParams["snapshotScheduleUUID"] = vc.snapshotScheduleUUID != vmFromClient["snapshotScheduleUUID"]
 
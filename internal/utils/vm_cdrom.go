@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const CDROM_DISK_TYPE = "IDE_CDROM"
+
+// GetVMCdrom returns the VM CD-ROM drive in slot, or the CD-ROM drive with the lowest slot
+// if slot is nil. nil is returned if there is no such drive.
+func GetVMCdrom(vm map[string]any, slot *int64) map[string]any {
+	var found map[string]any
+	for _, blockDev := range AnyToListOfMap(vm["blockDevs"]) {
+		if AnyToString(blockDev["type"]) != CDROM_DISK_TYPE {
+			continue
+		}
+		blockDevSlot := AnyToInteger64(blockDev["slot"])
+		if slot != nil {
+			if blockDevSlot == *slot {
+				return blockDev
+			}
+			continue
+		}
+		if found == nil || blockDevSlot < AnyToInteger64(found["slot"]) {
+			found = blockDev
+		}
+	}
+	return found
+}
+
+// CreateVMCdrom creates an empty CD-ROM drive. If slot is nil, HC3 picks the slot.
+func CreateVMCdrom(
+	restClient RestClient,
+	vmUUID string,
+	slot *int64,
+	ctx context.Context,
+) (string, map[string]any) {
+	payload := map[string]any{
+		"virDomainUUID": vmUUID,
+		"type":          CDROM_DISK_TYPE,
+		"capacity":      0,
+	}
+	if slot != nil {
+		payload["slot"] = *slot
+	}
+	return CreateDisk(restClient, payload, ctx)
+}
+
+// InsertISO inserts iso into CD-ROM drive. The ISO must be ready for insert.
+func InsertISO(
+	restClient RestClient,
+	cdromUUID string,
+	iso map[string]any,
+	ctx context.Context,
+) diag.Diagnostic {
+	if d := ValidateISOReadyForInsert(iso); d != nil {
+		return d
+	}
+	return UpdateDisk(restClient, cdromUUID, map[string]any{"path": AnyToString(iso["path"])}, ctx)
+}
+
+// EjectISO removes media from CD-ROM drive. The ISO itself is not modified.
+func EjectISO(
+	restClient RestClient,
+	cdromUUID string,
+	ctx context.Context,
+) diag.Diagnostic {
+	return UpdateDisk(restClient, cdromUUID, map[string]any{"path": ""}, ctx)
+}
+
+// ResolveISO finds an ISO by UUID or, if isoUUID is empty, by name.
+func ResolveISO(restClient RestClient, isoUUID string, isoName string) (*map[string]any, diag.Diagnostic) {
+	if isoUUID != "" {
+		iso := GetISOByUUID(restClient, isoUUID)
+		if iso == nil {
+			return nil, diag.NewErrorDiagnostic("ISO not found", fmt.Sprintf("ISO with UUID '%s' not found.", isoUUID))
+		}
+		return iso, nil
+	}
+	iso := GetISOByName(restClient, isoName)
+	if iso == nil {
+		return nil, diag.NewErrorDiagnostic("ISO not found", fmt.Sprintf("ISO with name '%s' not found.", isoName))
+	}
+	return iso, nil
+}
@@ -21,6 +21,18 @@ var ALLOWED_DISK_TYPES = map[string]bool{
 	"IDE_CDROM":   true,
 }
 
+var ALLOWED_DISK_CACHE_MODES = map[string]bool{
+	"WRITETHROUGH": true,
+	"WRITEBACK":    true,
+	"NONE":         true,
+}
+
+// ALLOWED_ON_INCOMPATIBLE_CHANGE are ways to handle disk changes HC3 can not do in place.
+var ALLOWED_ON_INCOMPATIBLE_CHANGE = map[string]bool{
+	"fail":    true,
+	"replace": true,
+}
+
 var FROM_HUMAN_PRIORITY_FACTOR = map[int64]int64{
 	0:  0,
 	1:  1,
@@ -72,7 +84,7 @@ func NewVMDisk(
 	var byteSize *float64
 	if _size != nil {
 		byteSize = new(float64)
-		*byteSize = *_size * 1000 * 1000 * 1000 // GB to B
+		*byteSize = float64(DiskSizeGBToBytes(*_size))
 	} else {
 		byteSize = nil
 	}
@@ -98,7 +110,7 @@ func UpdateVMDisk(
 	var byteSize *float64
 	if _size != nil {
 		byteSize = new(float64)
-		*byteSize = *_size * 1000 * 1000 * 1000 // GB to B
+		*byteSize = float64(DiskSizeGBToBytes(*_size))
 	} else {
 		byteSize = nil
 	}
@@ -181,7 +193,7 @@ func GetDiskByTypeAndSlot(hc3Disks []map[string]any, diskSlot int64, diskType st
 		hc3DiskUUID := AnyToString(hc3Disk["uuid"])
 		hc3DiskSlot := AnyToInteger64(hc3Disk["slot"])
 		hc3DiskType := AnyToString(hc3Disk["type"])
-		hc3DiskSize := AnyToFloat64(hc3Disk["capacity"]) / 1000 / 1000 / 1000 // B -> GB
+		hc3DiskSize := DiskSizeBytesToGB(AnyToInteger64(hc3Disk["capacity"]))
 
 		if hc3DiskSlot == diskSlot && hc3DiskType == diskType {
 			tflog.Debug(ctx, fmt.Sprintf("Got disk by slot and type: %v", hc3Disk))
@@ -204,7 +216,7 @@ func GetDiskByUUID(restClient RestClient, diskUUID string) *map[string]any {
 func BuildDiskPayload(diskType string, diskSizeGB float64) map[string]any {
 	return map[string]any{
 		"type":     diskType,
-		"capacity": diskSizeGB * 1000 * 1000 * 1000, // GB to B
+		"capacity": DiskSizeGBToBytes(diskSizeGB),
 	}
 }
 
@@ -262,6 +274,16 @@ func ValidateDiskFlashPriority(diskFlashPriority int64) diag.Diagnostic {
 	return nil
 }
 
+func ValidateDiskCacheMode(cacheMode string) diag.Diagnostic {
+	if !ALLOWED_DISK_CACHE_MODES[cacheMode] {
+		return diag.NewErrorDiagnostic(
+			"Invalid disk cache mode",
+			fmt.Sprintf("Disk cache mode '%s' not allowed. Allowed cache modes are: WRITETHROUGH, WRITEBACK, NONE", cacheMode),
+		)
+	}
+	return nil
+}
+
 func ValidateDiskType(diskType string, isoUUID string) diag.Diagnostic {
 	if !ALLOWED_DISK_TYPES[diskType] {
 		return diag.NewErrorDiagnostic(
@@ -293,19 +315,30 @@ func ValidateISOAttach(restClient RestClient, isoUUID string, isAttachingISO boo
 	return nil, nil
 }
 
-func ValidateDiskSize(diskUUID string, oldSize float64, newSize float64) diag.Diagnostic {
-	if newSize < oldSize {
+// Checks that disk type wasn't altered - HC3 can not change type of an existing disk.
+func ValidateDiskTypeUnchanged(diskUUID string, oldType string, newType string) diag.Diagnostic {
+	if oldType != newType {
 		return diag.NewErrorDiagnostic(
-			"Invalid disk size",
+			"Invalid disk type change",
 			fmt.Sprintf(
-				" can only be expanded. Use a larger size. %v GB > %v GB: diskUUID=%s",
-				newSize, oldSize, diskUUID,
+				" disk type can not be changed on an existing disk. %s -> %s: diskUUID=%s",
+				oldType, newType, diskUUID,
 			),
 		)
 	}
 	return nil
 }
 
+func ValidateOnIncompatibleChange(onIncompatibleChange string) diag.Diagnostic {
+	if !ALLOWED_ON_INCOMPATIBLE_CHANGE[onIncompatibleChange] {
+		return diag.NewErrorDiagnostic(
+			"Invalid on_incompatible_change",
+			fmt.Sprintf("Value '%s' not allowed. Allowed values are: fail, replace", onIncompatibleChange),
+		)
+	}
+	return nil
+}
+
 // Checks that source VM UUID wasn't altered during update.
 func ValidateDiskSourceVMUUIDUnchanged(diskUUID string, oldVMUUID string, newVMUUID string) diag.Diagnostic {
 	if oldVMUUID != newVMUUID {
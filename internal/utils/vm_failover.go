@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// GetFailoverSnapshot returns the replica VM snapshot to fail over from.
+// If snapshotUUID is empty, the most recent snapshot of the replica VM is used.
+func GetFailoverSnapshot(snapshots []map[string]any, replicaVMUUID string, snapshotUUID string) (map[string]any, diag.Diagnostic) {
+	replicaSnapshots := FilterVMSnapshots(snapshots, VMSnapshotFilter{VMUUID: replicaVMUUID})
+	if snapshotUUID == "" {
+		if len(replicaSnapshots) == 0 {
+			return nil, diag.NewErrorDiagnostic(
+				"No replicated snapshot",
+				fmt.Sprintf("Replica VM '%s' has no snapshot to fail over from. Wait until replication completes initial sync.", replicaVMUUID),
+			)
+		}
+		return replicaSnapshots[len(replicaSnapshots)-1], nil
+	}
+
+	for _, snapshot := range replicaSnapshots {
+		if AnyToString(snapshot["uuid"]) == snapshotUUID {
+			return snapshot, nil
+		}
+	}
+	return nil, diag.NewErrorDiagnostic(
+		"Replicated snapshot not found",
+		fmt.Sprintf("Snapshot '%s' is not a snapshot of replica VM '%s'.", snapshotUUID, replicaVMUUID),
+	)
+}
+
+// ValidateNicVlanMap checks keys of source VLAN to target VLAN map are VLAN numbers.
+func ValidateNicVlanMap(vlanMap map[string]int64) diag.Diagnostic {
+	for sourceVlan, targetVlan := range vlanMap {
+		vlan, err := strconv.ParseInt(sourceVlan, 10, 64)
+		for _, v := range []int64{vlan, targetVlan} {
+			if err != nil || v < 0 || v > 4094 {
+				return diag.NewErrorDiagnostic(
+					"Invalid NIC VLAN map",
+					fmt.Sprintf("VLAN map entry '%s' = %d is invalid. VLANs must be numbers between 0 and 4094.", sourceVlan, targetVlan),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// PlanNicVlanRemap returns new VLAN for each NIC UUID, for NICs with VLAN present in the map.
+func PlanNicVlanRemap(nics []map[string]any, vlanMap map[string]int64) map[string]int64 {
+	remap := map[string]int64{}
+	for _, nic := range nics {
+		vlan := strconv.FormatInt(AnyToInteger64(nic["vlan"]), 10)
+		if targetVlan, ok := vlanMap[vlan]; ok && targetVlan != AnyToInteger64(nic["vlan"]) {
+			remap[AnyToString(nic["uuid"])] = targetVlan
+		}
+	}
+	return remap
+}
+
+// CloneVMFromSnapshot clones the VM as it was in the snapshot. Returns UUID of the new VM.
+func CloneVMFromSnapshot(
+	restClient RestClient,
+	sourceVMUUID string,
+	snapshotUUID string,
+	name string,
+	ctx context.Context,
+) (string, diag.Diagnostic) {
+	payload := map[string]any{
+		"template": map[string]any{
+			"name": name,
+		},
+		"snapUUID": snapshotUUID,
+	}
+	taskTag, _, err := restClient.CreateRecord(
+		fmt.Sprintf("/rest/v1/VirDomain/%s/clone", sourceVMUUID),
+		payload,
+		-1,
+	)
+	if err != nil {
+		return "", diag.NewErrorDiagnostic(
+			"Couldn't clone VM from snapshot",
+			fmt.Sprintf("Cloning VM '%s' from snapshot '%s' failed. HC3 response message: %v", sourceVMUUID, snapshotUUID, err.Error()),
+		)
+	}
+
+	taskTag.WaitTask(restClient, ctx)
+	taskStatus := taskTag.GetStatus(restClient)
+	if taskStatus == nil || (*taskStatus)["state"] != "COMPLETE" {
+		return "", diag.NewErrorDiagnostic(
+			"Couldn't clone VM from snapshot",
+			fmt.Sprintf("Cloning VM '%s' from snapshot '%s' did not complete, task status: %v", sourceVMUUID, snapshotUUID, taskStatus),
+		)
+	}
+	return taskTag.CreatedUUID, nil
+}
+
+// RemapNicVlans moves NICs of the VM to target VLANs from the map.
+func RemapNicVlans(
+	restClient RestClient,
+	vmUUID string,
+	vlanMap map[string]int64,
+	ctx context.Context,
+) diag.Diagnostic {
+	vm, err := GetOneVMWithError(vmUUID, restClient)
+	if err != nil {
+		return diag.NewErrorDiagnostic("VM not found", err.Error())
+	}
+	remap := PlanNicVlanRemap(AnyToListOfMap((*vm)["netDevs"]), vlanMap)
+
+	nicUUIDs := []string{}
+	for nicUUID := range remap {
+		nicUUIDs = append(nicUUIDs, nicUUID)
+	}
+	slices.Sort(nicUUIDs)
+	for _, nicUUID := range nicUUIDs {
+		tflog.Info(ctx, fmt.Sprintf("TTRT RemapNicVlans: vm_uuid=%s, nic_uuid=%s, vlan=%d", vmUUID, nicUUID, remap[nicUUID]))
+		// VM must not start on the original VLAN, so failures are errors.
+		if d := UpdateNic(restClient, nicUUID, map[string]any{"vlan": remap[nicUUID]}, ctx); d != nil {
+			return diag.NewErrorDiagnostic(d.Summary(), d.Detail())
+		}
+	}
+	return nil
+}
+
+// StartFailoverVM starts the VM and waits until it is RUNNING, then waits bootDelay seconds
+// so VMs failed over after this one start once it had time to boot.
+func StartFailoverVM(
+	restClient RestClient,
+	vmUUID string,
+	bootDelay int64,
+	ctx context.Context,
+) diag.Diagnostic {
+	// VMs failed over after this one depend on it running, so failures are errors.
+	if d := ModifyVMPowerState(restClient, vmUUID, "START", ctx); d != nil {
+		return diag.NewErrorDiagnostic(d.Summary(), d.Detail())
+	}
+	if !waitVMPowerState(SHUTDOWN_TIMEOUT_SECONDS, POWER_STATE_RUNNING, vmUUID, restClient, ctx) {
+		return diag.NewErrorDiagnostic(
+			"Failover VM is not running",
+			fmt.Sprintf("Failover VM %s did not start in %d seconds.", vmUUID, SHUTDOWN_TIMEOUT_SECONDS),
+		)
+	}
+	if bootDelay > 0 {
+		tflog.Info(ctx, fmt.Sprintf("TTRT StartFailoverVM: vm_uuid=%s running, waiting %d seconds", vmUUID, bootDelay))
+		time.Sleep(time.Duration(bootDelay) * time.Second)
+	}
+	return nil
+}
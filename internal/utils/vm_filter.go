@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// VMFilter holds optional client side filters for VirDomain records.
+// Empty fields are ignored.
+type VMFilter struct {
+	Name                string
+	NamePrefix          string
+	NameRegex           string
+	DescriptionContains string
+	TagsAny             []string
+	TagsAll             []string
+	PowerState          string
+	NodeUUID            string
+
+	nameMatcher
+}
+
+// TagsCommaStringToList splits a comma separated HC3 tags string, skipping empty tags.
+func TagsCommaStringToList(tags string) []string {
+	tagsList := []string{}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag != "" {
+			tagsList = append(tagsList, tag)
+		}
+	}
+	return tagsList
+}
+
+// ValidateVMFilter validates the filter, and compiles it for Matches.
+func ValidateVMFilter(filter *VMFilter) diag.Diagnostic {
+	if filter.PowerState != "" {
+		if d := ValidateObservedPowerState(filter.PowerState); d != nil {
+			return d
+		}
+	}
+	if err := filter.compile(); err != nil {
+		return diag.NewErrorDiagnostic(
+			"Invalid name regex",
+			fmt.Sprintf("Name regex '%s' is invalid: %s", filter.NameRegex, err.Error()),
+		)
+	}
+	return nil
+}
+
+func (filter *VMFilter) compile() error {
+	return filter.compileName(filter.NameRegex)
+}
+
+// Matches panics if the filter is invalid, use ValidateVMFilter first.
+func (filter *VMFilter) Matches(vm map[string]any) bool {
+	if err := filter.compile(); err != nil {
+		panic(err)
+	}
+
+	if !filter.matchesName(AnyToString(vm["name"]), filter.Name, filter.NamePrefix) {
+		return false
+	}
+	if filter.DescriptionContains != "" && !strings.Contains(AnyToString(vm["description"]), filter.DescriptionContains) {
+		return false
+	}
+	if filter.PowerState != "" {
+		wantedState, _ := NormalizePowerState(filter.PowerState)
+		if PowerStateFromHypercore(vm["state"]) != wantedState {
+			return false
+		}
+	}
+	if filter.NodeUUID != "" && AnyToString(vm["nodeUUID"]) != filter.NodeUUID {
+		return false
+	}
+
+	vmTags := TagsCommaStringToList(AnyToString(vm["tags"]))
+	for _, tag := range filter.TagsAll {
+		if !slices.Contains(vmTags, tag) {
+			return false
+		}
+	}
+	if len(filter.TagsAny) > 0 {
+		hasAny := false
+		for _, tag := range filter.TagsAny {
+			if slices.Contains(vmTags, tag) {
+				hasAny = true
+				break
+			}
+		}
+		if !hasAny {
+			return false
+		}
+	}
+
+	return true
+}
+
+func FilterVMs(vms []map[string]any, filter VMFilter) []map[string]any {
+	filtered := []map[string]any{}
+	for _, vm := range vms {
+		if filter.Matches(vm) {
+			filtered = append(filtered, vm)
+		}
+	}
+	return filtered
+}
+
+// TierVMs splits VMs into tiers. Each tier in ordering lists VM UUIDs, and VMs are
+// put into the first tier listing them. VMs not listed in any tier form the last tier.
+// VMs in a tier are sorted by name, and empty tiers are dropped.
+func TierVMs(vms []map[string]any, ordering [][]string) [][]map[string]any {
+	sorted := slices.Clone(vms)
+	slices.SortStableFunc(sorted, func(a, b map[string]any) int {
+		return strings.Compare(AnyToString(a["name"]), AnyToString(b["name"]))
+	})
+
+	tiers := make([][]map[string]any, len(ordering)+1)
+	for _, vm := range sorted {
+		tierIndex := slices.IndexFunc(ordering, func(tier []string) bool {
+			return slices.Contains(tier, AnyToString(vm["uuid"]))
+		})
+		if tierIndex < 0 {
+			tierIndex = len(ordering)
+		}
+		tiers[tierIndex] = append(tiers[tierIndex], vm)
+	}
+	return slices.DeleteFunc(tiers, func(tier []map[string]any) bool {
+		return len(tier) == 0
+	})
+}
@@ -6,16 +6,63 @@ package utils
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// Canonical VM power states. These are the HC3 VirDomain states,
+// and are used as-is by all resources and data sources.
+const (
+	POWER_STATE_RUNNING  = "RUNNING"
+	POWER_STATE_BLOCKED  = "BLOCKED"
+	POWER_STATE_PAUSED   = "PAUSED"
+	POWER_STATE_SHUTDOWN = "SHUTDOWN"
+	POWER_STATE_SHUTOFF  = "SHUTOFF"
+	POWER_STATE_CRASHED  = "CRASHED"
+)
+
+// ALL_POWER_STATES lists every power state HC3 can report for a VM.
+var ALL_POWER_STATES = []string{
+	POWER_STATE_RUNNING,
+	POWER_STATE_BLOCKED,
+	POWER_STATE_PAUSED,
+	POWER_STATE_SHUTDOWN,
+	POWER_STATE_SHUTOFF,
+	POWER_STATE_CRASHED,
+}
+
+// ALLOWED_POWER_STATES are power states that can be requested with a power action.
 var ALLOWED_POWER_STATES = map[string]bool{
-	"RUNNING": true,
-	"PAUSED":  true,
-	"SHUTOFF": true,
+	POWER_STATE_RUNNING: true,
+	POWER_STATE_PAUSED:  true,
+	POWER_STATE_SHUTOFF: true,
+}
+
+// POWER_STATE_ALIASES maps legacy (Ansible-like) power state names to canonical power states.
+// Legacy "shutdown" is a request to power off, so it is SHUTOFF, while "SHUTDOWN" is the state of a VM shutting down.
+var POWER_STATE_ALIASES = map[string]string{
+	"started":  POWER_STATE_RUNNING,
+	"start":    POWER_STATE_RUNNING,
+	"blocked":  POWER_STATE_BLOCKED,
+	"paused":   POWER_STATE_PAUSED,
+	"shutdown": POWER_STATE_SHUTOFF,
+	"stopped":  POWER_STATE_SHUTOFF,
+	"stop":     POWER_STATE_SHUTOFF,
+	"crashed":  POWER_STATE_CRASHED,
+}
+
+// POWER_STATE_AFTER_ACTION is the power state a VM ends up in after a successful HC3 power action.
+var POWER_STATE_AFTER_ACTION = map[string]string{
+	"START":    POWER_STATE_RUNNING,
+	"SHUTDOWN": POWER_STATE_SHUTOFF,
+	"STOP":     POWER_STATE_SHUTOFF,
+	"REBOOT":   POWER_STATE_RUNNING,
+	"RESET":    POWER_STATE_RUNNING,
+	"PAUSE":    POWER_STATE_PAUSED,
 }
 
 var NEEDED_ACTION_FOR_POWER_STATE = map[string]string{
@@ -24,6 +71,13 @@ var NEEDED_ACTION_FOR_POWER_STATE = map[string]string{
 	"PAUSED":  "PAUSE",
 }
 
+// ALLOWED_RESTART_METHODS are power actions usable to restart a running VM.
+// REBOOT asks the guest OS to reboot, RESET is a hard reset.
+var ALLOWED_RESTART_METHODS = map[string]bool{
+	"REBOOT": true,
+	"RESET":  true,
+}
+
 func GetNeededActionForState(desiredState string, forceShutoff bool) string {
 	if forceShutoff {
 		return "STOP"
@@ -32,19 +86,126 @@ func GetNeededActionForState(desiredState string, forceShutoff bool) string {
 	return NEEDED_ACTION_FOR_POWER_STATE[desiredState]
 }
 
+// GetNeededActionsForTransition returns the power actions needed to move a VM
+// from currentState into desiredState. No actions are needed if the VM is already there.
+// A paused VM is resumed first, because it does not react to ACPI shutdown or a second pause.
+func GetNeededActionsForTransition(currentState string, desiredState string, forceShutoff bool) []string {
+	if currentState == desiredState {
+		return []string{}
+	}
+
+	switch desiredState {
+	case POWER_STATE_SHUTOFF:
+		if forceShutoff {
+			return []string{"STOP"}
+		}
+		if currentState == POWER_STATE_PAUSED {
+			return []string{"START", "SHUTDOWN"}
+		}
+		if currentState != POWER_STATE_RUNNING {
+			// CRASHED, BLOCKED or SHUTDOWN VM does not react to ACPI shutdown.
+			return []string{"STOP"}
+		}
+		return []string{"SHUTDOWN"}
+	case POWER_STATE_PAUSED:
+		if currentState != POWER_STATE_RUNNING {
+			return []string{"START", "PAUSE"}
+		}
+		return []string{"PAUSE"}
+	}
+
+	return []string{GetNeededActionForState(desiredState, forceShutoff)}
+}
+
 func ModifyVMPowerState(
 	restClient RestClient,
 	vmUUID string,
 	actionType string,
 	ctx context.Context,
 ) diag.Diagnostic {
+	diag := sendVMPowerAction(restClient, vmUUID, actionType, ctx)
+	if diag != nil {
+		return diag
+	}
+
+	// corner case. If actionType=SHUTDOWN, the taskTag is empty, and we need to manuall wait on state transition to happen.
+	// Say at most 300 seconds.
+	if actionType == "SHUTDOWN" {
+		waitVMPowerState(SHUTDOWN_TIMEOUT_SECONDS, POWER_STATE_SHUTOFF, vmUUID, restClient, ctx)
+	}
+
+	return nil
+}
+
+// ModifyVMPowerStateSteps performs power actions one after another, stopping at first failure.
+func ModifyVMPowerStateSteps(
+	restClient RestClient,
+	vmUUID string,
+	actionTypes []string,
+	ctx context.Context,
+) diag.Diagnostic {
+	for _, actionType := range actionTypes {
+		diag := ModifyVMPowerState(restClient, vmUUID, actionType, ctx)
+		if diag != nil {
+			return diag
+		}
+	}
+	return nil
+}
+
+// RestartVM restarts a running VM with HC3 power action restartMethod, REBOOT or RESET.
+// Then it waits up to waitTimeout seconds for the VM to be RUNNING again.
+func RestartVM(
+	restClient RestClient,
+	vmUUID string,
+	restartMethod string,
+	waitTimeout int32,
+	ctx context.Context,
+) diag.Diagnostic {
+	if d := sendVMPowerAction(restClient, vmUUID, restartMethod, ctx); d != nil {
+		return d
+	}
+	if !waitVMPowerState(waitTimeout, POWER_STATE_RUNNING, vmUUID, restClient, ctx) {
+		return diag.NewErrorDiagnostic(
+			"VM did not restart",
+			fmt.Sprintf("VM %s is not RUNNING %d seconds after %s.", vmUUID, waitTimeout, restartMethod),
+		)
+	}
+	return nil
+}
+
+func sendVMPowerAction(
+	restClient RestClient,
+	vmUUID string,
+	actionType string,
+	ctx context.Context,
+) diag.Diagnostic {
+	return sendVMPowerActions(restClient, []VMPowerAction{{VMUUID: vmUUID, ActionType: actionType}}, ctx)
+}
+
+// VMPowerAction is a single element of /rest/v1/VirDomain/action payload.
+type VMPowerAction struct {
+	VMUUID     string
+	ActionType string
+}
+
+// sendVMPowerActions sends all actions in a single request, so HC3 performs them in one task.
+func sendVMPowerActions(
+	restClient RestClient,
+	actions []VMPowerAction,
+	ctx context.Context,
+) diag.Diagnostic {
+	if len(actions) == 0 {
+		return nil
+	}
 
-	payload := []map[string]any{
-		{
-			"virDomainUUID": vmUUID,
-			"actionType":    actionType,
+	payload := []map[string]any{}
+	for _, action := range actions {
+		payload = append(payload, map[string]any{
+			"virDomainUUID": action.VMUUID,
+			"actionType":    action.ActionType,
 			"cause":         "INTERNAL",
-		},
+		})
 	}
 	taskTag, _, err := restClient.CreateRecordWithList(
 		"/rest/v1/VirDomain/action",
@@ -53,23 +214,138 @@ func ModifyVMPowerState(
 	)
 
 	if err != nil {
-		return diag.NewWarningDiagnostic(
-			"HC3 is receiving too many requests at the same time.",
-			fmt.Sprintf("Please retry apply after Terraform finishes it's current operation. HC3 response message: %v", err.Error()),
+		return diag.NewErrorDiagnostic(
+			"Couldn't send VM power actions",
+			fmt.Sprintf("HC3 rejected power actions %v, VMs were not changed. Please retry apply. HC3 response message: %v", actions, err.Error()),
 		)
 	}
 
 	taskTag.WaitTask(restClient, ctx)
 
-	// corner case. If actionType=SHUTDOWN, the taskTag is empty, and we need to manuall wait on state transition to happen.
-	// Say at most 300 seconds.
-	if actionType == "SHUTDOWN" {
-		waitVMPowerState(300, "SHUTOFF", vmUUID, restClient, ctx)
+	return nil
+}
+
+// ModifyVMsPowerState moves all VMs into desiredState, tier by tier.
+// A tier is started only after all VMs of the previous tier reached desiredState.
+// VMs of a tier are processed in batches of batchSize VMs (0 means the whole tier in one batch).
+// Each step of a batch is sent as one /rest/v1/VirDomain/action request, and its VMs are awaited together.
+// There is a staggerDelay seconds pause between batches, and between tiers.
+func ModifyVMsPowerState(
+	restClient RestClient,
+	tiers [][]string,
+	desiredState string,
+	forceShutoff bool,
+	batchSize int,
+	staggerDelay int,
+	ctx context.Context,
+) diag.Diagnostic {
+	firstBatch := true
+	for tierIndex, tier := range tiers {
+		tierBatchSize := batchSize
+		if tierBatchSize <= 0 {
+			tierBatchSize = len(tier)
+		}
+
+		for batchStart := 0; batchStart < len(tier); batchStart += tierBatchSize {
+			if !firstBatch && staggerDelay > 0 {
+				tflog.Info(ctx, fmt.Sprintf("TTRT ModifyVMsPowerState: waiting %d seconds before next batch", staggerDelay))
+				time.Sleep(time.Duration(staggerDelay) * time.Second)
+			}
+			firstBatch = false
+
+			batch := tier[batchStart:min(batchStart+tierBatchSize, len(tier))]
+			diag := modifyVMsPowerStateBatch(restClient, batch, desiredState, forceShutoff, ctx)
+			if diag != nil {
+				return diag
+			}
+			tflog.Info(ctx, fmt.Sprintf("TTRT ModifyVMsPowerState: tier=%d batch_start=%d done", tierIndex, batchStart))
+		}
+
+		if tierIndex == len(tiers)-1 {
+			break
+		}
+		pending := waitVMsPowerState(SHUTDOWN_TIMEOUT_SECONDS, desiredState, tier, restClient, ctx)
+		if len(pending) > 0 {
+			return diag.NewErrorDiagnostic(
+				fmt.Sprintf("Couldn't transition into the '%s' state.", desiredState),
+				fmt.Sprintf(
+					"VMs %s did not reach the '%s' state in %d seconds, remaining tiers were not processed.",
+					strings.Join(pending, ", "), desiredState, SHUTDOWN_TIMEOUT_SECONDS,
+				),
+			)
+		}
 	}
 
 	return nil
 }
 
+// modifyVMsPowerStateBatch sends needed power actions for all VMs in the batch, one step at a time.
+func modifyVMsPowerStateBatch(
+	restClient RestClient,
+	batch []string,
+	desiredState string,
+	forceShutoff bool,
+	ctx context.Context,
+) diag.Diagnostic {
+	vmActionTypes := map[string][]string{}
+	maxSteps := 0
+	for _, vmUUID := range batch {
+		currentState, diag := GetVMPowerState(vmUUID, restClient)
+		if diag != nil {
+			return diag
+		}
+		vmActionTypes[vmUUID] = GetNeededActionsForTransition(currentState, desiredState, forceShutoff)
+		maxSteps = max(maxSteps, len(vmActionTypes[vmUUID]))
+	}
+
+	for step := 0; step < maxSteps; step++ {
+		actions := []VMPowerAction{}
+		shutdownVMUUIDs := []string{}
+		for _, vmUUID := range batch {
+			if step < len(vmActionTypes[vmUUID]) {
+				actionType := vmActionTypes[vmUUID][step]
+				actions = append(actions, VMPowerAction{VMUUID: vmUUID, ActionType: actionType})
+				if actionType == "SHUTDOWN" {
+					shutdownVMUUIDs = append(shutdownVMUUIDs, vmUUID)
+				}
+			}
+		}
+		tflog.Info(ctx, fmt.Sprintf("TTRT ModifyVMsPowerState: step=%d actions=%v", step, actions))
+
+		diag := sendVMPowerActions(restClient, actions, ctx)
+		if diag != nil {
+			return diag
+		}
+		// SHUTDOWN task completes before VM is SHUTOFF, see ModifyVMPowerState.
+		waitVMsPowerState(SHUTDOWN_TIMEOUT_SECONDS, POWER_STATE_SHUTOFF, shutdownVMUUIDs, restClient, ctx)
+	}
+
+	return nil
+}
+
+// waitVMsPowerState polls all VMs together until they are in desiredPowerState, or waitTimeout seconds pass.
+// It returns UUIDs of VMs which are not in desiredPowerState.
+func waitVMsPowerState(waitTimeout int32, desiredPowerState string, vmUUIDs []string, restClient RestClient, ctx context.Context) []string {
+	startTime := time.Now().Unix()
+	pending := slices.Clone(vmUUIDs)
+	for {
+		pending = slices.DeleteFunc(pending, func(vmUUID string) bool {
+			vmPowerState, _ := GetVMPowerState(vmUUID, restClient)
+			return vmPowerState == desiredPowerState
+		})
+		if len(pending) == 0 {
+			return pending
+		}
+		tflog.Info(ctx, fmt.Sprintf("TTRT waitVMsPowerState %v != %v", pending, desiredPowerState))
+
+		duration := time.Now().Unix() - startTime
+		if duration >= int64(waitTimeout) {
+			return pending
+		}
+		time.Sleep(10 * time.Second)
+	}
+}
+
 func waitVMPowerState(waitTimeout int32, desiredPowerState string, vmUUID string, restClient RestClient, ctx context.Context) bool {
 	startTime := time.Now().Unix()
 	for {
@@ -97,7 +373,7 @@ func GetVMPowerState(vmUUID string, restClient RestClient) (string, diag.Diagnos
 		)
 	}
 
-	powerState := AnyToString((*vm)["state"])
+	powerState := PowerStateFromHypercore((*vm)["state"])
 
 	return powerState, nil
 }
@@ -112,16 +388,68 @@ func GetVMDesiredState(vmUUID string, restClient RestClient) (string, diag.Diagn
 		)
 	}
 
-	powerState := AnyToString((*vm)["desiredDisposition"])
+	powerState := PowerStateFromHypercore((*vm)["desiredDisposition"])
 
 	return powerState, nil
 }
 
+// NormalizePowerState converts a power state (canonical, lowercase or legacy alias)
+// into the canonical power state. Returns false if the power state is unknown.
+func NormalizePowerState(state string) (string, bool) {
+	// Legacy names are lowercase, and take precedence over canonical states in other case.
+	if aliasedState, ok := POWER_STATE_ALIASES[state]; ok {
+		return aliasedState, true
+	}
+	upperState := strings.ToUpper(state)
+	for _, knownState := range ALL_POWER_STATES {
+		if upperState == knownState {
+			return knownState, true
+		}
+	}
+	if aliasedState, ok := POWER_STATE_ALIASES[strings.ToLower(state)]; ok {
+		return aliasedState, true
+	}
+	return state, false
+}
+
+// PowerStateFromHypercore converts HC3 "state" or "desiredDisposition" value into canonical power state.
+// Missing value is returned as "", unknown values are returned unchanged.
+func PowerStateFromHypercore(hc3State any) string {
+	state, _ := NormalizePowerState(AnyToStringOrEmpty(hc3State))
+	return state
+}
+
+// ValidatePowerState checks desiredState is a canonical power state that can be requested.
 func ValidatePowerState(desiredState string) diag.Diagnostic {
 	if !ALLOWED_POWER_STATES[desiredState] {
+		hintMsg := ""
+		if canonicalState, ok := NormalizePowerState(desiredState); ok && ALLOWED_POWER_STATES[canonicalState] {
+			hintMsg = fmt.Sprintf(" Use '%s' instead.", canonicalState)
+		}
+		return diag.NewErrorDiagnostic(
+			"Invalid power state",
+			fmt.Sprintf("Power state '%s' not allowed. Allowed states are: RUNNING, PAUSED, SHUTOFF.%s", desiredState, hintMsg),
+		)
+	}
+	return nil
+}
+
+func ValidateRestartMethod(restartMethod string) diag.Diagnostic {
+	if !ALLOWED_RESTART_METHODS[restartMethod] {
+		return diag.NewErrorDiagnostic(
+			"Invalid restart method",
+			fmt.Sprintf("Restart method '%s' not allowed. Allowed methods are: REBOOT, RESET", restartMethod),
+		)
+	}
+	return nil
+}
+
+// ValidateObservedPowerState checks state is any power state HC3 can report, including aliases.
+func ValidateObservedPowerState(state string) diag.Diagnostic {
+	if _, ok := NormalizePowerState(state); !ok {
 		return diag.NewErrorDiagnostic(
 			"Invalid power state",
-			fmt.Sprintf("Power state '%s' not allowed. Allowed states are: RUNNING, PAUSED, SHUTOFF", desiredState),
+			fmt.Sprintf("Power state '%s' is unknown. Known states are: %s", state, strings.Join(ALL_POWER_STATES, ", ")),
 		)
 	}
 	return nil
@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const GUEST_AGENT_STATE_AVAILABLE = "AVAILABLE"
+
+// GuestReadiness describes when a running VM's guest OS is considered ready.
+// Zero values disable the corresponding check.
+type GuestReadiness struct {
+	GuestAgent       bool
+	MinIpv4Addresses int64
+	Ipv4CIDR         string
+	Nic              GuestNic
+}
+
+// GuestNic selects NICs whose IPv4 addresses are counted. All set fields must match,
+// zero GuestNic matches all NICs. Several NICs can share a VLAN, UUID or MAC address selects one.
+type GuestNic struct {
+	UUID       string
+	MacAddress string
+	Vlan       *int64
+}
+
+func (nic GuestNic) IsSet() bool {
+	return nic.UUID != "" || nic.MacAddress != "" || nic.Vlan != nil
+}
+
+func (nic GuestNic) Matches(device map[string]any) bool {
+	if nic.UUID != "" && AnyToStringOrEmpty(device["uuid"]) != nic.UUID {
+		return false
+	}
+	if nic.MacAddress != "" && !MacAddressesEqual(AnyToStringOrEmpty(device["macAddress"]), nic.MacAddress) {
+		return false
+	}
+	if nic.Vlan != nil && (device["vlan"] == nil || AnyToInteger64(device["vlan"]) != *nic.Vlan) {
+		return false
+	}
+	return true
+}
+
+func ValidateGuestReadiness(readiness GuestReadiness) diag.Diagnostic {
+	if readiness.Nic.MacAddress != "" {
+		if d := ValidateMacAddress(readiness.Nic.MacAddress); d != nil {
+			return d
+		}
+	}
+	if readiness.MinIpv4Addresses < 0 {
+		return diag.NewErrorDiagnostic(
+			"Invalid guest readiness",
+			fmt.Sprintf("Minimal number of IPv4 addresses must not be negative, got %d.", readiness.MinIpv4Addresses),
+		)
+	}
+	if readiness.Ipv4CIDR != "" {
+		return ValidateIpv4CIDR(readiness.Ipv4CIDR)
+	}
+	return nil
+}
+
+func ValidateIpv4CIDR(cidr string) diag.Diagnostic {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return diag.NewErrorDiagnostic(
+			"Invalid IPv4 CIDR",
+			fmt.Sprintf("IPv4 CIDR '%s' is invalid: %s", cidr, err.Error()),
+		)
+	}
+	if !prefix.Addr().Is4() {
+		return diag.NewErrorDiagnostic(
+			"Invalid IPv4 CIDR",
+			fmt.Sprintf("'%s' is not an IPv4 CIDR.", cidr),
+		)
+	}
+	return nil
+}
+
+// GetVMIpv4Addresses returns IPv4 addresses reported by the guest agent, for NICs matching nic.
+func GetVMIpv4Addresses(vm map[string]any, nic GuestNic) []string {
+	allIpv4Addresses := []string{}
+	netDevs, _ := vm["netDevs"].([]any)
+	for _, netDev := range netDevs {
+		device, ok := netDev.(map[string]any)
+		if !ok {
+			continue
+		}
+		if !nic.Matches(device) {
+			continue
+		}
+		allIpv4Addresses = append(allIpv4Addresses, AnyToListOfStringsOrEmpty(device["ipv4Addresses"])...)
+	}
+	return allIpv4Addresses
+}
+
+// FilterIpv4AddressesByCIDR returns addresses inside cidr. Empty cidr matches all addresses.
+func FilterIpv4AddressesByCIDR(addresses []string, cidr string) []string {
+	if cidr == "" {
+		return addresses
+	}
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return []string{}
+	}
+	filtered := []string{}
+	for _, address := range addresses {
+		addr, err := netip.ParseAddr(address)
+		if err == nil && prefix.Contains(addr) {
+			filtered = append(filtered, address)
+		}
+	}
+	return filtered
+}
+
+// GetVMPrimaryIpv4 returns the first IPv4 address matching readiness NIC and CIDR, or "".
+func GetVMPrimaryIpv4(vm map[string]any, readiness GuestReadiness) string {
+	addresses := FilterIpv4AddressesByCIDR(GetVMIpv4Addresses(vm, readiness.Nic), readiness.Ipv4CIDR)
+	if len(addresses) == 0 {
+		return ""
+	}
+	return addresses[0]
+}
+
+// IsReady checks a VM against readiness. If VM is not ready, the reason is returned.
+func (readiness GuestReadiness) IsReady(vm map[string]any) (bool, string) {
+	if readiness.GuestAgent && AnyToStringOrEmpty(vm["guestAgentState"]) != GUEST_AGENT_STATE_AVAILABLE {
+		return false, fmt.Sprintf("guest agent state is '%s'", AnyToStringOrEmpty(vm["guestAgentState"]))
+	}
+
+	addresses := FilterIpv4AddressesByCIDR(GetVMIpv4Addresses(vm, readiness.Nic), readiness.Ipv4CIDR)
+	minAddresses := readiness.MinIpv4Addresses
+	if minAddresses == 0 && (readiness.Ipv4CIDR != "" || readiness.Nic.IsSet()) {
+		minAddresses = 1
+	}
+	if int64(len(addresses)) < minAddresses {
+		return false, fmt.Sprintf("found %d matching IPv4 addresses %v, need %d", len(addresses), addresses, minAddresses)
+	}
+
+	return true, ""
+}
+
+// WaitGuestReadiness waits up to waitTimeout seconds for a running VM to become ready.
+// VM which is not running can not become ready, and an error is returned.
+func WaitGuestReadiness(vmUUID string, readiness GuestReadiness, waitTimeout int32, restClient RestClient, ctx context.Context) diag.Diagnostic {
+	startTime := time.Now().Unix()
+	for {
+		vm, err := GetOneVMWithError(vmUUID, restClient)
+		if err != nil {
+			return diag.NewErrorDiagnostic("VM not found", err.Error())
+		}
+		powerState := PowerStateFromHypercore((*vm)["state"])
+		if powerState != POWER_STATE_RUNNING {
+			return diag.NewErrorDiagnostic(
+				"VM is not running",
+				fmt.Sprintf("Can't wait for VM %s guest OS, VM power state is '%s'. Guest readiness can be checked only for a running VM.", vmUUID, powerState),
+			)
+		}
+
+		ready, reason := readiness.IsReady(*vm)
+		if ready {
+			return nil
+		}
+		tflog.Info(ctx, fmt.Sprintf("TTRT WaitGuestReadiness: VM %s not ready, %s", vmUUID, reason))
+
+		duration := time.Now().Unix() - startTime
+		if duration >= int64(waitTimeout) {
+			return diag.NewErrorDiagnostic(
+				"VM guest is not ready",
+				fmt.Sprintf("VM %s guest OS did not become ready in %d seconds: %s", vmUUID, waitTimeout, reason),
+			)
+		}
+		time.Sleep(10 * time.Second)
+	}
+}
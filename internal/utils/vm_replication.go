@@ -6,7 +6,9 @@ package utils
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -25,6 +27,26 @@ func GetVMReplicationByUUID(
 	return replication
 }
 
+// GetVMReplicationBySourceVM returns replication configured for the source VM, or nil.
+// HC3 allows only one replication per source VM.
+func GetVMReplicationBySourceVM(
+	restClient RestClient,
+	sourceVmUUID string,
+) *map[string]any {
+	replications := restClient.ListRecords(
+		"/rest/v1/VirDomainReplication",
+		map[string]any{"sourceDomainUUID": sourceVmUUID},
+		-1.0,
+		false,
+	)
+	for _, replication := range replications {
+		if AnyToString(replication["sourceDomainUUID"]) == sourceVmUUID {
+			return &replication
+		}
+	}
+	return nil
+}
+
 func CreateVMReplication(
 	restClient RestClient,
 	sourceVmUUID string,
@@ -48,7 +70,7 @@ func CreateVMReplication(
 	if status == 400 && err != nil {
 		isReplicationError := strings.Contains(err.Error(), "Failed to create replication")
 		if isReplicationError {
-			return "", nil, diag.NewWarningDiagnostic(
+			return "", nil, diag.NewErrorDiagnostic(
 				"Couldn't create a VM replication",
 				fmt.Sprintf("VM replication failed. Source VM '%s' might already have configured replication. Response message: %s", sourceVmUUID, err.Error()),
 			)
@@ -112,3 +134,128 @@ func ValidateReplicationSourceVMUUIDUnchanged(replicationUUID string, oldVMUUID
 	}
 	return nil
 }
+
+const (
+	REPLICATION_STATE_DISABLED = "DISABLED"
+	REPLICATION_STATE_UNKNOWN  = "UNKNOWN"
+	REPLICATION_STATE_ERROR    = "ERROR"
+	REPLICATION_STATE_SYNCING  = "SYNCING"
+	REPLICATION_STATE_ACTIVE   = "ACTIVE"
+)
+
+// GetVMReplicationConnection returns remote cluster connection used by the replication, or nil.
+func GetVMReplicationConnection(restClient RestClient, replication map[string]any) *map[string]any {
+	connectionUUID := AnyToStringOrEmpty(replication["connectionUUID"])
+	if connectionUUID == "" {
+		return nil
+	}
+	return GetRemoteClusterConnectionByUUID(restClient, connectionUUID)
+}
+
+// GetReplicationProgressPercent returns percent of the current replication transfer.
+// The second value is false if HC3 does not report progress.
+func GetReplicationProgressPercent(replication map[string]any) (int64, bool) {
+	progress, ok := replication["progress"].(map[string]any)
+	if !ok || progress["percentComplete"] == nil {
+		return 0, false
+	}
+	return AnyToInteger64(progress["percentComplete"]), true
+}
+
+// GetReplicationLastSync returns creation time of the newest source VM snapshot that was fully replicated.
+// Snapshots taken before since, when the replication was set up, were never sent to the target and are ignored.
+// Snapshots are replicated in order, so while a transfer is in progress the newest
+// replicated snapshot is still being sent and the one before it is the last complete one.
+// The second value is false if no snapshot was replicated yet.
+func GetReplicationLastSync(replication map[string]any, snapshots []map[string]any, since time.Time) (time.Time, bool) {
+	replicated := []time.Time{}
+	for _, snapshot := range snapshots {
+		if AnyToString(snapshot["domainUUID"]) != AnyToString(replication["sourceDomainUUID"]) {
+			continue
+		}
+		if snapshot["replication"] != nil && !AnyToBool(snapshot["replication"]) {
+			continue
+		}
+		created := GetVMSnapshotCreated(snapshot)
+		if created.Before(since) {
+			continue
+		}
+		replicated = append(replicated, created)
+	}
+	slices.SortFunc(replicated, func(a, b time.Time) int { return a.Compare(b) })
+
+	if percent, ok := GetReplicationProgressPercent(replication); ok && percent < 100 && len(replicated) > 0 {
+		replicated = replicated[:len(replicated)-1]
+	}
+	if len(replicated) == 0 {
+		return time.Time{}, false
+	}
+	return replicated[len(replicated)-1], true
+}
+
+// GetReplicationState summarizes replication health as DISABLED, UNKNOWN, ERROR, SYNCING or ACTIVE.
+// connection is the remote cluster connection of the replication, nil if it was not found.
+// Snapshots reach the target only over a healthy connection, so replication is UNKNOWN without
+// the connection and ERROR if HC3 reports the connection is not healthy.
+// Otherwise replication is SYNCING until the first snapshot taken after since is fully replicated.
+func GetReplicationState(replication map[string]any, connection *map[string]any, snapshots []map[string]any, since time.Time) string {
+	if replication["enable"] != nil && !AnyToBool(replication["enable"]) {
+		return REPLICATION_STATE_DISABLED
+	}
+	if connection == nil {
+		return REPLICATION_STATE_UNKNOWN
+	}
+	if !IsRemoteClusterConnectionHealthy(*connection) {
+		return REPLICATION_STATE_ERROR
+	}
+	if _, ok := GetReplicationLastSync(replication, snapshots, since); !ok {
+		return REPLICATION_STATE_SYNCING
+	}
+	if percent, ok := GetReplicationProgressPercent(replication); ok && percent < 100 {
+		return REPLICATION_STATE_SYNCING
+	}
+	return REPLICATION_STATE_ACTIVE
+}
+
+func ListVMReplicationSnapshots(restClient RestClient, sourceVmUUID string) []map[string]any {
+	return restClient.ListRecords(
+		"/rest/v1/VirDomainSnapshot",
+		map[string]any{"domainUUID": sourceVmUUID},
+		-1.0,
+		false,
+	)
+}
+
+// WaitVMReplicationInitialSync waits up to waitTimeout seconds for the first snapshot taken after since to be fully replicated.
+func WaitVMReplicationInitialSync(restClient RestClient, replicationUUID string, since time.Time, waitTimeout int32, ctx context.Context) diag.Diagnostic {
+	startTime := time.Now().Unix()
+	for {
+		replication := GetVMReplicationByUUID(restClient, replicationUUID)
+		if replication == nil {
+			return diag.NewErrorDiagnostic("VM replication not found", fmt.Sprintf("VM replication not found - replicationUUID=%s", replicationUUID))
+		}
+		if replication := *replication; replication["enable"] != nil && !AnyToBool(replication["enable"]) {
+			return diag.NewErrorDiagnostic(
+				"VM replication is disabled",
+				fmt.Sprintf("Can't wait for VM replication %s initial sync, the replication is disabled.", replicationUUID),
+			)
+		}
+
+		snapshots := ListVMReplicationSnapshots(restClient, AnyToString((*replication)["sourceDomainUUID"]))
+		state := GetReplicationState(*replication, GetVMReplicationConnection(restClient, *replication), snapshots, since)
+		if _, ok := GetReplicationLastSync(*replication, snapshots, since); ok && (state == REPLICATION_STATE_SYNCING || state == REPLICATION_STATE_ACTIVE) {
+			return nil
+		}
+		percent, _ := GetReplicationProgressPercent(*replication)
+		tflog.Info(ctx, fmt.Sprintf("TTRT WaitVMReplicationInitialSync: replication %s not synced, state=%s, progress=%d%%", replicationUUID, state, percent))
+
+		duration := time.Now().Unix() - startTime
+		if duration >= int64(waitTimeout) {
+			return diag.NewErrorDiagnostic(
+				"VM replication initial sync is not complete",
+				fmt.Sprintf("VM replication %s did not complete initial sync in %d seconds, state is %s, progress is %d%%.", replicationUUID, waitTimeout, state, percent),
+			)
+		}
+		time.Sleep(10 * time.Second)
+	}
+}
@@ -6,6 +6,9 @@ package utils
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -66,6 +69,103 @@ func CreateVMSnapshot(
 	return snapUUID, *snapshot, nil
 }
 
+func UpdateVMSnapshot(
+	restClient RestClient,
+	snapUUID string,
+	payload map[string]any,
+	ctx context.Context,
+) diag.Diagnostic {
+
+	taskTag, err := restClient.UpdateRecord(
+		fmt.Sprintf("/rest/v1/VirDomainSnapshot/%s", snapUUID),
+		payload,
+		-1,
+		ctx,
+	)
+
+	if err != nil {
+		return diag.NewWarningDiagnostic(
+			"HC3 is receiving too many requests at the same time.",
+			fmt.Sprintf("Please retry apply after Terraform finishes it's current operation. HC3 response message: %v", err.Error()),
+		)
+	}
+
+	taskTag.WaitTask(restClient, ctx)
+
+	return nil
+}
+
+// ParseRetentionDuration is like time.ParseDuration, but also accepts whole days and weeks ("30d", "2w").
+func ParseRetentionDuration(value string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if count, found := strings.CutSuffix(value, suffix); found {
+			n, err := strconv.ParseInt(count, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+	return time.ParseDuration(value)
+}
+
+// ParseRetainUntil converts snapshot retain-until value to a Unix timestamp.
+// Value is either RFC3339 timestamp or a duration added to base (snapshot creation time).
+// Empty value means the snapshot is retained forever, and 0 is returned.
+func ParseRetainUntil(value string, base time.Time) (int64, diag.Diagnostic) {
+	if value == "" {
+		return 0, nil
+	}
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamp.Unix(), nil
+	}
+	duration, err := ParseRetentionDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, diag.NewErrorDiagnostic(
+			"Invalid snapshot retain until value",
+			fmt.Sprintf("Retain until '%s' is invalid. It must be RFC3339 timestamp (2030-01-31T00:00:00Z) or positive duration (12h, 30d, 2w).", value),
+		)
+	}
+	return base.Add(duration).Unix(), nil
+}
+
+// ValidateRetainUntil checks value can be parsed by ParseRetainUntil.
+// It does not depend on current time, so a config stays valid after a timestamp passes.
+func ValidateRetainUntil(value string) diag.Diagnostic {
+	_, d := ParseRetainUntil(value, time.Unix(0, 0))
+	return d
+}
+
+// ValidateRetainUntilNotPast checks value is not a RFC3339 timestamp before now.
+// Durations are relative to snapshot creation time, so they are always accepted.
+func ValidateRetainUntilNotPast(value string, now time.Time) diag.Diagnostic {
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err == nil && !timestamp.After(now) {
+		return diag.NewErrorDiagnostic(
+			"Invalid snapshot retain until value",
+			fmt.Sprintf("Retain until '%s' is in the past. HC3 would remove the snapshot right away.", value),
+		)
+	}
+	return nil
+}
+
+// FormatRetainUntil is inverse of ParseRetainUntil for HC3 reported timestamps, 0 is returned as "".
+func FormatRetainUntil(timestamp int64) string {
+	if timestamp == 0 {
+		return ""
+	}
+	return time.Unix(timestamp, 0).UTC().Format(time.RFC3339)
+}
+
+// GetVMSnapshotCreated returns snapshot creation time. Zero timestamp (Unix epoch) is returned
+// if HC3 did not report it, so ordering and created after/before filters are stable.
+func GetVMSnapshotCreated(snapshot map[string]any) time.Time {
+	if snapshot["timestamp"] == nil {
+		return time.Unix(0, 0)
+	}
+	return time.Unix(AnyToInteger64(snapshot["timestamp"]), 0)
+}
+
 func CreateVMSnapshotSchedule(
 	restClient RestClient,
 	payload map[string]any,
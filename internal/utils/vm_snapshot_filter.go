@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+var VM_SNAPSHOT_TYPES = []string{"USER", "AUTOMATED", "SUPPORT"}
+
+// VMSnapshotFilter holds optional client side filters for VirDomainSnapshot records.
+// Empty fields are ignored. CreatedAfter and CreatedBefore are RFC3339 timestamps.
+type VMSnapshotFilter struct {
+	VMUUID        string
+	Label         string
+	LabelRegex    string
+	Type          string
+	CreatedAfter  string
+	CreatedBefore string
+
+	// Matches the label. Timestamps are parsed by compile too, once per filter.
+	nameMatcher
+	createdAfter  time.Time
+	createdBefore time.Time
+}
+
+func ValidateVMSnapshotType(snapType string) diag.Diagnostic {
+	if slices.Contains(VM_SNAPSHOT_TYPES, snapType) {
+		return nil
+	}
+	return diag.NewErrorDiagnostic(
+		"Invalid VM snapshot type",
+		fmt.Sprintf("VM snapshot type '%s' is invalid. Type must be one of: %s", snapType, strings.Join(VM_SNAPSHOT_TYPES, ", ")),
+	)
+}
+
+func ValidateRFC3339Timestamp(timestamp string) diag.Diagnostic {
+	if _, err := time.Parse(time.RFC3339, timestamp); err != nil {
+		return diag.NewErrorDiagnostic(
+			"Invalid timestamp",
+			fmt.Sprintf("Timestamp '%s' is invalid. It must be RFC3339 timestamp, e.g. '2030-01-31T00:00:00Z'", timestamp),
+		)
+	}
+	return nil
+}
+
+// ValidateVMSnapshotFilter validates the filter, and compiles it for Matches.
+func ValidateVMSnapshotFilter(filter *VMSnapshotFilter) diag.Diagnostic {
+	if filter.Type != "" {
+		if d := ValidateVMSnapshotType(filter.Type); d != nil {
+			return d
+		}
+	}
+	for _, timestamp := range []string{filter.CreatedAfter, filter.CreatedBefore} {
+		if timestamp != "" {
+			if d := ValidateRFC3339Timestamp(timestamp); d != nil {
+				return d
+			}
+		}
+	}
+	// Timestamps are valid, so only the label regex can fail to compile
+	if err := filter.compile(); err != nil {
+		return diag.NewErrorDiagnostic(
+			"Invalid label regex",
+			fmt.Sprintf("Label regex '%s' is invalid: %s", filter.LabelRegex, err.Error()),
+		)
+	}
+	return nil
+}
+
+func (filter *VMSnapshotFilter) compile() error {
+	if filter.compiled {
+		return nil
+	}
+	var err error
+	if filter.CreatedAfter != "" {
+		if filter.createdAfter, err = time.Parse(time.RFC3339, filter.CreatedAfter); err != nil {
+			return err
+		}
+	}
+	if filter.CreatedBefore != "" {
+		if filter.createdBefore, err = time.Parse(time.RFC3339, filter.CreatedBefore); err != nil {
+			return err
+		}
+	}
+	return filter.compileName(filter.LabelRegex)
+}
+
+// Matches panics if the filter is invalid, use ValidateVMSnapshotFilter first.
+func (filter *VMSnapshotFilter) Matches(snapshot map[string]any) bool {
+	if err := filter.compile(); err != nil {
+		panic(err)
+	}
+
+	if filter.VMUUID != "" && AnyToString(snapshot["domainUUID"]) != filter.VMUUID {
+		return false
+	}
+	if !filter.matchesName(AnyToString(snapshot["label"]), filter.Label, "") {
+		return false
+	}
+	if filter.Type != "" && AnyToString(snapshot["type"]) != filter.Type {
+		return false
+	}
+	created := GetVMSnapshotCreated(snapshot)
+	if filter.CreatedAfter != "" && !created.After(filter.createdAfter) {
+		return false
+	}
+	if filter.CreatedBefore != "" && !created.Before(filter.createdBefore) {
+		return false
+	}
+	return true
+}
+
+// FilterVMSnapshots returns snapshots matching the filter, sorted by creation time, oldest first.
+func FilterVMSnapshots(snapshots []map[string]any, filter VMSnapshotFilter) []map[string]any {
+	filtered := []map[string]any{}
+	for _, snapshot := range snapshots {
+		if filter.Matches(snapshot) {
+			filtered = append(filtered, snapshot)
+		}
+	}
+	slices.SortStableFunc(filtered, func(a, b map[string]any) int {
+		return cmp.Or(
+			GetVMSnapshotCreated(a).Compare(GetVMSnapshotCreated(b)),
+			strings.Compare(AnyToString(a["uuid"]), AnyToString(b["uuid"])),
+		)
+	})
+	return filtered
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// FilterVMSnapshotSchedules returns schedules with the name, sorted by name and UUID.
+// Empty name returns all schedules.
+func FilterVMSnapshotSchedules(schedules []map[string]any, name string) []map[string]any {
+	filtered := []map[string]any{}
+	for _, schedule := range schedules {
+		if name == "" || AnyToString(schedule["name"]) == name {
+			filtered = append(filtered, schedule)
+		}
+	}
+	slices.SortStableFunc(filtered, func(a, b map[string]any) int {
+		if c := strings.Compare(AnyToString(a["name"]), AnyToString(b["name"])); c != 0 {
+			return c
+		}
+		return strings.Compare(AnyToString(a["uuid"]), AnyToString(b["uuid"]))
+	})
+	return filtered
+}
+
+// FindVMSnapshotScheduleForImport finds the schedule by UUID, or by name if no UUID matches.
+// HC3 does not require unique schedule names, so an ambiguous name is returned as an error.
+func FindVMSnapshotScheduleForImport(schedules []map[string]any, id string) (map[string]any, diag.Diagnostic) {
+	for _, schedule := range schedules {
+		if AnyToString(schedule["uuid"]) == id {
+			return schedule, nil
+		}
+	}
+
+	byName := FilterVMSnapshotSchedules(schedules, id)
+	if len(byName) == 0 {
+		return nil, diag.NewErrorDiagnostic(
+			"VM Schedule import error, schedule not found",
+			fmt.Sprintf("VM Schedule import, schedule not found - no schedule with UUID or name '%s'.", id),
+		)
+	}
+	if len(byName) > 1 {
+		uuids := []string{}
+		for _, schedule := range byName {
+			uuids = append(uuids, AnyToString(schedule["uuid"]))
+		}
+		return nil, diag.NewErrorDiagnostic(
+			"VM Schedule import error, schedule name is not unique",
+			fmt.Sprintf("VM Schedule import, %d schedules are named '%s'. Import by UUID instead, one of: %s", len(byName), id, strings.Join(uuids, ", ")),
+		)
+	}
+	return byName[0], nil
+}